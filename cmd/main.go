@@ -2,12 +2,19 @@ package main
 
 import (
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/yasseryazid/technical-test/config"
 	"github.com/yasseryazid/technical-test/handlers"
 	"github.com/yasseryazid/technical-test/migrations"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/notifiers"
 	"github.com/yasseryazid/technical-test/repositories"
 	"github.com/yasseryazid/technical-test/routes"
+	"github.com/yasseryazid/technical-test/storage"
 	"github.com/yasseryazid/technical-test/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -23,10 +30,109 @@ func main() {
 	router := gin.Default()
 
 	taskRepo := repositories.NewTaskRepository()
+	projectRepo := repositories.NewProjectRepository()
+	labelRepo := repositories.NewLabelRepository()
+	workflowRepo := repositories.NewWorkflowRepository()
+	seriesRepo := repositories.NewSeriesRepository()
+	workspaceRepo := repositories.NewWorkspaceRepository()
 	taskService := usecases.NewTaskService(taskRepo)
+	taskService.Projects = projectRepo
+	taskService.Labels = labelRepo
+	taskService.Workflows = workflowRepo
+	taskService.Series = seriesRepo
+	taskService.Users = repositories.NewUserRepository()
+	taskService.Workspaces = workspaceRepo
+	activityRepo := repositories.NewActivityRepository()
+	taskService.Activity = activityRepo
+	taskService.Revisions = repositories.NewRevisionRepository()
+	taskService.Imports = repositories.NewImportJobRepository()
+	taskService.FailInterruptedImports()
+	go taskService.RunRecurrenceScheduler(time.Hour)
+	trashRetention := usecases.DefaultTrashRetention
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		trashRetention = time.Duration(days) * 24 * time.Hour
+	}
+	go taskService.RunTrashPurger(trashRetention, time.Hour)
+	archiveAge := usecases.DefaultAutoArchiveAge
+	if days, err := strconv.Atoi(os.Getenv("AUTO_ARCHIVE_DAYS")); err == nil {
+		archiveAge = time.Duration(days) * 24 * time.Hour
+	}
+	if archiveAge > 0 {
+		go taskService.RunAutoArchiver(archiveAge, time.Hour)
+	}
 	taskHandler := &handlers.TaskHandler{Service: taskService}
 
-	routes.RegisterAPIRoutes(router, taskHandler)
+	reminderRepo := repositories.NewReminderRepository()
+	reminderService := usecases.NewReminderService(reminderRepo, repositories.NewReminderQueue(), taskRepo)
+	reminderService.Users = taskService.Users
+	reminderService.RegisterChannel(models.ChannelWebhook, notifiers.NewWebhookChannel())
+	reminderService.RegisterChannel(models.ChannelInApp, notifiers.NewInAppChannel(reminderRepo))
+	if email := notifiers.NewEmailChannel(); email != nil {
+		reminderService.RegisterChannel(models.ChannelEmail, email)
+	}
+	taskService.Reminders = reminderService
+	taskService.Assignments = reminderService
+	go reminderService.RunWorker(15 * time.Second)
+	reminderHandler := &handlers.ReminderHandler{Service: reminderService}
+
+	projectService := usecases.NewProjectService(projectRepo, taskService)
+	projectService.Workflows = workflowRepo
+	projectHandler := &handlers.ProjectHandler{Service: projectService}
+
+	labelService := usecases.NewLabelService(labelRepo)
+	labelHandler := &handlers.LabelHandler{Service: labelService}
+
+	workflowService := usecases.NewWorkflowService(workflowRepo)
+	workflowHandler := &handlers.WorkflowHandler{Service: workflowService}
+
+	workspaceService := usecases.NewWorkspaceService(workspaceRepo)
+	workspaceHandler := &handlers.WorkspaceHandler{Service: workspaceService}
+
+	commentService := usecases.NewCommentService(repositories.NewCommentRepository(), taskRepo)
+	commentService.Users = taskService.Users
+	commentService.Mentions = reminderService
+	commentService.Workspaces = workspaceRepo
+	commentHandler := &handlers.CommentHandler{Service: commentService}
+
+	fileStorage, err := storage.FromEnv()
+	if err != nil {
+		log.Fatalf("[X] Failed to set up attachment storage: %v", err)
+	}
+	attachmentService := usecases.NewAttachmentService(repositories.NewAttachmentRepository(), taskRepo, fileStorage)
+	if size, err := strconv.ParseInt(os.Getenv("ATTACHMENT_MAX_SIZE"), 10, 64); err == nil && size > 0 {
+		attachmentService.MaxSize = size
+	}
+	if types := os.Getenv("ATTACHMENT_ALLOWED_TYPES"); types != "" {
+		attachmentService.AllowedTypes = strings.Split(types, ",")
+	}
+	taskService.Files = attachmentService
+	attachmentHandler := &handlers.AttachmentHandler{Service: attachmentService}
+
+	activityService := usecases.NewActivityService(activityRepo, taskRepo)
+	activityHandler := &handlers.ActivityHandler{Service: activityService}
+
+	calendarService := usecases.NewCalendarService(taskService.Users, workspaceRepo, taskRepo)
+	calendarService.Cache = repositories.NewFeedCache()
+	taskService.Feeds = calendarService
+	calendarHandler := &handlers.CalendarHandler{Service: calendarService}
+
+	viewRepo := repositories.NewViewRepository()
+	viewService := usecases.NewViewService(viewRepo, taskService)
+	viewHandler := &handlers.ViewHandler{Service: viewService}
+
+	routes.RegisterAPIRoutes(router, routes.Handlers{
+		Task:       taskHandler,
+		View:       viewHandler,
+		Project:    projectHandler,
+		Label:      labelHandler,
+		Workflow:   workflowHandler,
+		Reminder:   reminderHandler,
+		Workspace:  workspaceHandler,
+		Comment:    commentHandler,
+		Attachment: attachmentHandler,
+		Activity:   activityHandler,
+		Calendar:   calendarHandler,
+	})
 
 	log.Println("[...] Server running on port 3000")
 	log.Fatal(router.Run(":3000"))
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityHandler struct {
+	Service *usecases.ActivityService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *ActivityHandler) service(c *gin.Context) *usecases.ActivityService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *ActivityHandler) GetTaskActivity(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	activities, err := h.service(c).GetTaskActivity(id)
+	if err != nil {
+		log.Printf("[X] Failed to fetch activity (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	localizeActivities(c, activities)
+	log.Printf("[V] Successfully fetched activity of task %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"activity": presenters.FormatActivities(activities)})
+}
+
+// GetAudit searches the activity of every task, in every workspace.
+func (h *ActivityHandler) GetAudit(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	filter := models.ActivityFilter{
+		TaskID:      parseUintQuery(c, "task_id"),
+		UserID:      parseUintQuery(c, "user_id"),
+		WorkspaceID: parseUintQuery(c, "workspace_id"),
+		Action:      c.Query("action"),
+		RequestID:   c.Query("request_id"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+	}
+
+	activities, total, err := h.Service.FindActivity(filter, page, limit)
+	if isClientError(err) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("[X] Failed to fetch audit log: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit log"})
+		return
+	}
+
+	localizeActivities(c, activities)
+	log.Printf("[V] Successfully fetched audit log (page %d, limit %d)\n", page, limit)
+	c.JSON(http.StatusOK, gin.H{
+		"activity": presenters.FormatActivities(activities),
+		"pagination": gin.H{
+			"current_page":  page,
+			"total_pages":   (total + limit - 1) / limit,
+			"total_entries": total,
+		},
+	})
+}
+
+func localizeActivities(c *gin.Context, activities []models.TaskActivity) {
+	location := userLocation(c)
+	for i := range activities {
+		activities[i].CreatedAt = activities[i].CreatedAt.In(location)
+	}
+}
+
+// parseUintQuery reads an optional numeric query parameter, 0 when missing or
+// invalid.
+func parseUintQuery(c *gin.Context, name string) uint {
+	value, err := strconv.ParseUint(c.Query(name), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(value)
+}
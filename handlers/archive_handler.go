@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *TaskHandler) ArchiveTask(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+func (h *TaskHandler) UnarchiveTask(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *TaskHandler) setArchived(c *gin.Context, archived bool) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var task *models.Task
+	if archived {
+		task, err = h.service(c).ArchiveTask(id)
+	} else {
+		task, err = h.service(c).UnarchiveTask(id)
+	}
+	if err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Archiving task failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	message := "Task unarchived successfully"
+	if archived {
+		message = "Task archived successfully"
+	}
+	localizeTask(c, task)
+	log.Printf("[V] %s: ID %d\n", message, id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"task":    presenters.FormatTask(task),
+	})
+}
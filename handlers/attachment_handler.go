@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is room for the multipart headers around the file.
+const multipartOverhead = 1 << 20
+
+type AttachmentHandler struct {
+	Service *usecases.AttachmentService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *AttachmentHandler) service(c *gin.Context) *usecases.AttachmentService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service := h.service(c)
+	attachments, err := service.GetAttachments(id)
+	if err != nil {
+		respondAttachmentError(c, id, "Fetching attachments", err)
+		return
+	}
+
+	response := make([]presenters.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		formatted, err := h.formatAttachment(c, service, &attachments[i])
+		if err != nil {
+			respondAttachmentError(c, id, "Signing attachment URLs", err)
+			return
+		}
+		response = append(response, formatted)
+	}
+
+	log.Printf("[V] Successfully fetched attachments of task %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"attachments": response})
+}
+
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	id, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	service := h.service(c)
+	attachment, err := service.GetAttachment(id, attachmentID)
+	if err != nil {
+		respondAttachmentError(c, id, "Fetching attachment", err)
+		return
+	}
+
+	formatted, err := h.formatAttachment(c, service, attachment)
+	if err != nil {
+		respondAttachmentError(c, id, "Signing attachment URL", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attachment": formatted})
+}
+
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	service := h.service(c)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": usecases.ErrAttachmentTooLarge.Error()})
+			return
+		}
+		log.Printf("[X] Invalid upload: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required in the 'file' form field"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		log.Printf("[X] Failed to read upload: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the uploaded file"})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := service.Upload(id, currentUserID(c), header.Filename, file)
+	if err != nil {
+		respondAttachmentError(c, id, "Attachment upload", err)
+		return
+	}
+
+	formatted, err := h.formatAttachment(c, service, attachment)
+	if err != nil {
+		respondAttachmentError(c, id, "Signing attachment URL", err)
+		return
+	}
+	log.Printf("[V] Attachment uploaded successfully: ID %d\n", attachment.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Attachment uploaded successfully",
+		"attachment": formatted,
+	})
+}
+
+// DownloadAttachment redirects to a freshly signed download URL.
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	id, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	service := h.service(c)
+	attachment, err := service.GetAttachment(id, attachmentID)
+	if err != nil {
+		respondAttachmentError(c, id, "Fetching attachment", err)
+		return
+	}
+
+	url, _, err := service.DownloadURL(attachment)
+	if err != nil {
+		respondAttachmentError(c, id, "Signing attachment URL", err)
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	id, attachmentID, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	moderator := middlewares.HasPermission(c, models.PermTaskDelete)
+	if err := h.service(c).DeleteAttachment(id, attachmentID, currentUserID(c), moderator); err != nil {
+		respondAttachmentError(c, id, "Attachment deletion", err)
+		return
+	}
+
+	log.Printf("[V] Attachment deleted successfully: ID %d\n", attachmentID)
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// ServeDownload streams an attachment to anyone holding a valid download
+// token; the token stands in for the session.
+func (h *AttachmentHandler) ServeDownload(c *gin.Context) {
+	attachment, contents, err := h.Service.OpenDownload(c.Query("token"))
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidDownloadURL) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		respondAttachmentError(c, 0, "Attachment download", err)
+		return
+	}
+	defer contents.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, contents, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
+
+func (h *AttachmentHandler) formatAttachment(c *gin.Context, service *usecases.AttachmentService, attachment *models.Attachment) (presenters.AttachmentResponse, error) {
+	url, expiresAt, err := service.DownloadURL(attachment)
+	if err != nil {
+		return presenters.AttachmentResponse{}, err
+	}
+	location := userLocation(c)
+	attachment.CreatedAt = attachment.CreatedAt.In(location)
+	return presenters.FormatAttachment(attachment, url, expiresAt.In(location)), nil
+}
+
+// parseAttachmentParams reads the task and attachment IDs, answering with a
+// 400 when either is invalid.
+func parseAttachmentParams(c *gin.Context) (uint, uint, bool) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil || attachmentID == 0 {
+		log.Printf("[X] Invalid attachment ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid attachment ID"})
+		return 0, 0, false
+	}
+	return id, uint(attachmentID), true
+}
+
+// respondAttachmentError maps errors from AttachmentService to responses.
+func respondAttachmentError(c *gin.Context, taskID uint, action string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrTaskNotFound), errors.Is(err, usecases.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrAttachmentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrAttachmentType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrAttachmentEmpty):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("[X] %s failed (task %d): %v\n", action, taskID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process attachment"})
+	}
+}
@@ -1,18 +1,23 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yasseryazid/technical-test/models"
 	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
 	"github.com/yasseryazid/technical-test/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthHandler struct {
 	UserRepo repositories.UserRepository
+	// Workspaces creates the personal workspace of new users. When nil,
+	// users start without a workspace until they are invited to one.
+	Workspaces *usecases.WorkspaceService
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -22,6 +27,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	if user.Timezone != "" {
+		if err := validateTimezone(user.Timezone); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
@@ -35,6 +47,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	if h.Workspaces != nil {
+		if err := h.Workspaces.CreatePersonalWorkspace(&user); err != nil {
+			log.Printf("[X] Failed to create workspace for user %d: %v\n", user.ID, err)
+		}
+	}
+
 	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully"})
 }
 
@@ -58,7 +76,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := utils.GenerateJWT(user.ID, user.Username)
+	token, err := utils.GenerateJWT(user.ID, user.Username, user.Permissions())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
 		return
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkTasks runs a list of operations, or one operation on every task a
+// filter selects, in a single transaction. The route only requires
+// task:write; delete operations additionally need task:delete.
+func (h *TaskHandler) BulkTasks(c *gin.Context) {
+	var req usecases.BulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": requestBodyError(err)})
+		return
+	}
+
+	if req.HasBulkOp(usecases.BulkDelete) && !middlewares.HasPermission(c, models.PermTaskDelete) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":              fmt.Sprintf("Missing permission '%s'", models.PermTaskDelete),
+			"missing_permission": models.PermTaskDelete,
+		})
+		return
+	}
+	if req.Filter != nil {
+		req.Filter.Location = userLocation(c)
+	}
+
+	report, err := h.service(c).Bulk(req, validateTask, changeContext(c))
+	if isClientError(err) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("[X] Bulk request failed: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to run bulk request"})
+		return
+	}
+
+	response := presenters.FormatBulkReport(report, bulkErrorMessage)
+	if !report.Committed {
+		log.Printf("[X] Bulk request rolled back: %d of %d operation(s) failed\n", report.Failed, len(report.Results))
+		c.JSON(http.StatusBadRequest, response)
+		return
+	}
+	log.Printf("[V] Bulk request committed: %d succeeded, %d failed\n", report.Succeeded, report.Failed)
+	c.JSON(http.StatusOK, response)
+}
+
+func bulkErrorMessage(err error) string {
+	if isClientError(err) {
+		return err.Error()
+	}
+	return "Task not found"
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	Service *usecases.CalendarService
+}
+
+// RegenerateFeedToken gives the user a new calendar feed URL. Any URL handed
+// out before stops working.
+func (h *CalendarHandler) RegenerateFeedToken(c *gin.Context) {
+	id := currentUserID(c)
+	token, err := h.Service.RegenerateToken(id)
+	if err != nil {
+		log.Printf("[X] Failed to create calendar feed token (user %d): %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create calendar feed"})
+		return
+	}
+
+	path := "/ical/" + token + ".ics"
+	log.Printf("[V] Calendar feed token regenerated (user %d)\n", id)
+	c.JSON(http.StatusOK, gin.H{"url": requestOrigin(c) + path, "path": path})
+}
+
+// RevokeFeedToken turns the user's calendar feed off.
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	id := currentUserID(c)
+	if err := h.Service.RevokeToken(id); err != nil {
+		log.Printf("[X] Failed to revoke calendar feed token (user %d): %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke calendar feed"})
+		return
+	}
+
+	log.Printf("[V] Calendar feed token revoked (user %d)\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked"})
+}
+
+// ServeFeed answers GET /ical/:token.ics. The token in the path is the only
+// credential, so calendar apps can subscribe without logging in.
+func (h *CalendarHandler) ServeFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	feedType := c.DefaultQuery("type", usecases.FeedEvents)
+
+	feed, err := h.Service.Feed(token, feedType, renderCalendarFeed(feedType))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrFeedNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecases.ErrInvalidFeedType):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("[X] Failed to build calendar feed: %v\n", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed"})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// renderCalendarFeed writes the tasks as events or to-dos.
+func renderCalendarFeed(feedType string) usecases.FeedRenderer {
+	return func(user *models.User, tasks []models.Task) ([]byte, error) {
+		var buf bytes.Buffer
+		calendar := presenters.NewICalendar(&buf, "Tasks of "+user.Username)
+		write := calendar.WriteEvent
+		if feedType == usecases.FeedTodos {
+			write = calendar.WriteTodo
+		}
+		for i := range tasks {
+			if err := write(&tasks[i]); err != nil {
+				return nil, err
+			}
+		}
+		if err := calendar.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// requestOrigin is the scheme and host the client used to reach the API,
+// honouring a proxy's X-Forwarded-Proto.
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
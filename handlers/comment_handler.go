@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCommentLength caps comment bodies, in bytes.
+const maxCommentLength = 10000
+
+type CommentHandler struct {
+	Service *usecases.CommentService
+}
+
+type commentInput struct {
+	Body     string `json:"body"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *CommentHandler) service(c *gin.Context) *usecases.CommentService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	comments, err := h.service(c).GetComments(id)
+	if err != nil {
+		log.Printf("[X] Failed to fetch comments (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	localizeComments(c, comments)
+	log.Printf("[V] Successfully fetched comments of task %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"comments": presenters.FormatCommentThreads(comments)})
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input commentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateCommentBody(input.Body); err != nil {
+		log.Printf("[X] Comment validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment := models.Comment{
+		UserID:   currentUserID(c),
+		ParentID: input.ParentID,
+		Body:     input.Body,
+	}
+	if err := h.service(c).CreateComment(id, &comment); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create comment (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	localizeComments(c, []models.Comment{comment})
+	log.Printf("[V] Comment created successfully: ID %d\n", comment.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Comment created successfully",
+		"comment": presenters.FormatComment(&comment),
+	})
+}
+
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	id, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	var input commentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateCommentBody(input.Body); err != nil {
+		log.Printf("[X] Comment validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.service(c).UpdateComment(id, commentID, input.Body, currentUserID(c))
+	if err != nil {
+		respondCommentError(c, commentID, "Comment update", err)
+		return
+	}
+
+	localizeComments(c, []models.Comment{*comment})
+	log.Printf("[V] Comment updated successfully: ID %d\n", commentID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment updated successfully",
+		"comment": presenters.FormatComment(comment),
+	})
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	id, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	moderator := middlewares.HasPermission(c, models.PermTaskDelete)
+	if err := h.service(c).DeleteComment(id, commentID, currentUserID(c), moderator); err != nil {
+		respondCommentError(c, commentID, "Comment deletion", err)
+		return
+	}
+
+	log.Printf("[V] Comment deleted successfully: ID %d\n", commentID)
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+func (h *CommentHandler) GetCommentHistory(c *gin.Context) {
+	id, commentID, ok := parseCommentParams(c)
+	if !ok {
+		return
+	}
+
+	comment, revisions, err := h.service(c).GetHistory(id, commentID)
+	if err != nil {
+		respondCommentError(c, commentID, "Fetching comment history", err)
+		return
+	}
+
+	location := userLocation(c)
+	for i := range revisions {
+		revisions[i].CreatedAt = revisions[i].CreatedAt.In(location)
+	}
+	localizeComments(c, []models.Comment{*comment})
+	c.JSON(http.StatusOK, gin.H{
+		"comment":   presenters.FormatComment(comment),
+		"revisions": presenters.FormatCommentRevisions(revisions),
+	})
+}
+
+// parseCommentParams reads the task and comment IDs, answering with a 400
+// when either is invalid.
+func parseCommentParams(c *gin.Context) (uint, uint, bool) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil || commentID == 0 {
+		log.Printf("[X] Invalid comment ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return 0, 0, false
+	}
+	return id, uint(commentID), true
+}
+
+// respondCommentError maps errors from CommentService to responses.
+func respondCommentError(c *gin.Context, id uint, action string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case isClientError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("[X] %s failed (comment %d): %v\n", action, id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	}
+}
+
+func localizeComments(c *gin.Context, comments []models.Comment) {
+	location := userLocation(c)
+	for i := range comments {
+		comments[i].CreatedAt = comments[i].CreatedAt.In(location)
+		if comments[i].EditedAt != nil {
+			editedAt := comments[i].EditedAt.In(location)
+			comments[i].EditedAt = &editedAt
+		}
+	}
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("Body is required")
+	}
+	if len(body) > maxCommentLength {
+		return fmt.Errorf("Body must be at most %d characters", maxCommentLength)
+	}
+	return nil
+}
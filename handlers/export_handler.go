@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportTasks streams every task matching the GET /api/tasks filters as a
+// file download. Tasks are read and written in batches, so the export is
+// never held in memory as a whole.
+func (h *TaskHandler) ExportTasks(c *gin.Context) {
+	name := c.DefaultQuery("format", "csv")
+	format, ok := presenters.ExportFormats[name]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Use 'csv', 'json', 'md' or 'ics'"})
+		return
+	}
+	filter, sort, _, _ := parseTaskQuery(c)
+
+	writer := format.NewWriter(c.Writer)
+	started := false
+	start := func() {
+		started = true
+		filename := fmt.Sprintf("tasks-%s.%s", time.Now().In(userLocation(c)).Format("2006-01-02"), name)
+		c.Header("Content-Type", format.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+	}
+
+	exported := 0
+	err := h.service(c).ExportTasks(filter, sort, func(tasks []models.Task) error {
+		if !started {
+			start()
+		}
+		localizeTasks(c, tasks)
+		for i := range tasks {
+			if err := writer.WriteTask(&tasks[i]); err != nil {
+				return err
+			}
+		}
+		exported += len(tasks)
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	if err != nil && !started {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to export tasks: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export tasks"})
+		return
+	}
+	if err != nil {
+		// The response has begun, so the client only sees it end early.
+		log.Printf("[X] Task export aborted after %d task(s): %v\n", exported, err)
+		return
+	}
+
+	if !started {
+		start()
+	}
+	if err := writer.Close(); err != nil {
+		log.Printf("[X] Failed to finish task export: %v\n", err)
+		return
+	}
+	log.Printf("[V] Exported %d task(s) as %s\n", exported, name)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportTasks creates tasks from a CSV or JSON file, uploaded in the 'file'
+// form field or sent as the request body. Every row is validated like a
+// single create; with dry_run=true the rows are only checked. Large files
+// are imported by a background job whose progress is at
+// GET /api/tasks/import/:jobId.
+func (h *TaskHandler) ImportTasks(c *gin.Context) {
+	format, data, mapping, err := readImportFile(c)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "The import file is too large"})
+			return
+		}
+		log.Printf("[X] Invalid import request: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := usecases.ParseImport(format, bytes.NewReader(data), mapping)
+	if err != nil {
+		log.Printf("[X] Failed to parse import file: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	opts := usecases.ImportOptions{
+		DryRun:       c.Query("dry_run") == "true",
+		Validate:     validateTask,
+		ErrorMessage: importErrorMessage,
+	}
+	report, job, err := h.service(c).Import(rows, format, opts)
+	if err != nil {
+		log.Printf("[X] Failed to start import: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start import"})
+		return
+	}
+
+	if job != nil {
+		log.Printf("[V] Import job %d started: %d row(s)\n", job.ID, job.Total)
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "Import started",
+			"job":     presenters.FormatImportJob(job),
+		})
+		return
+	}
+	log.Printf("[V] Import finished (dry run %t): %d imported, %d failed\n", report.DryRun, report.Imported, report.Failed)
+	c.JSON(http.StatusOK, presenters.FormatImportReport(report))
+}
+
+func (h *TaskHandler) GetImportJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("jobId"), 10, 32)
+	if err != nil || jobID == 0 {
+		log.Printf("[X] Invalid import job ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import job ID"})
+		return
+	}
+	id := uint(jobID)
+
+	job, err := h.service(c).GetImportJob(id)
+	if err != nil {
+		log.Printf("[X] Import job not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	localizeImportJob(c, job)
+	c.JSON(http.StatusOK, gin.H{"job": presenters.FormatImportJob(job)})
+}
+
+// readImportFile returns the import file with its format and CSV column
+// mapping. The format is the 'format' parameter, or else guessed from the
+// file name or content type.
+func readImportFile(c *gin.Context) (string, []byte, map[string]string, error) {
+	format := strings.ToLower(c.Query("format"))
+	rawMapping := c.Query("mapping")
+
+	var body io.Reader
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, usecases.MaxImportSize+multipartOverhead)
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return "", nil, nil, err
+			}
+			return "", nil, nil, errors.New("A file is required in the 'file' form field")
+		}
+		if header.Size > usecases.MaxImportSize {
+			return "", nil, nil, &http.MaxBytesError{Limit: usecases.MaxImportSize}
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", nil, nil, errors.New("Failed to read the uploaded file")
+		}
+		defer file.Close()
+
+		body = file
+		if value := c.PostForm("format"); value != "" {
+			format = strings.ToLower(value)
+		}
+		if format == "" {
+			format = strings.ToLower(strings.TrimPrefix(filepath.Ext(header.Filename), "."))
+		}
+		if value := c.PostForm("mapping"); value != "" {
+			rawMapping = value
+		}
+	} else {
+		body = http.MaxBytesReader(c.Writer, c.Request.Body, usecases.MaxImportSize)
+		if format == "" {
+			switch c.ContentType() {
+			case "text/csv":
+				format = usecases.ImportCSV
+			case "application/json":
+				format = usecases.ImportJSON
+			}
+		}
+	}
+
+	var mapping map[string]string
+	if rawMapping != "" {
+		if err := json.Unmarshal([]byte(rawMapping), &mapping); err != nil {
+			return "", nil, nil, errors.New("Invalid column mapping. Use a JSON object of field to column name")
+		}
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return format, data, mapping, nil
+}
+
+// importErrorMessage explains why a row was rejected without exposing
+// database errors.
+func importErrorMessage(err error) string {
+	var dueDateErr *models.DueDateError
+	if errors.As(err, &dueDateErr) {
+		return dueDateErr.Error()
+	}
+	if isClientError(err) {
+		return err.Error()
+	}
+	return "Failed to import row"
+}
+
+func localizeImportJob(c *gin.Context, job *models.ImportJob) {
+	loc := userLocation(c)
+	job.CreatedAt = job.CreatedAt.In(loc)
+	job.UpdatedAt = job.UpdatedAt.In(loc)
+	if job.FinishedAt != nil {
+		finishedAt := job.FinishedAt.In(loc)
+		job.FinishedAt = &finishedAt
+	}
+}
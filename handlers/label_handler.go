@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LabelHandler struct {
+	Service *usecases.LabelService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *LabelHandler) service(c *gin.Context) *usecases.LabelService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *LabelHandler) GetLabels(c *gin.Context) {
+	labels, err := h.service(c).GetLabels()
+	if err != nil {
+		log.Printf("[X] Failed to fetch labels: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch labels"})
+		return
+	}
+
+	usage, err := h.service(c).UsageCounts()
+	if err != nil {
+		log.Printf("[X] Failed to count label usage: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch labels"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched labels\n")
+	c.JSON(http.StatusOK, gin.H{"labels": presenters.FormatLabelUsageList(labels, usage)})
+}
+
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var label models.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateLabel(&label); err != nil {
+		log.Printf("[X] Label validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	label.ID = 0
+	if err := h.service(c).CreateLabel(&label); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create label: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create label"})
+		return
+	}
+
+	log.Printf("[V] Label created successfully: ID %d\n", label.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Label created successfully",
+		"label":   presenters.FormatLabel(&label),
+	})
+}
+
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid label ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+
+	var updatedLabel models.Label
+	if err := c.ShouldBindJSON(&updatedLabel); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateLabel(&updatedLabel); err != nil {
+		log.Printf("[X] Label validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service(c).UpdateLabel(id, &updatedLabel); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Label update failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+		return
+	}
+
+	log.Printf("[V] Label updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Label updated successfully",
+		"label":   presenters.FormatLabel(&updatedLabel),
+	})
+}
+
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid label ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+
+	if err := h.service(c).DeleteLabel(id); err != nil {
+		log.Printf("[X] Label deletion failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Label not found"})
+		return
+	}
+
+	log.Printf("[V] Label deleted successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Label deleted successfully"})
+}
+
+func validateLabel(label *models.Label) error {
+	if label.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	if label.Color != "" && !hexColorPattern.MatchString(label.Color) {
+		return fmt.Errorf("Invalid color. Use a hex value like '#1e90ff'")
+	}
+	return nil
+}
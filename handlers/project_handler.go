@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type ProjectHandler struct {
+	Service *usecases.ProjectService
+}
+
+// service returns the service scoped to the request's workspace, acting as
+// the requesting user.
+func (h *ProjectHandler) service(c *gin.Context) *usecases.ProjectService {
+	return h.Service.InWorkspace(workspaceID(c)).As(changeContext(c))
+}
+
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	projects, err := h.service(c).GetProjects(c.Query("include_archived") == "true")
+	if err != nil {
+		log.Printf("[X] Failed to fetch projects: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	counts, err := h.service(c).TaskCounts(projects)
+	if err != nil {
+		log.Printf("[X] Failed to count project tasks: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched projects\n")
+	c.JSON(http.StatusOK, gin.H{"projects": presenters.FormatProjectList(projects, counts)})
+}
+
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var project models.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateProject(&project); err != nil {
+		log.Printf("[X] Project validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project.ID = 0
+	if err := h.service(c).CreateProject(&project); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create project: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create project"})
+		return
+	}
+
+	log.Printf("[V] Project created successfully: ID %d\n", project.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Project created successfully",
+		"project": presenters.FormatProject(&project, nil),
+	})
+}
+
+func (h *ProjectHandler) GetProjectByID(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid project ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	project, err := h.service(c).GetProjectByID(id)
+	if err != nil {
+		log.Printf("[X] Project not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	counts, err := h.service(c).TaskCounts([]models.Project{*project})
+	if err != nil {
+		log.Printf("[X] Failed to count project tasks (ID %d): %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch project"})
+		return
+	}
+
+	log.Printf("[V] Project retrieved: ID %d\n", id)
+	c.JSON(http.StatusOK, presenters.FormatProject(project, counts[id]))
+}
+
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid project ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var updatedProject models.Project
+	if err := c.ShouldBindJSON(&updatedProject); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateProject(&updatedProject); err != nil {
+		log.Printf("[X] Project validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service(c).UpdateProject(id, &updatedProject); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Project update failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	log.Printf("[V] Project updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Project updated successfully",
+		"project": presenters.FormatProject(&updatedProject, nil),
+	})
+}
+
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid project ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if err := h.service(c).DeleteProject(id); err != nil {
+		log.Printf("[X] Project deletion failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	log.Printf("[V] Project deleted successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+func (h *ProjectHandler) GetProjectTasks(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid project ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	if _, err := h.service(c).GetProjectByID(id); err != nil {
+		log.Printf("[X] Project not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Project not found"})
+		return
+	}
+
+	filter, sort, page, limit := parseTaskQuery(c)
+	tasks, total, err := fetchTaskPage(func(page, limit int) ([]models.Task, int, error) {
+		return h.service(c).GetProjectTasks(id, filter, sort, page, limit)
+	}, page, limit)
+	if isClientError(err) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("[X] Failed to fetch project tasks (ID %d): %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	localizeTasks(c, tasks)
+	log.Printf("[V] Successfully fetched tasks for project %d (page %d, limit %d)\n", id, page, limit)
+	respond(c, http.StatusOK, presenters.TaskListResponse{
+		Tasks:      presenters.FormatTaskList(tasks),
+		Pagination: paginationResponse(page, limit, total),
+	})
+}
+
+func (h *ProjectHandler) CreateProjectTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid project ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		return
+	}
+
+	var task models.Task
+	if err := c.ShouldBindJSON(&task); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": requestBodyError(err)})
+		return
+	}
+
+	if err := validateTask(&task); err != nil {
+		log.Printf("[X] Task validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service(c).CreateProjectTask(id, &task); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create task in project %d: %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		return
+	}
+
+	localizeTask(c, &task)
+	log.Printf("[V] Task created successfully in project %d: ID %d\n", id, task.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Task created successfully",
+		"task":    presenters.FormatTaskDetail(&task),
+	})
+}
+
+func validateProject(project *models.Project) error {
+	if project.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	if project.Color != "" && !hexColorPattern.MatchString(project.Color) {
+		return fmt.Errorf("Invalid color. Use a hex value like '#1e90ff'")
+	}
+	return nil
+}
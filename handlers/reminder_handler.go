@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+	"github.com/yasseryazid/technical-test/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReminderHandler struct {
+	Service *usecases.ReminderService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *ReminderHandler) service(c *gin.Context) *usecases.ReminderService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *ReminderHandler) GetReminders(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	reminders, err := h.service(c).GetReminders(id)
+	if err != nil {
+		log.Printf("[X] Failed to fetch reminders (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched reminders of task %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"reminders": presenters.FormatReminders(reminders)})
+}
+
+func (h *ReminderHandler) CreateReminder(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var reminder models.Reminder
+	if err := c.ShouldBindJSON(&reminder); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateReminder(&reminder); err != nil {
+		log.Printf("[X] Reminder validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminder = models.Reminder{
+		UserID:        currentUserID(c),
+		RemindAt:      reminder.RemindAt,
+		OffsetMinutes: reminder.OffsetMinutes,
+		Channel:       reminder.Channel,
+		Target:        reminder.Target,
+	}
+	if err := h.service(c).CreateReminder(id, &reminder); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create reminder (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Reminder created successfully: ID %d\n", reminder.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Reminder created successfully",
+		"reminder": presenters.FormatReminder(&reminder),
+	})
+}
+
+func (h *ReminderHandler) DeleteReminder(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	reminderID, err := strconv.ParseUint(c.Param("reminderId"), 10, 32)
+	if err != nil || reminderID == 0 {
+		log.Printf("[X] Invalid reminder ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reminder ID"})
+		return
+	}
+
+	if err := h.service(c).DeleteReminder(id, uint(reminderID)); err != nil {
+		log.Printf("[X] Reminder deletion failed (ID %d): %v\n", reminderID, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Reminder not found"})
+		return
+	}
+
+	log.Printf("[V] Reminder deleted successfully: ID %d\n", reminderID)
+	c.JSON(http.StatusOK, gin.H{"message": "Reminder deleted successfully"})
+}
+
+func (h *ReminderHandler) GetNotifications(c *gin.Context) {
+	notifications, err := h.Service.GetNotifications(currentUserID(c), c.Query("unread") == "true")
+	if err != nil {
+		log.Printf("[X] Failed to fetch notifications: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched %d notifications\n", len(notifications))
+	c.JSON(http.StatusOK, gin.H{"notifications": presenters.FormatNotifications(notifications)})
+}
+
+func (h *ReminderHandler) MarkNotificationRead(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid notification ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := h.Service.MarkNotificationRead(currentUserID(c), id); err != nil {
+		log.Printf("[X] Marking notification read failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+
+	log.Printf("[V] Notification marked read: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+func validateReminder(reminder *models.Reminder) error {
+	if (reminder.RemindAt == nil) == (reminder.OffsetMinutes == nil) {
+		return fmt.Errorf("Set either remind_at or offset_minutes")
+	}
+	if reminder.OffsetMinutes != nil && *reminder.OffsetMinutes < 0 {
+		return fmt.Errorf("offset_minutes cannot be negative")
+	}
+
+	switch reminder.Channel {
+	case models.ChannelWebhook:
+		target, err := url.Parse(reminder.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return fmt.Errorf("Webhook reminders need an http(s) target URL")
+		}
+		if !utils.IsPublicHost(target.Hostname()) {
+			return fmt.Errorf("Webhook targets must be public addresses")
+		}
+	case models.ChannelEmail:
+		address, err := mail.ParseAddress(reminder.Target)
+		if err != nil || address.Address != reminder.Target {
+			return fmt.Errorf("Email reminders need a valid target address")
+		}
+	case models.ChannelInApp:
+		reminder.Target = ""
+	default:
+		return fmt.Errorf("Invalid channel. Use 'webhook', 'email' or 'in_app'")
+	}
+	return nil
+}
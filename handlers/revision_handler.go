@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *TaskHandler) GetTaskRevisions(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	revisions, err := h.service(c).GetRevisions(id)
+	if err != nil {
+		log.Printf("[X] Failed to fetch revisions (task %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	localizeRevisions(c, revisions)
+	log.Printf("[V] Successfully fetched revisions of task %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"revisions": presenters.FormatRevisions(revisions)})
+}
+
+func (h *TaskHandler) GetTaskRevision(c *gin.Context) {
+	id, number, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	revision, err := h.service(c).GetRevision(id, number)
+	if err != nil {
+		log.Printf("[X] Failed to fetch revision %d (task %d): %v\n", number, id, err)
+		respondRevisionError(c, err)
+		return
+	}
+
+	localizeRevision(c, revision)
+	log.Printf("[V] Successfully fetched revision %d of task %d\n", number, id)
+	c.JSON(http.StatusOK, gin.H{"revision": presenters.FormatRevision(revision)})
+}
+
+// DiffTaskRevisions lists the changes between the revisions given by the
+// from and to query parameters.
+func (h *TaskHandler) DiffTaskRevisions(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from and to must be revision numbers"})
+		return
+	}
+
+	changes, err := h.service(c).DiffRevisions(id, from, to)
+	if err != nil {
+		log.Printf("[X] Failed to diff revisions %d and %d (task %d): %v\n", from, to, id, err)
+		respondRevisionError(c, err)
+		return
+	}
+
+	log.Printf("[V] Successfully diffed revisions %d and %d of task %d\n", from, to, id)
+	c.JSON(http.StatusOK, gin.H{"from": from, "to": to, "changes": changes})
+}
+
+// RestoreTaskRevision rolls a task back to a revision. The result is saved
+// as a new revision.
+func (h *TaskHandler) RestoreTaskRevision(c *gin.Context) {
+	id, number, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.service(c).RestoreRevision(id, number, changeContext(c))
+	if err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to restore revision %d (task %d): %v\n", number, id, err)
+		respondRevisionError(c, err)
+		return
+	}
+
+	localizeTask(c, task)
+	log.Printf("[V] Task %d restored to revision %d\n", id, number)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task restored successfully",
+		"task":    presenters.FormatTask(task),
+	})
+}
+
+// parseRevisionParams reads the task ID and revision number, responding
+// with 400 when either is invalid.
+func parseRevisionParams(c *gin.Context) (uint, int, bool) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return 0, 0, false
+	}
+	number, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || number < 1 {
+		log.Printf("[X] Invalid revision: %s\n", c.Param("rev"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision"})
+		return 0, 0, false
+	}
+	return id, number, true
+}
+
+func respondRevisionError(c *gin.Context, err error) {
+	if errors.Is(err, usecases.ErrRevisionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+}
+
+func localizeRevision(c *gin.Context, revision *models.TaskRevision) {
+	location := userLocation(c)
+	revision.CreatedAt = revision.CreatedAt.In(location)
+	revision.Snapshot.DueDate = revision.Snapshot.DueDate.In(location)
+}
+
+func localizeRevisions(c *gin.Context, revisions []models.TaskRevision) {
+	for i := range revisions {
+		localizeRevision(c, &revisions[i])
+	}
+}
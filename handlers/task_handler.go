@@ -1,15 +1,19 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/yasseryazid/technical-test/models"
 	"github.com/yasseryazid/technical-test/presenters"
 	"github.com/yasseryazid/technical-test/usecases"
+	"github.com/yasseryazid/technical-test/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,9 +22,38 @@ type TaskHandler struct {
 	Service *usecases.TaskService
 }
 
+// service returns the service scoped to the request's workspace, acting as
+// the requesting user.
+func (h *TaskHandler) service(c *gin.Context) *usecases.TaskService {
+	return h.Service.InWorkspace(workspaceID(c)).As(changeContext(c))
+}
+
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	status, search, page, limit := parseQueryParams(c)
+	filter, sort, page, limit := parseTaskQuery(c)
+
+	tasks, total, err := fetchTaskPage(func(page, limit int) ([]models.Task, int, error) {
+		return h.service(c).FindTasks(filter, sort, page, limit)
+	}, page, limit)
+	if isClientError(err) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("[X] Failed to fetch tasks: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	localizeTasks(c, tasks)
+	log.Printf("[V] Successfully fetched tasks (page %d, limit %d)\n", page, limit)
+	respond(c, http.StatusOK, presenters.TaskListResponse{
+		Tasks:      presenters.FormatTaskList(tasks),
+		Pagination: paginationResponse(page, limit, total),
+	})
+}
 
+// fetchTaskPage runs the page query and the total count concurrently.
+func fetchTaskPage(fetch func(page, limit int) ([]models.Task, int, error), page, limit int) ([]models.Task, int, error) {
 	var tasks []models.Task
 	var total int
 	var wg sync.WaitGroup
@@ -32,7 +65,7 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	go func() {
 		defer wg.Done()
 		var err error
-		tasks, _, err = h.Service.GetTasks(status, search, page, limit)
+		tasks, _, err = fetch(page, limit)
 		if err != nil {
 			log.Printf("[X] Error fetching tasks: %v\n", err)
 			errChan <- err
@@ -43,7 +76,7 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	go func() {
 		defer wg.Done()
 		var err error
-		_, total, err = h.Service.GetTasks(status, search, 1, 1) // Only count total
+		_, total, err = fetch(1, 1) // Only count total
 		if err != nil {
 			log.Printf("[X] Error counting tasks: %v\n", err)
 			errChan <- err
@@ -57,23 +90,40 @@ func (h *TaskHandler) GetTasks(c *gin.Context) {
 	// Check for errors
 	for err := range errChan {
 		if err != nil {
-			log.Printf("[X] Failed to fetch tasks: %v\n", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
-			return
+			return nil, 0, err
 		}
 	}
 
-	totalPages := (total + limit - 1) / limit
+	return tasks, total, nil
+}
 
-	log.Printf("[V] Successfully fetched tasks (page %d, limit %d)\n", page, limit)
-	c.JSON(http.StatusOK, gin.H{
-		"tasks": presenters.FormatTaskList(tasks),
-		"pagination": gin.H{
-			"current_page": page,
-			"total_pages":  totalPages,
-			"total_tasks":  total,
-		},
-	})
+func paginationResponse(page, limit, total int) gin.H {
+	return gin.H{
+		"current_page": page,
+		"total_pages":  (total + limit - 1) / limit,
+		"total_tasks":  total,
+	}
+}
+
+func (h *TaskHandler) GetNextTasks(c *gin.Context) {
+	filter, _, _, _ := parseTaskQuery(c)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 {
+		limit = 10
+	}
+
+	tasks, err := h.service(c).NextTasks(filter, limit)
+	if err != nil {
+		log.Printf("[X] Failed to fetch next tasks: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched %d next tasks\n", len(tasks))
+	for i := range tasks {
+		localizeTask(c, &tasks[i].Task)
+	}
+	respond(c, http.StatusOK, presenters.ScoredTaskListResponse{Tasks: presenters.FormatScoredTasks(tasks)})
 }
 
 func (h *TaskHandler) CreateTask(c *gin.Context) {
@@ -81,7 +131,7 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&task); err != nil {
 		log.Printf("[X] Invalid request body: %v\n", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": requestBodyError(err)})
 		return
 	}
 
@@ -91,19 +141,71 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.CreateTask(&task); err != nil {
+	if err := h.service(c).CreateTask(&task); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		log.Printf("[X] Failed to create task: %v\n", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
 		return
 	}
 
+	localizeTask(c, &task)
 	log.Printf("[V] Task created successfully: ID %d\n", task.ID)
-	c.JSON(http.StatusCreated, gin.H{
+	respond(c, http.StatusCreated, gin.H{
 		"message": "Task created successfully",
 		"task":    presenters.FormatTaskDetail(&task),
 	})
 }
 
+type quickAddInput struct {
+	Text      string `json:"text"`
+	ProjectID *uint  `json:"project_id"`
+}
+
+// QuickAddTask creates a task from one line of text, or only returns what
+// was parsed when dry_run=true.
+func (h *TaskHandler) QuickAddTask(c *gin.Context) {
+	var input quickAddInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	parsed, err := usecases.ParseQuickAdd(input.Text, time.Now().In(userLocation(c)))
+	if err != nil {
+		log.Printf("[X] Quick add parsing failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		log.Printf("[V] Quick add parsed: %q\n", input.Text)
+		c.JSON(http.StatusOK, gin.H{"preview": presenters.FormatQuickAdd(parsed)})
+		return
+	}
+
+	task, err := h.service(c).QuickAddTask(parsed, input.ProjectID)
+	if err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to quick add task: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		return
+	}
+
+	localizeTask(c, task)
+	log.Printf("[V] Task quick added successfully: ID %d\n", task.ID)
+	respond(c, http.StatusCreated, gin.H{
+		"message": "Task created successfully",
+		"task":    presenters.FormatTask(task),
+	})
+}
+
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 	id, err := parseIDParam(c)
 	if err != nil {
@@ -112,7 +214,7 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 		return
 	}
 
-	task, err := h.Service.GetTaskByID(id)
+	task, err := h.service(c).GetTaskByID(id)
 	if err != nil {
 		log.Printf("[X] Task not found (ID %d): %v\n", id, err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
@@ -120,7 +222,8 @@ func (h *TaskHandler) GetTaskByID(c *gin.Context) {
 	}
 
 	log.Printf("[V] Task retrieved: ID %d\n", id)
-	c.JSON(http.StatusOK, presenters.FormatTask(task))
+	localizeTask(c, task)
+	respond(c, http.StatusOK, presenters.FormatTask(task))
 }
 
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
@@ -134,7 +237,7 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	var updatedTask models.Task
 	if err := c.ShouldBindJSON(&updatedTask); err != nil {
 		log.Printf("[X] Invalid request body: %v\n", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": requestBodyError(err)})
 		return
 	}
 
@@ -144,14 +247,30 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.UpdateTask(id, &updatedTask); err != nil {
+	scope := c.DefaultQuery("scope", "this")
+	if scope != "this" && scope != "future" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scope. Use 'this' or 'future'"})
+		return
+	}
+
+	update := h.service(c).UpdateTaskAs
+	if scope == "future" {
+		update = h.service(c).UpdateTaskSeries
+	}
+
+	if err := update(id, &updatedTask, changeContext(c)); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		log.Printf("[X] Task update failed (ID %d): %v\n", id, err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
+	localizeTask(c, &updatedTask)
 	log.Printf("[V] Task updated successfully: ID %d\n", id)
-	c.JSON(http.StatusOK, gin.H{
+	respond(c, http.StatusOK, gin.H{
 		"message": "Task updated successfully",
 		"task":    presenters.FormatTaskDetail(&updatedTask),
 	})
@@ -165,7 +284,7 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.DeleteTask(id); err != nil {
+	if err := h.service(c).DeleteTask(id); err != nil {
 		log.Printf("[X] Task deletion failed (ID %d): %v\n", id, err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
@@ -175,12 +294,420 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
 
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		ProjectID *uint `json:"project_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.service(c).MoveTask(id, input.ProjectID); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Task move failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Task moved successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Task moved successfully"})
+}
+
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	subtasks, err := h.service(c).GetSubtasks(id)
+	if err != nil {
+		log.Printf("[X] Subtasks not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Subtasks retrieved: ID %d\n", id)
+	localizeTasks(c, subtasks)
+	respond(c, http.StatusOK, presenters.TaskListResponse{Tasks: presenters.FormatTaskList(subtasks)})
+}
+
+func (h *TaskHandler) CreateSubtask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var task models.Task
+	if err := c.ShouldBindJSON(&task); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": requestBodyError(err)})
+		return
+	}
+
+	if err := validateTask(&task); err != nil {
+		log.Printf("[X] Task validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service(c).CreateSubtask(id, &task); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create subtask of task %d: %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		return
+	}
+
+	localizeTask(c, &task)
+	log.Printf("[V] Subtask created successfully under task %d: ID %d\n", id, task.ID)
+	respond(c, http.StatusCreated, gin.H{
+		"message": "Task created successfully",
+		"task":    presenters.FormatTask(&task),
+	})
+}
+
+func (h *TaskHandler) SetTaskParent(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		ParentID *uint `json:"parent_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.service(c).SetParent(id, input.ParentID); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Setting parent failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Task parent updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Task parent updated successfully"})
+}
+
+func (h *TaskHandler) AddTaskDependency(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		BlockerID uint `json:"blocker_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || input.BlockerID == 0 {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "blocker_id is required"})
+		return
+	}
+
+	if err := h.service(c).AddDependency(id, input.BlockerID); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Adding dependency failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Task %d now blocked by task %d\n", id, input.BlockerID)
+	c.JSON(http.StatusCreated, gin.H{"message": "Dependency added successfully"})
+}
+
+func (h *TaskHandler) RemoveTaskDependency(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	blockerID, err := strconv.ParseUint(c.Param("blockerId"), 10, 32)
+	if err != nil || blockerID == 0 {
+		log.Printf("[X] Invalid blocker ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blocker ID"})
+		return
+	}
+
+	if err := h.service(c).RemoveDependency(id, uint(blockerID)); err != nil {
+		log.Printf("[X] Removing dependency failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Dependency not found"})
+		return
+	}
+
+	log.Printf("[V] Task %d no longer blocked by task %d\n", id, blockerID)
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
+}
+
+func (h *TaskHandler) GetDependencyGraph(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	tasks, dependencies, err := h.service(c).DependencyGraph(id)
+	if err != nil {
+		log.Printf("[X] Dependency graph not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Dependency graph retrieved: ID %d (%d nodes)\n", id, len(tasks))
+	c.JSON(http.StatusOK, presenters.FormatDependencyGraph(tasks, dependencies))
+}
+
+func (h *TaskHandler) TransitionTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		Status string `json:"status"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || input.Status == "" {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status is required"})
+		return
+	}
+
+	task, err := h.service(c).TransitionTask(id, input.Status, changeContext(c))
+	if err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Task transition failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	localizeTask(c, task)
+	log.Printf("[V] Task transitioned to %s: ID %d\n", task.Status, id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task transitioned successfully",
+		"task":    presenters.FormatTask(task),
+	})
+}
+
+func (h *TaskHandler) GetTaskTransitions(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	transitions, err := h.service(c).GetTransitions(id)
+	if err != nil {
+		log.Printf("[X] Task transitions not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Task transitions retrieved: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"transitions": presenters.FormatTransitions(transitions)})
+}
+
+func (h *TaskHandler) AddTaskLabels(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		LabelIDs []uint `json:"label_ids"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || len(input.LabelIDs) == 0 {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.service(c).AddLabels(id, input.LabelIDs); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Attaching labels failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Labels attached to task: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Labels attached successfully"})
+}
+
+func (h *TaskHandler) RemoveTaskLabel(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	labelID, err := strconv.ParseUint(c.Param("labelId"), 10, 32)
+	if err != nil || labelID == 0 {
+		log.Printf("[X] Invalid label ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid label ID"})
+		return
+	}
+
+	if err := h.service(c).RemoveLabel(id, uint(labelID)); err != nil {
+		log.Printf("[X] Detaching label failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Label %d detached from task: ID %d\n", labelID, id)
+	c.JSON(http.StatusOK, gin.H{"message": "Label detached successfully"})
+}
+
+func (h *TaskHandler) AssignTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var input struct {
+		UserIDs []uint `json:"user_ids"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || len(input.UserIDs) == 0 {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.service(c).AssignTask(id, input.UserIDs, changeContext(c)); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Assigning task failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		return
+	}
+
+	log.Printf("[V] Users assigned to task: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Users assigned successfully"})
+}
+
+func (h *TaskHandler) UnassignTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil || userID == 0 {
+		log.Printf("[X] Invalid user ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service(c).UnassignTask(id, uint(userID)); err != nil {
+		log.Printf("[X] Unassigning task failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Assignee not found"})
+		return
+	}
+
+	log.Printf("[V] User %d unassigned from task: ID %d\n", userID, id)
+	c.JSON(http.StatusOK, gin.H{"message": "User unassigned successfully"})
+}
+
 func parseQueryParams(c *gin.Context) (string, string, int, int) {
 	status := c.Query("status")
 	search := c.Query("search")
+	page, limit := parsePagination(c)
+	return status, search, page, limit
+}
+
+func parsePagination(c *gin.Context) (int, int) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	return status, search, page, limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	return page, limit
+}
+
+func parseTaskQuery(c *gin.Context) (models.TaskFilter, string, int, int) {
+	status, search, page, limit := parseQueryParams(c)
+	filter := models.TaskFilter{
+		Status:  status,
+		Search:  search,
+		DueFrom: c.Query("due_from"),
+		DueTo:   c.Query("due_to"),
+		Overdue: c.Query("overdue") == "true",
+	}
+	projectID, _ := strconv.ParseUint(c.Query("project_id"), 10, 32)
+	filter.ProjectID = uint(projectID)
+	filter.StatusCategory = c.Query("status_category")
+	filter.Priorities = parseList(c.Query("priority"))
+	filter.LabelIDs = parseIDList(c.Query("labels"))
+	filter.LabelMatch = c.DefaultQuery("label_match", "any")
+	filter.DueWithinDays, _ = strconv.Atoi(c.Query("due_within_days"))
+	filter.CompletedWithinDays, _ = strconv.Atoi(c.Query("completed_within_days"))
+	filter.AssigneeID = parseAssignee(c)
+	filter.IncludeArchived = c.Query("include_archived") == "true"
+	filter.Location = userLocation(c)
+	return filter, c.Query("sort"), page, limit
+}
+
+// parseAssignee reads the assignee query parameter, which is a user ID or
+// "me" for the authenticated user.
+func parseAssignee(c *gin.Context) uint {
+	value := c.Query("assignee")
+	if value == "me" {
+		return currentUserID(c)
+	}
+	id, _ := strconv.ParseUint(value, 10, 32)
+	return uint(id)
 }
 
 func parseIDParam(c *gin.Context) (uint, error) {
@@ -192,12 +719,191 @@ func parseIDParam(c *gin.Context) (uint, error) {
 	return uint(id), nil
 }
 
+// parseList splits a comma separated query value, dropping empty entries.
+func parseList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// parseIDList parses a comma separated list of IDs, skipping invalid entries.
+func parseIDList(value string) []uint {
+	var ids []uint
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err == nil && id != 0 {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// isClientError reports whether err is a validation error from the service
+// layer that should be reported back to the client as a 400.
+func isClientError(err error) bool {
+	for _, target := range []error{
+		usecases.ErrInvalidSort,
+		usecases.ErrInvalidDateFilter,
+		usecases.ErrProjectNotFound,
+		usecases.ErrProjectArchived,
+		usecases.ErrLabelNotFound,
+		usecases.ErrLabelExists,
+		usecases.ErrInvalidStatus,
+		usecases.ErrTransitionNotAllowed,
+		usecases.ErrWorkflowNotFound,
+		usecases.ErrParentNotFound,
+		usecases.ErrTaskCycle,
+		usecases.ErrTaskTooDeep,
+		usecases.ErrBlockerNotFound,
+		usecases.ErrDependencyCycle,
+		usecases.ErrTaskBlocked,
+		usecases.ErrInvalidRecurrence,
+		usecases.ErrNotRecurring,
+		usecases.ErrInvalidReminder,
+		usecases.ErrQuickAddNoTitle,
+		usecases.ErrUnknownChannel,
+		usecases.ErrUserNotFound,
+		usecases.ErrNotWorkspaceMember,
+		usecases.ErrInvalidRole,
+		usecases.ErrOwnerMembership,
+		usecases.ErrWorkspaceNotEmpty,
+		usecases.ErrInvalidInvitation,
+		usecases.ErrAlreadyMember,
+		usecases.ErrCommentParent,
+		usecases.ErrCommentNotChanged,
+		usecases.ErrTaskArchived,
+		usecases.ErrTaskNotArchived,
+		usecases.ErrInvalidBulkMode,
+		usecases.ErrInvalidBulkOperation,
+		usecases.ErrTooManyOperations,
+		usecases.ErrNoOperations,
+		usecases.ErrInvalidTaskFields,
+		usecases.ErrInvalidImportRow,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBodyError explains why a request body was rejected, passing due
+// date parse errors through instead of the generic message.
+func requestBodyError(err error) string {
+	var dueDateErr *models.DueDateError
+	if errors.As(err, &dueDateErr) {
+		return dueDateErr.Error()
+	}
+	return "Invalid request body"
+}
+
+// userLocation returns the user's timezone set by TimezoneMiddleware.
+func userLocation(c *gin.Context) *time.Location {
+	if value, ok := c.Get("location"); ok {
+		if location, ok := value.(*time.Location); ok {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// localizeTask shows the task's timestamps in the user's timezone.
+func localizeTask(c *gin.Context, task *models.Task) {
+	location := userLocation(c)
+	task.DueDate = task.DueDate.In(location)
+	if task.CompletedAt != nil {
+		completedAt := task.CompletedAt.In(location)
+		task.CompletedAt = &completedAt
+	}
+	if task.ArchivedAt != nil {
+		archivedAt := task.ArchivedAt.In(location)
+		task.ArchivedAt = &archivedAt
+	}
+}
+
+func localizeTasks(c *gin.Context, tasks []models.Task) {
+	for i := range tasks {
+		localizeTask(c, &tasks[i])
+	}
+}
+
+// respond writes body in the format the Accept header prefers. Routes using
+// it run NegotiationMiddleware, so some format is acceptable; when only ones
+// that can't show this body are, like CSV for a response that isn't tasks,
+// the body is sent as JSON.
+func respond(c *gin.Context, status int, body any) {
+	presenter, ok := presenters.Negotiate(c.GetHeader("Accept"), body)
+	if !ok {
+		c.JSON(status, body)
+		return
+	}
+
+	c.Header("Content-Type", presenter.ContentType)
+	c.Header("Vary", "Accept")
+	c.Status(status)
+	if err := presenter.Present(c.Writer, body); err != nil {
+		log.Printf("[X] Failed to write %s response: %v\n", presenter.ContentType, err)
+	}
+}
+
+// currentUserID returns the ID of the authenticated user set by AuthMiddleware.
+func currentUserID(c *gin.Context) uint {
+	value, _ := c.Get("user_id")
+	switch id := value.(type) {
+	case float64:
+		return uint(id)
+	case uint:
+		return id
+	}
+	return 0
+}
+
+// workspaceID returns the active workspace set by WorkspaceMiddleware, or 0
+// on routes that are not scoped to a workspace.
+func workspaceID(c *gin.Context) uint {
+	value, _ := c.Get("workspace_id")
+	id, _ := value.(uint)
+	return id
+}
+
+func changeContext(c *gin.Context) usecases.ChangeContext {
+	return usecases.ChangeContext{
+		UserID:    currentUserID(c),
+		Force:     c.Query("force") == "true",
+		RequestID: c.GetString("request_id"),
+	}
+}
+
+// validateTask checks the fields that don't depend on the task's workflow;
+// the status is validated by the service against the workflow.
 func validateTask(task *models.Task) error {
 	if task.Title == "" {
 		return fmt.Errorf("Title is required")
 	}
-	if task.Status != "pending" && task.Status != "completed" {
-		return fmt.Errorf("Invalid status. Use 'pending' or 'completed'")
+	if task.Priority != "" && models.PriorityRank(task.Priority) < 0 {
+		return fmt.Errorf("Invalid priority. Use %s", quoteList(models.Priorities))
+	}
+	if task.Recurrence != "" {
+		if _, err := utils.ParseRRule(task.Recurrence); err != nil {
+			return fmt.Errorf("Invalid recurrence: %v", err)
+		}
+	}
+	switch task.RecurrenceMode {
+	case "", models.RecurrenceOnComplete, models.RecurrenceScheduled:
+	default:
+		return fmt.Errorf("Invalid recurrence mode. Use %s", quoteList(models.RecurrenceModes))
 	}
 	return nil
 }
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
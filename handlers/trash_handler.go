@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/yasseryazid/technical-test/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	tasks, total, err := h.service(c).GetTrash(page, limit)
+	if err != nil {
+		log.Printf("[X] Failed to fetch trash: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch trash"})
+		return
+	}
+
+	localizeTasks(c, tasks)
+	for i := range tasks {
+		tasks[i].DeletedAt.Time = tasks[i].DeletedAt.Time.In(userLocation(c))
+	}
+	log.Printf("[V] Successfully fetched trash (page %d, limit %d)\n", page, limit)
+	respond(c, http.StatusOK, presenters.TrashedTaskListResponse{
+		Tasks:      presenters.FormatTrashedTasks(tasks),
+		Pagination: paginationResponse(page, limit, total),
+	})
+}
+
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.service(c).RestoreTask(id)
+	if err != nil {
+		log.Printf("[X] Task restore failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found in trash"})
+		return
+	}
+
+	localizeTask(c, task)
+	log.Printf("[V] Task restored from trash: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Task restored successfully",
+		"task":    presenters.FormatTask(task),
+	})
+}
+
+func (h *TaskHandler) PurgeTask(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid task ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	if err := h.service(c).PurgeTask(id); err != nil {
+		log.Printf("[X] Task purge failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found in trash"})
+		return
+	}
+
+	log.Printf("[V] Task purged: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Task permanently deleted"})
+}
+
+func (h *TaskHandler) EmptyTrash(c *gin.Context) {
+	purged, err := h.service(c).EmptyTrash()
+	if err != nil {
+		log.Printf("[X] Failed to empty trash: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to empty trash"})
+		return
+	}
+
+	log.Printf("[V] Trash emptied: %d task(s) purged\n", purged)
+	c.JSON(http.StatusOK, gin.H{"message": "Trash emptied", "purged": purged})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	UserRepo repositories.UserRepository
+}
+
+type settingsInput struct {
+	Timezone string `json:"timezone"`
+}
+
+func (h *UserHandler) GetMe(c *gin.Context) {
+	user, err := h.UserRepo.GetUserByID(currentUserID(c))
+	if err != nil {
+		log.Printf("[X] User not found (ID %d): %v\n", currentUserID(c), err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, presenters.FormatUser(user))
+}
+
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var input settingsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateTimezone(input.Timezone); err != nil {
+		log.Printf("[X] Settings validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id := currentUserID(c)
+	if err := h.UserRepo.UpdateTimezone(id, input.Timezone); err != nil {
+		log.Printf("[X] Settings update failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update settings"})
+		return
+	}
+
+	user, err := h.UserRepo.GetUserByID(id)
+	if err != nil {
+		log.Printf("[X] User not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	log.Printf("[V] Settings updated successfully: user %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Settings updated successfully",
+		"user":    presenters.FormatUser(user),
+	})
+}
+
+// GetUsers lists everyone who can be assigned to a task.
+func (h *UserHandler) GetUsers(c *gin.Context) {
+	users, err := h.UserRepo.GetUsers()
+	if err != nil {
+		log.Printf("[X] Failed to fetch users: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": presenters.FormatMembers(users)})
+}
+
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return fmt.Errorf("Timezone is required")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("Invalid timezone '%s'. Use an IANA name like 'Asia/Jakarta'", timezone)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ViewHandler struct {
+	Service *usecases.ViewService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *ViewHandler) service(c *gin.Context) *usecases.ViewService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *ViewHandler) GetViews(c *gin.Context) {
+	views, err := h.Service.GetViews(currentUserID(c))
+	if err != nil {
+		log.Printf("[X] Failed to fetch views: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch views"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched views\n")
+	c.JSON(http.StatusOK, gin.H{"views": presenters.FormatViewList(h.Service.BuiltinViews(), views)})
+}
+
+func (h *ViewHandler) CreateView(c *gin.Context) {
+	var view models.SavedView
+	if err := c.ShouldBindJSON(&view); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateView(&view); err != nil {
+		log.Printf("[X] View validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	view.ID = 0
+	view.UserID = currentUserID(c)
+	if err := h.Service.CreateView(&view); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] Failed to create view: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create view"})
+		return
+	}
+
+	log.Printf("[V] View created successfully: ID %d\n", view.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "View created successfully",
+		"view":    presenters.FormatView(&view),
+	})
+}
+
+func (h *ViewHandler) GetViewByID(c *gin.Context) {
+	if builtin, ok := h.Service.GetBuiltinView(c.Param("id")); ok {
+		c.JSON(http.StatusOK, presenters.FormatBuiltinView(builtin))
+		return
+	}
+
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid view ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	view, err := h.Service.GetViewByID(id, currentUserID(c))
+	if err != nil {
+		log.Printf("[X] View not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "View not found"})
+		return
+	}
+
+	log.Printf("[V] View retrieved: ID %d\n", id)
+	c.JSON(http.StatusOK, presenters.FormatView(view))
+}
+
+func (h *ViewHandler) UpdateView(c *gin.Context) {
+	if _, ok := h.Service.GetBuiltinView(c.Param("id")); ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBuiltinView.Error()})
+		return
+	}
+
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid view ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	var updatedView models.SavedView
+	if err := c.ShouldBindJSON(&updatedView); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateView(&updatedView); err != nil {
+		log.Printf("[X] View validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Service.UpdateView(id, currentUserID(c), &updatedView); err != nil {
+		if isClientError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("[X] View update failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "View not found"})
+		return
+	}
+
+	log.Printf("[V] View updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "View updated successfully",
+		"view":    presenters.FormatView(&updatedView),
+	})
+}
+
+func (h *ViewHandler) DeleteView(c *gin.Context) {
+	if _, ok := h.Service.GetBuiltinView(c.Param("id")); ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecases.ErrBuiltinView.Error()})
+		return
+	}
+
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid view ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+		return
+	}
+
+	if err := h.Service.DeleteView(id, currentUserID(c)); err != nil {
+		log.Printf("[X] View deletion failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "View not found"})
+		return
+	}
+
+	log.Printf("[V] View deleted successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+func (h *ViewHandler) GetViewTasks(c *gin.Context) {
+	page, limit := parsePagination(c)
+
+	var fetch func(page, limit int) ([]models.Task, int, error)
+	var columns []string
+
+	if builtin, ok := h.Service.GetBuiltinView(c.Param("id")); ok {
+		builtin.Filters.Location = userLocation(c)
+		fetch = func(page, limit int) ([]models.Task, int, error) {
+			return h.service(c).RunBuiltinView(builtin, page, limit)
+		}
+	} else {
+		id, err := parseIDParam(c)
+		if err != nil {
+			log.Printf("[X] Invalid view ID: %v\n", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid view ID"})
+			return
+		}
+
+		view, err := h.Service.GetViewByID(id, currentUserID(c))
+		if err != nil {
+			log.Printf("[X] View not found (ID %d): %v\n", id, err)
+			c.JSON(http.StatusNotFound, gin.H{"error": "View not found"})
+			return
+		}
+
+		columns = view.Columns
+		view.Filters.Location = userLocation(c)
+		fetch = func(page, limit int) ([]models.Task, int, error) {
+			return h.service(c).RunView(view, page, limit)
+		}
+	}
+
+	tasks, total, err := fetchTaskPage(fetch, page, limit)
+	if err != nil {
+		log.Printf("[X] Failed to run view %s: %v\n", c.Param("id"), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+
+	localizeTasks(c, tasks)
+	log.Printf("[V] Successfully ran view %s (page %d, limit %d)\n", c.Param("id"), page, limit)
+	respond(c, http.StatusOK, gin.H{
+		"tasks":      presenters.SelectTaskColumns(tasks, columns),
+		"pagination": paginationResponse(page, limit, total),
+	})
+}
+
+func validateView(view *models.SavedView) error {
+	if view.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	for _, column := range view.Columns {
+		if !presenters.IsTaskColumn(column) {
+			return fmt.Errorf("Unknown column '%s'", column)
+		}
+	}
+	return usecases.ValidateTaskFilter(view.Filters)
+}
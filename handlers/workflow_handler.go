@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+var stateKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type WorkflowHandler struct {
+	Service *usecases.WorkflowService
+}
+
+// service returns the service scoped to the request's workspace.
+func (h *WorkflowHandler) service(c *gin.Context) *usecases.WorkflowService {
+	return h.Service.InWorkspace(workspaceID(c))
+}
+
+func (h *WorkflowHandler) GetWorkflows(c *gin.Context) {
+	workflows, err := h.service(c).GetWorkflows()
+	if err != nil {
+		log.Printf("[X] Failed to fetch workflows: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch workflows"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched workflows\n")
+	c.JSON(http.StatusOK, gin.H{
+		"default":   presenters.FormatWorkflow(&usecases.DefaultWorkflow),
+		"workflows": presenters.FormatWorkflowList(workflows),
+	})
+}
+
+func (h *WorkflowHandler) CreateWorkflow(c *gin.Context) {
+	var workflow models.Workflow
+	if err := c.ShouldBindJSON(&workflow); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateWorkflow(&workflow); err != nil {
+		log.Printf("[X] Workflow validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workflow.ID = 0
+	if err := h.service(c).CreateWorkflow(&workflow); err != nil {
+		log.Printf("[X] Failed to create workflow: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create workflow"})
+		return
+	}
+
+	log.Printf("[V] Workflow created successfully: ID %d\n", workflow.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Workflow created successfully",
+		"workflow": presenters.FormatWorkflow(&workflow),
+	})
+}
+
+func (h *WorkflowHandler) GetWorkflowByID(c *gin.Context) {
+	if c.Param("id") == "default" {
+		c.JSON(http.StatusOK, presenters.FormatWorkflow(&usecases.DefaultWorkflow))
+		return
+	}
+
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid workflow ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	workflow, err := h.service(c).GetWorkflowByID(id)
+	if err != nil {
+		log.Printf("[X] Workflow not found (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+		return
+	}
+
+	log.Printf("[V] Workflow retrieved: ID %d\n", id)
+	c.JSON(http.StatusOK, presenters.FormatWorkflow(workflow))
+}
+
+func (h *WorkflowHandler) UpdateWorkflow(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid workflow ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	var updatedWorkflow models.Workflow
+	if err := c.ShouldBindJSON(&updatedWorkflow); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateWorkflow(&updatedWorkflow); err != nil {
+		log.Printf("[X] Workflow validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service(c).UpdateWorkflow(id, &updatedWorkflow); err != nil {
+		log.Printf("[X] Workflow update failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+		return
+	}
+
+	log.Printf("[V] Workflow updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Workflow updated successfully",
+		"workflow": presenters.FormatWorkflow(&updatedWorkflow),
+	})
+}
+
+func (h *WorkflowHandler) DeleteWorkflow(c *gin.Context) {
+	id, err := parseIDParam(c)
+	if err != nil {
+		log.Printf("[X] Invalid workflow ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workflow ID"})
+		return
+	}
+
+	if err := h.service(c).DeleteWorkflow(id); err != nil {
+		log.Printf("[X] Workflow deletion failed (ID %d): %v\n", id, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Workflow not found"})
+		return
+	}
+
+	log.Printf("[V] Workflow deleted successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow deleted successfully"})
+}
+
+func validateWorkflow(workflow *models.Workflow) error {
+	if workflow.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	if len(workflow.States) == 0 {
+		return fmt.Errorf("At least one state is required")
+	}
+
+	keys := make(map[string]bool, len(workflow.States))
+	for _, state := range workflow.States {
+		if !stateKeyPattern.MatchString(state.Key) {
+			return fmt.Errorf("Invalid state key '%s'. Use lowercase letters, digits and underscores", state.Key)
+		}
+		if keys[state.Key] {
+			return fmt.Errorf("Duplicate state key '%s'", state.Key)
+		}
+		switch state.Category {
+		case models.StatusCategoryOpen, models.StatusCategoryDone, models.StatusCategoryCancelled:
+		default:
+			return fmt.Errorf("Invalid category for state '%s'. Use 'open', 'done' or 'cancelled'", state.Key)
+		}
+		keys[state.Key] = true
+	}
+	if workflow.States[0].Category != models.StatusCategoryOpen {
+		return fmt.Errorf("The first state must be in the 'open' category")
+	}
+
+	for _, transition := range workflow.Transitions {
+		if !keys[transition.From] || !keys[transition.To] {
+			return fmt.Errorf("Transition '%s' -> '%s' references an unknown state", transition.From, transition.To)
+		}
+	}
+	return nil
+}
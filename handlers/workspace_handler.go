@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	Service *usecases.WorkspaceService
+}
+
+func (h *WorkspaceHandler) GetWorkspaces(c *gin.Context) {
+	memberships, err := h.Service.GetWorkspaces(currentUserID(c))
+	if err != nil {
+		log.Printf("[X] Failed to fetch workspaces: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch workspaces"})
+		return
+	}
+
+	log.Printf("[V] Successfully fetched workspaces\n")
+	c.JSON(http.StatusOK, gin.H{"workspaces": presenters.FormatWorkspaceList(memberships)})
+}
+
+func (h *WorkspaceHandler) CreateWorkspace(c *gin.Context) {
+	var workspace models.Workspace
+	if err := c.ShouldBindJSON(&workspace); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateWorkspace(&workspace); err != nil {
+		log.Printf("[X] Workspace validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workspace.ID = 0
+	if err := h.Service.CreateWorkspace(&workspace, currentUserID(c)); err != nil {
+		log.Printf("[X] Failed to create workspace: %v\n", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create workspace"})
+		return
+	}
+
+	log.Printf("[V] Workspace created successfully: ID %d\n", workspace.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "Workspace created successfully",
+		"workspace": presenters.FormatWorkspace(&workspace, models.RoleOwner),
+	})
+}
+
+func (h *WorkspaceHandler) GetWorkspaceByID(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	workspace, member, err := h.Service.GetWorkspace(id, currentUserID(c))
+	if err != nil {
+		respondWorkspaceError(c, id, "Fetching workspace", err)
+		return
+	}
+
+	log.Printf("[V] Workspace retrieved: ID %d\n", id)
+	c.JSON(http.StatusOK, presenters.FormatWorkspace(workspace, member.Role))
+}
+
+func (h *WorkspaceHandler) UpdateWorkspace(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	var updatedWorkspace models.Workspace
+	if err := c.ShouldBindJSON(&updatedWorkspace); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := validateWorkspace(&updatedWorkspace); err != nil {
+		log.Printf("[X] Workspace validation failed: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.Service.UpdateWorkspace(id, currentUserID(c), &updatedWorkspace); err != nil {
+		respondWorkspaceError(c, id, "Workspace update", err)
+		return
+	}
+
+	log.Printf("[V] Workspace updated successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Workspace updated successfully"})
+}
+
+func (h *WorkspaceHandler) DeleteWorkspace(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	if err := h.Service.DeleteWorkspace(id, currentUserID(c)); err != nil {
+		respondWorkspaceError(c, id, "Workspace deletion", err)
+		return
+	}
+
+	log.Printf("[V] Workspace deleted successfully: ID %d\n", id)
+	c.JSON(http.StatusOK, gin.H{"message": "Workspace deleted successfully"})
+}
+
+func (h *WorkspaceHandler) GetMembers(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	members, err := h.Service.GetMembers(id, currentUserID(c))
+	if err != nil {
+		respondWorkspaceError(c, id, "Fetching members", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": presenters.FormatWorkspaceMembers(members)})
+}
+
+// GetWorkspaceUsers lists the members of the request's workspace in the same
+// shape as GET /api/users, for picking assignees.
+func (h *WorkspaceHandler) GetWorkspaceUsers(c *gin.Context) {
+	id := workspaceID(c)
+	members, err := h.Service.GetMembers(id, currentUserID(c))
+	if err != nil {
+		respondWorkspaceError(c, id, "Fetching members", err)
+		return
+	}
+
+	users := make([]models.User, len(members))
+	for i, member := range members {
+		users[i] = member.User
+	}
+	c.JSON(http.StatusOK, gin.H{"users": presenters.FormatMembers(users)})
+}
+
+func (h *WorkspaceHandler) UpdateMemberRole(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil || memberID == 0 {
+		log.Printf("[X] Invalid user ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var input struct {
+		Role string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.Service.UpdateMemberRole(id, currentUserID(c), uint(memberID), input.Role); err != nil {
+		respondWorkspaceError(c, id, "Role update", err)
+		return
+	}
+
+	log.Printf("[V] Role of user %d in workspace %d set to %s\n", memberID, id, input.Role)
+	c.JSON(http.StatusOK, gin.H{"message": "Role updated successfully"})
+}
+
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	memberID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil || memberID == 0 {
+		log.Printf("[X] Invalid user ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.Service.RemoveMember(id, currentUserID(c), uint(memberID)); err != nil {
+		respondWorkspaceError(c, id, "Removing member", err)
+		return
+	}
+
+	log.Printf("[V] User %d removed from workspace %d\n", memberID, id)
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+func (h *WorkspaceHandler) CreateInvitation(c *gin.Context) {
+	id, err := parseWorkspaceID(c)
+	if err != nil {
+		log.Printf("[X] Invalid workspace ID: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+		return
+	}
+
+	input := struct {
+		Role string `json:"role"`
+	}{Role: models.RoleMember}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	invitation, token, err := h.Service.CreateInvitation(id, currentUserID(c), input.Role)
+	if err != nil {
+		respondWorkspaceError(c, id, "Creating invitation", err)
+		return
+	}
+
+	log.Printf("[V] Invitation created for workspace %d: ID %d\n", id, invitation.ID)
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Invitation created successfully",
+		"invitation": presenters.FormatInvitation(invitation, token),
+	})
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	var input struct {
+		Token string `json:"token"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil || input.Token == "" {
+		log.Printf("[X] Invalid request body: %v\n", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	member, err := h.Service.AcceptInvitation(input.Token, currentUserID(c))
+	if err != nil {
+		respondWorkspaceError(c, 0, "Accepting invitation", err)
+		return
+	}
+
+	workspace, _, err := h.Service.GetWorkspace(member.WorkspaceID, member.UserID)
+	if err != nil {
+		respondWorkspaceError(c, member.WorkspaceID, "Fetching workspace", err)
+		return
+	}
+
+	log.Printf("[V] User %d joined workspace %d as %s\n", member.UserID, member.WorkspaceID, member.Role)
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Invitation accepted successfully",
+		"workspace": presenters.FormatWorkspace(workspace, member.Role),
+	})
+}
+
+// respondWorkspaceError maps errors from WorkspaceService to responses.
+func respondWorkspaceError(c *gin.Context, id uint, action string, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrWorkspaceForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrWorkspaceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case isClientError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Printf("[X] %s failed (workspace %d): %v\n", action, id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update workspace"})
+	}
+}
+
+func parseWorkspaceID(c *gin.Context) (uint, error) {
+	id, err := strconv.ParseUint(c.Param("workspaceId"), 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid workspace ID %q", c.Param("workspaceId"))
+	}
+	return uint(id), nil
+}
+
+func validateWorkspace(workspace *models.Workspace) error {
+	workspace.Name = strings.TrimSpace(workspace.Name)
+	if workspace.Name == "" {
+		return fmt.Errorf("Name is required")
+	}
+	if len(workspace.Name) > 100 {
+		return fmt.Errorf("Name must be at most 100 characters")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/yasseryazid/technical-test/utils"
 )
 
@@ -27,6 +28,20 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		c.Set("user_id", claims["user_id"])
 		c.Set("username", claims["username"])
+		c.Set("permissions", tokenPermissions(claims))
 		c.Next()
 	}
 }
+
+// tokenPermissions reads the permissions embedded in a token's claims.
+// Tokens issued before permissions existed carry none.
+func tokenPermissions(claims jwt.MapClaims) []string {
+	values, _ := claims["permissions"].([]interface{})
+	permissions := make([]string, 0, len(values))
+	for _, value := range values {
+		if permission, ok := value.(string); ok {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yasseryazid/technical-test/presenters"
+)
+
+// NegotiationMiddleware answers 406 when the Accept header names none of the
+// formats that can show the route's responses, which have the type of
+// sample; nil stands for responses only formats like JSON can show. It runs
+// before the handler so a write isn't made only to fail on its response.
+// The 406 lists the supported types.
+func NegotiationMiddleware(sample any) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := presenters.Negotiate(c.GetHeader("Accept"), sample); !ok {
+			c.JSON(http.StatusNotAcceptable, gin.H{
+				"error":     "None of the accepted media types can be produced",
+				"supported": presenters.MediaTypes(),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
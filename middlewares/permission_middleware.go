@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// RequirePermission lets a request through only if the user holds every
+// listed permission, either from their token or from their role in the
+// active workspace. Workspace permissions need WorkspaceMiddleware to run
+// first. The 403 response names the first permission that is missing.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, permission := range permissions {
+			if !HasPermission(c, permission) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":              fmt.Sprintf("Missing permission '%s'", permission),
+					"missing_permission": permission,
+				})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the request's user holds permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	if value, ok := c.Get("permissions"); ok {
+		if granted, ok := value.([]string); ok {
+			for _, p := range granted {
+				if p == permission {
+					return true
+				}
+			}
+		}
+	}
+	return models.RoleHasPermission(c.GetString("workspace_role"), permission)
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// TimezoneMiddleware loads the authenticated user's timezone and stores it
+// as "location" for handlers that render dates. It must run after
+// AuthMiddleware; unknown users and zones fall back to UTC.
+func TimezoneMiddleware() gin.HandlerFunc {
+	userRepo := repositories.NewUserRepository()
+
+	return func(c *gin.Context) {
+		location := time.UTC
+
+		if userID, ok := c.Get("user_id"); ok {
+			if id, ok := userID.(float64); ok {
+				if user, err := userRepo.GetUserByID(uint(id)); err == nil && user.Timezone != "" {
+					if loaded, err := time.LoadLocation(user.Timezone); err == nil {
+						location = loaded
+					}
+				}
+			}
+		}
+
+		c.Set("location", location)
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// WorkspaceHeader selects the active workspace on routes that don't name it
+// in their path.
+const WorkspaceHeader = "X-Workspace-ID"
+
+// WorkspaceMiddleware resolves the workspace a request works in and stores
+// its ID and the user's role as "workspace_id" and "workspace_role". The
+// workspace comes from the :workspaceId path segment, then the
+// X-Workspace-ID header, and defaults to the user's oldest workspace. It must
+// run after AuthMiddleware.
+func WorkspaceMiddleware() gin.HandlerFunc {
+	workspaceRepo := repositories.NewWorkspaceRepository()
+
+	return func(c *gin.Context) {
+		var userID uint
+		if value, ok := c.Get("user_id"); ok {
+			if id, ok := value.(float64); ok {
+				userID = uint(id)
+			}
+		}
+
+		value := c.Param("workspaceId")
+		if value == "" {
+			value = c.GetHeader(WorkspaceHeader)
+		}
+
+		var member *models.WorkspaceMember
+		if value == "" {
+			memberships, err := workspaceRepo.GetMemberships(userID)
+			if err != nil || len(memberships) == 0 {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of any workspace"})
+				c.Abort()
+				return
+			}
+			member = &memberships[0]
+		} else {
+			workspaceID, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || workspaceID == 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace ID"})
+				c.Abort()
+				return
+			}
+			member, err = workspaceRepo.GetMember(uint(workspaceID), userID)
+			if err != nil {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this workspace"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set("workspace_id", member.WorkspaceID)
+		c.Set("workspace_role", member.Role)
+		c.Next()
+	}
+}
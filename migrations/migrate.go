@@ -5,17 +5,311 @@ import (
 
 	"github.com/yasseryazid/technical-test/config"
 	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
 )
 
 func RunMigration() {
-	if err := config.DB.AutoMigrate(&models.Task{}, &models.User{}); err != nil {
+	convertDueDateColumn()
+	dropGlobalLabelNameIndex()
+
+	if err := config.DB.AutoMigrate(&models.TaskSeries{}, &models.Task{}, &models.User{}, &models.SavedView{}, &models.Project{}, &models.Label{}, &models.Workflow{}, &models.TaskTransition{}, &models.TaskDependency{}, &models.Reminder{}, &models.Notification{}, &models.TaskAssignee{}, &models.Workspace{}, &models.WorkspaceMember{}, &models.WorkspaceInvitation{}, &models.Comment{}, &models.CommentRevision{}, &models.Attachment{}, &models.TaskActivity{}, &models.TaskRevision{}, &models.ImportJob{}); err != nil {
 		fmt.Println("[X] Migration failed:", err)
 		return
 	}
 	fmt.Println("[V] Migration successful")
 
+	backfillTaskStatusCategory()
 	insertDummyIntoTaskTable()
 	insertDummyIntoUserTable()
+	insertDummyIntoWorkflowTable()
+	backfillWorkspaces()
+	backfillProjectWorkspaces()
+	backfillWorkflowWorkspaces()
+	backfillLabelWorkspaces()
+	backfillSeriesWorkspaces()
+	backfillRevisions()
+}
+
+// backfillTaskStatusCategory marks tasks completed before workflows existed
+// as done.
+func backfillTaskStatusCategory() {
+	result := config.DB.Model(&models.Task{}).
+		Where("status = ? AND status_category = ?", "completed", models.StatusCategoryOpen).
+		Update("status_category", models.StatusCategoryDone)
+	if result.Error != nil {
+		fmt.Println("[X] Failed to backfill task status categories:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("[V] Backfilled status category for %d tasks\n", result.RowsAffected)
+	}
+}
+
+// backfillWorkspaces gives every user without a workspace a personal one and
+// moves tasks created before workspaces existed into the first user's.
+func backfillWorkspaces() {
+	var users []models.User
+	config.DB.Where("id NOT IN (?)", config.DB.Model(&models.WorkspaceMember{}).Select("user_id")).Order("id ASC").Find(&users)
+	for _, user := range users {
+		workspace := models.Workspace{Name: user.Username + "'s workspace", OwnerID: user.ID}
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&workspace).Error; err != nil {
+				return err
+			}
+			return tx.Create(&models.WorkspaceMember{WorkspaceID: workspace.ID, UserID: user.ID, Role: models.RoleOwner}).Error
+		})
+		if err != nil {
+			fmt.Println("[X] Failed to create workspace for user", user.Username+":", err)
+			return
+		}
+		fmt.Printf("[V] Created workspace for user %s\n", user.Username)
+	}
+
+	var first models.Workspace
+	if err := config.DB.Order("id ASC").First(&first).Error; err != nil {
+		return
+	}
+	result := config.DB.Model(&models.Task{}).Where("workspace_id = ? OR workspace_id IS NULL", 0).Update("workspace_id", first.ID)
+	if result.Error != nil {
+		fmt.Println("[X] Failed to move tasks into a workspace:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("[V] Moved %d tasks into workspace '%s'\n", result.RowsAffected, first.Name)
+	}
+}
+
+// firstWorkspaceID is where rows that no task or project ties to a workspace
+// are moved.
+func firstWorkspaceID() uint {
+	var first models.Workspace
+	if err := config.DB.Order("id ASC").First(&first).Error; err != nil {
+		return 0
+	}
+	return first.ID
+}
+
+// backfillProjectWorkspaces moves projects created before they belonged to a
+// workspace into the workspace of their tasks. A project with tasks in
+// several workspaces is copied into each of the others, and those tasks are
+// moved to the copy.
+func backfillProjectWorkspaces() {
+	first := firstWorkspaceID()
+	if first == 0 {
+		return
+	}
+
+	var projects []models.Project
+	config.DB.Where("workspace_id = ?", 0).Order("id ASC").Find(&projects)
+	for _, project := range projects {
+		var workspaceIDs []uint
+		config.DB.Unscoped().Model(&models.Task{}).Where("project_id = ?", project.ID).
+			Distinct().Order("workspace_id ASC").Pluck("workspace_id", &workspaceIDs)
+		if len(workspaceIDs) == 0 {
+			workspaceIDs = []uint{first}
+		}
+
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&project).Update("workspace_id", workspaceIDs[0]).Error; err != nil {
+				return err
+			}
+			for _, workspaceID := range workspaceIDs[1:] {
+				copied := project
+				copied.ID = 0
+				copied.WorkspaceID = workspaceID
+				if err := tx.Create(&copied).Error; err != nil {
+					return err
+				}
+				err := tx.Unscoped().Model(&models.Task{}).
+					Where("project_id = ? AND workspace_id = ?", project.ID, workspaceID).
+					Update("project_id", copied.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Println("[X] Failed to move project", project.Name, "into a workspace:", err)
+			return
+		}
+	}
+	if len(projects) > 0 {
+		fmt.Printf("[V] Moved %d projects into workspaces\n", len(projects))
+	}
+}
+
+// backfillWorkflowWorkspaces moves workflows into the workspace of the
+// projects using them, copying the ones shared between workspaces. It runs
+// after backfillProjectWorkspaces.
+func backfillWorkflowWorkspaces() {
+	first := firstWorkspaceID()
+	if first == 0 {
+		return
+	}
+
+	var workflows []models.Workflow
+	config.DB.Where("workspace_id = ?", 0).Order("id ASC").Find(&workflows)
+	for _, workflow := range workflows {
+		var workspaceIDs []uint
+		config.DB.Model(&models.Project{}).Where("workflow_id = ?", workflow.ID).
+			Distinct().Order("workspace_id ASC").Pluck("workspace_id", &workspaceIDs)
+		if len(workspaceIDs) == 0 {
+			workspaceIDs = []uint{first}
+		}
+
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&workflow).Update("workspace_id", workspaceIDs[0]).Error; err != nil {
+				return err
+			}
+			for _, workspaceID := range workspaceIDs[1:] {
+				copied := workflow
+				copied.ID = 0
+				copied.WorkspaceID = workspaceID
+				if err := tx.Create(&copied).Error; err != nil {
+					return err
+				}
+				err := tx.Model(&models.Project{}).
+					Where("workflow_id = ? AND workspace_id = ?", workflow.ID, workspaceID).
+					Update("workflow_id", copied.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Println("[X] Failed to move workflow", workflow.Name, "into a workspace:", err)
+			return
+		}
+	}
+	if len(workflows) > 0 {
+		fmt.Printf("[V] Moved %d workflows into workspaces\n", len(workflows))
+	}
+}
+
+// backfillSeriesWorkspaces moves series into the workspace of their
+// occurrences. Series are never shared, so the first occurrence decides.
+func backfillSeriesWorkspaces() {
+	result := config.DB.Exec(`
+		UPDATE task_series SET workspace_id = (
+			SELECT tasks.workspace_id FROM tasks
+			WHERE tasks.series_id = task_series.id
+			ORDER BY tasks.occurrence ASC LIMIT 1
+		)
+		WHERE workspace_id = 0 AND EXISTS (SELECT 1 FROM tasks WHERE tasks.series_id = task_series.id)`)
+	if result.Error != nil {
+		fmt.Println("[X] Failed to move series into workspaces:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("[V] Moved %d series into workspaces\n", result.RowsAffected)
+	}
+}
+
+// backfillLabelWorkspaces moves labels into the workspace of the tasks
+// carrying them, copying the ones shared between workspaces.
+func backfillLabelWorkspaces() {
+	first := firstWorkspaceID()
+	if first == 0 {
+		return
+	}
+
+	var labels []models.Label
+	config.DB.Where("workspace_id = ?", 0).Order("id ASC").Find(&labels)
+	for _, label := range labels {
+		var workspaceIDs []uint
+		config.DB.Table("task_labels").
+			Joins("JOIN tasks ON tasks.id = task_labels.task_id").
+			Where("task_labels.label_id = ?", label.ID).
+			Distinct().Order("tasks.workspace_id ASC").Pluck("tasks.workspace_id", &workspaceIDs)
+		if len(workspaceIDs) == 0 {
+			workspaceIDs = []uint{first}
+		}
+
+		err := config.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&label).Update("workspace_id", workspaceIDs[0]).Error; err != nil {
+				return err
+			}
+			for _, workspaceID := range workspaceIDs[1:] {
+				copied := label
+				copied.ID = 0
+				copied.WorkspaceID = workspaceID
+				if err := tx.Create(&copied).Error; err != nil {
+					return err
+				}
+				err := tx.Exec("UPDATE task_labels SET label_id = ? WHERE label_id = ? AND task_id IN (SELECT id FROM tasks WHERE workspace_id = ?)",
+					copied.ID, label.ID, workspaceID).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Println("[X] Failed to move label", label.Name, "into a workspace:", err)
+			return
+		}
+	}
+	if len(labels) > 0 {
+		fmt.Printf("[V] Moved %d labels into workspaces\n", len(labels))
+	}
+}
+
+// dropGlobalLabelNameIndex drops the index that kept label names unique
+// across every workspace; they are now unique within one.
+func dropGlobalLabelNameIndex() {
+	if !config.DB.Migrator().HasIndex(&models.Label{}, "idx_labels_name") {
+		return
+	}
+	if err := config.DB.Migrator().DropIndex(&models.Label{}, "idx_labels_name"); err != nil {
+		fmt.Println("[X] Failed to drop the label name index:", err)
+	}
+}
+
+// backfillRevisions gives tasks created before revisions existed a first
+// revision holding their current state, so they can be restored to it.
+func backfillRevisions() {
+	var tasks []models.Task
+	result := config.DB.Preload("Labels").
+		Where("id NOT IN (?)", config.DB.Model(&models.TaskRevision{}).Select("task_id")).
+		FindInBatches(&tasks, 500, func(tx *gorm.DB, batch int) error {
+			revisions := make([]models.TaskRevision, len(tasks))
+			for i := range tasks {
+				revisions[i] = models.TaskRevision{
+					TaskID:    tasks[i].ID,
+					Revision:  1,
+					Action:    models.ActivityCreated,
+					Snapshot:  tasks[i].Snapshot(),
+					CreatedAt: tasks[i].CreatedAt,
+				}
+			}
+			return config.DB.Create(&revisions).Error
+		})
+	if result.Error != nil {
+		fmt.Println("[X] Failed to backfill task revisions:", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("[V] Backfilled first revision for %d tasks\n", result.RowsAffected)
+	}
+}
+
+// convertDueDateColumn turns the old date-only due_date column into a
+// timestamptz at midnight UTC. Left to AutoMigrate, the dates would be
+// shifted by the database session's time zone.
+func convertDueDateColumn() {
+	var dataType string
+	config.DB.Raw("SELECT data_type FROM information_schema.columns WHERE table_name = 'tasks' AND column_name = 'due_date'").Scan(&dataType)
+	if dataType != "date" {
+		return
+	}
+
+	if err := config.DB.Exec("ALTER TABLE tasks ALTER COLUMN due_date TYPE timestamptz USING due_date::timestamp AT TIME ZONE 'UTC'").Error; err != nil {
+		fmt.Println("[X] Failed to convert task due dates:", err)
+		return
+	}
+	fmt.Println("[V] Converted task due dates to timestamps")
 }
 
 func insertDummyIntoTaskTable() {
@@ -28,9 +322,9 @@ func insertDummyIntoTaskTable() {
 	}
 
 	dummyTasks := []models.Task{
-		{Title: "Task 1", Description: "Description for Task 1", Status: "pending", DueDate: "2025-03-10"},
-		{Title: "Task 2", Description: "Description for Task 2", Status: "completed", DueDate: "2025-03-12"},
-		{Title: "Task 3", Description: "Description for Task 3", Status: "pending", DueDate: "2025-03-15"},
+		{Title: "Task 1", Description: "Description for Task 1", Status: "pending", StatusCategory: models.StatusCategoryOpen, DueDate: dummyDueDate("2025-03-10")},
+		{Title: "Task 2", Description: "Description for Task 2", Status: "completed", StatusCategory: models.StatusCategoryDone, DueDate: dummyDueDate("2025-03-12")},
+		{Title: "Task 3", Description: "Description for Task 3", Status: "pending", StatusCategory: models.StatusCategoryOpen, DueDate: dummyDueDate("2025-03-15")},
 	}
 
 	if err := config.DB.Create(&dummyTasks).Error; err != nil {
@@ -41,6 +335,11 @@ func insertDummyIntoTaskTable() {
 	fmt.Println("[V] Dummy Tasks data inserted into 'tasks' table")
 }
 
+func dummyDueDate(value string) models.DueDate {
+	due, _ := models.ParseDueDate(value)
+	return due
+}
+
 func insertDummyIntoUserTable() {
 	var count int64
 	config.DB.Model(&models.User{}).Count(&count)
@@ -51,7 +350,7 @@ func insertDummyIntoUserTable() {
 	}
 
 	dummyUsers := []models.User{
-		{Username: "admin", Password: "$2a$10$a1RoSuluLXdshtNXVZQ0Be3V9vVGohPIUVt/26I3kJs.4PQvyYlL6"}, // Password: "password"
+		{Username: "admin", Password: "$2a$10$a1RoSuluLXdshtNXVZQ0Be3V9vVGohPIUVt/26I3kJs.4PQvyYlL6", IsAdmin: true}, // Password: "password"
 	}
 
 	if err := config.DB.Create(&dummyUsers).Error; err != nil {
@@ -61,3 +360,42 @@ func insertDummyIntoUserTable() {
 
 	fmt.Println("[V] Dummy user data inserted into 'users' table")
 }
+
+func insertDummyIntoWorkflowTable() {
+	var count int64
+	config.DB.Model(&models.Workflow{}).Count(&count)
+
+	if count > 0 {
+		fmt.Println("[!] Dummy Workflow data already exists, skipping insertion")
+		return
+	}
+
+	dummyWorkflow := models.Workflow{
+		Name: "Development",
+		States: []models.WorkflowState{
+			{Key: "todo", Name: "To Do", Category: models.StatusCategoryOpen},
+			{Key: "in_progress", Name: "In Progress", Category: models.StatusCategoryOpen},
+			{Key: "review", Name: "Review", Category: models.StatusCategoryOpen},
+			{Key: "done", Name: "Done", Category: models.StatusCategoryDone},
+			{Key: "cancelled", Name: "Cancelled", Category: models.StatusCategoryCancelled},
+		},
+		Transitions: []models.WorkflowTransition{
+			{From: "todo", To: "in_progress"},
+			{From: "in_progress", To: "review"},
+			{From: "in_progress", To: "todo"},
+			{From: "review", To: "done"},
+			{From: "review", To: "in_progress"},
+			{From: "todo", To: "cancelled"},
+			{From: "in_progress", To: "cancelled"},
+			{From: "review", To: "cancelled"},
+			{From: "cancelled", To: "todo"},
+		},
+	}
+
+	if err := config.DB.Create(&dummyWorkflow).Error; err != nil {
+		fmt.Println("[X] Failed to insert dummy workflow data:", err)
+		return
+	}
+
+	fmt.Println("[V] Dummy workflow data inserted into 'workflows' table")
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Actions recorded in the activity log.
+const (
+	ActivityCreated      = "created"
+	ActivityUpdated      = "updated"
+	ActivityTransitioned = "transitioned"
+	ActivityDeleted      = "deleted"
+	ActivityMoved        = "moved"
+	ActivityLabeled      = "labeled"
+	ActivityUnlabeled    = "unlabeled"
+	ActivityAssigned     = "assigned"
+	ActivityUnassigned   = "unassigned"
+	ActivityRestored     = "restored"
+	ActivityUndeleted    = "undeleted"
+	ActivityPurged       = "purged"
+	ActivityArchived     = "archived"
+	ActivityUnarchived   = "unarchived"
+)
+
+// FieldChange is one field of a task before and after a change. From is nil
+// for new tasks and To is nil for deleted ones.
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// TaskActivity records who changed a task, when, in which request and how.
+// Entries outlive the task so deletions stay auditable. UserID is nil for
+// changes the system makes on its own, such as recurring tasks.
+type TaskActivity struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	TaskID      uint          `gorm:"index;not null" json:"task_id"`
+	WorkspaceID uint          `gorm:"index;default:0" json:"workspace_id"`
+	UserID      *uint         `gorm:"index" json:"user_id"`
+	Action      string        `gorm:"type:varchar(20);index;not null" json:"action"`
+	RequestID   string        `gorm:"type:varchar(64);index" json:"request_id"`
+	Changes     []FieldChange `gorm:"type:jsonb;serializer:json" json:"changes"`
+	CreatedAt   time.Time     `gorm:"index" json:"created_at"`
+	User        *User         `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// ActivityFilter narrows the audit log. Zero fields match everything; From
+// and To are inclusive YYYY-MM-DD dates in UTC.
+type ActivityFilter struct {
+	TaskID      uint
+	UserID      uint
+	WorkspaceID uint
+	Action      string
+	RequestID   string
+	From        string
+	To          string
+}
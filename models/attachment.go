@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a task. Its contents are stored once per
+// SHA-256 Hash, so uploading the same file twice keeps a single copy.
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TaskID      uint      `gorm:"index;not null" json:"task_id"`
+	UploadedBy  uint      `gorm:"not null" json:"uploaded_by"`
+	Filename    string    `gorm:"not null" json:"filename"`
+	ContentType string    `gorm:"not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	Hash        string    `gorm:"size:64;index;not null" json:"hash"`
+	CreatedAt   time.Time `json:"created_at"`
+	Uploader    User      `gorm:"foreignKey:UploadedBy" json:"-"`
+}
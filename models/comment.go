@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Comment is a Markdown comment on a task. Replies point at a top-level
+// comment through ParentID; threads are one level deep.
+type Comment struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	TaskID    uint       `gorm:"index;not null" json:"task_id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	ParentID  *uint      `gorm:"index" json:"parent_id"`
+	Body      string     `gorm:"type:text;not null" json:"body"`
+	EditedAt  *time.Time `json:"edited_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	User      User       `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// CommentRevision keeps the body a comment had before an edit.
+type CommentRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommentID uint      `gorm:"index;not null" json:"comment_id"`
+	Body      string    `gorm:"type:text;not null" json:"body"`
+	EditedBy  uint      `json:"edited_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
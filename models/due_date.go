@@ -0,0 +1,136 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const dueDateLayout = "2006-01-02"
+
+// DueDate is a task deadline: either a calendar date, stored as midnight UTC,
+// or an instant when HasTime is set. The zero value means no due date. It is
+// written to JSON as "YYYY-MM-DD" or an RFC 3339 date-time.
+type DueDate struct {
+	Time    *time.Time `gorm:"column:date;type:timestamptz"`
+	HasTime bool       `gorm:"column:has_time;default:false"`
+}
+
+// DueDateError explains why a due date could not be parsed.
+type DueDateError struct {
+	Value string
+}
+
+func (e *DueDateError) Error() string {
+	return fmt.Sprintf("Invalid due_date '%s'. Use YYYY-MM-DD or an ISO 8601 date-time with offset, e.g. 2025-01-06T09:00:00+07:00", e.Value)
+}
+
+// ParseDueDate accepts a date ("2025-01-06") or a date-time with a zone
+// offset ("2025-01-06T09:00:00Z"). An empty value is no due date.
+func ParseDueDate(value string) (DueDate, error) {
+	if value == "" {
+		return DueDate{}, nil
+	}
+	if date, err := time.Parse(dueDateLayout, value); err == nil {
+		return DateOnly(date), nil
+	}
+	if instant, err := time.Parse(time.RFC3339, value); err == nil {
+		return DueDate{Time: &instant, HasTime: true}, nil
+	}
+	return DueDate{}, &DueDateError{Value: value}
+}
+
+// DateOnly returns the calendar date of t as a due date without a time.
+func DateOnly(t time.Time) DueDate {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return DueDate{Time: &date}
+}
+
+func (d DueDate) IsZero() bool {
+	return d.Time == nil
+}
+
+// day returns the stored time, in UTC for dates without a time. Those are
+// kept as midnight UTC, but the database may hand them back in its session's
+// time zone, where they can fall on the day before.
+func (d DueDate) day() time.Time {
+	if d.HasTime {
+		return *d.Time
+	}
+	return d.Time.UTC()
+}
+
+// Date returns the calendar day the task is due, as midnight UTC.
+func (d DueDate) Date() time.Time {
+	if d.Time == nil {
+		return time.Time{}
+	}
+	day := d.day()
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Deadline returns the moment the task is due. A date without a time is due
+// at the start of that day in loc.
+func (d DueDate) Deadline(loc *time.Location) time.Time {
+	if d.Time == nil {
+		return time.Time{}
+	}
+	if d.HasTime {
+		return *d.Time
+	}
+	day := d.day()
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+}
+
+// In returns the due date with its time shown in loc. Dates without a time
+// are the same day everywhere and are returned unchanged.
+func (d DueDate) In(loc *time.Location) DueDate {
+	if d.Time == nil || !d.HasTime {
+		return d
+	}
+	local := d.Time.In(loc)
+	return DueDate{Time: &local, HasTime: true}
+}
+
+// Equal reports whether two due dates describe the same deadline.
+func (d DueDate) Equal(other DueDate) bool {
+	if d.Time == nil || other.Time == nil {
+		return d.Time == nil && other.Time == nil
+	}
+	return d.HasTime == other.HasTime && d.Time.Equal(*other.Time)
+}
+
+func (d DueDate) String() string {
+	if d.Time == nil {
+		return ""
+	}
+	if d.HasTime {
+		return d.Time.Format(time.RFC3339)
+	}
+	return d.day().Format(dueDateLayout)
+}
+
+func (d DueDate) MarshalJSON() ([]byte, error) {
+	if d.Time == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *DueDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = DueDate{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return &DueDateError{Value: string(data)}
+	}
+	parsed, err := ParseDueDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
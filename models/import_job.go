@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Import job states.
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+// ImportRowError explains why one row of an import was rejected. Rows are
+// numbered as in the file: CSV rows count the header line, JSON rows are
+// the 1-based index in the array.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportJob tracks a task import running in the background. Processed goes
+// from 0 to Total; Error is set when the job stopped before finishing.
+type ImportJob struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint             `gorm:"index" json:"workspace_id"`
+	UserID      uint             `gorm:"index" json:"user_id"`
+	Format      string           `gorm:"type:varchar(10);not null" json:"format"`
+	Status      string           `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	Total       int              `json:"total"`
+	Processed   int              `json:"processed"`
+	Created     int              `json:"created"`
+	Failed      int              `json:"failed"`
+	Errors      []ImportRowError `gorm:"type:jsonb;serializer:json" json:"errors"`
+	Error       string           `gorm:"type:text" json:"error"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	FinishedAt  *time.Time       `json:"finished_at"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Label belongs to a workspace; names are unique within it.
+type Label struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"uniqueIndex:idx_labels_workspace_name;default:0" json:"-"`
+	Name        string    `gorm:"type:varchar(100);uniqueIndex:idx_labels_workspace_name;not null" json:"name"`
+	Color       string    `gorm:"type:varchar(7)" json:"color"`
+	CreatedAt   time.Time `json:"created_at"`
+}
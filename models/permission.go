@@ -0,0 +1,31 @@
+package models
+
+// Permissions checked by RequirePermission. Workspace permissions come from
+// the user's role in the active workspace; user:admin is granted to
+// administrators across the whole installation and travels in their token.
+const (
+	PermTaskRead        = "task:read"
+	PermTaskWrite       = "task:write"
+	PermTaskDelete      = "task:delete"
+	PermWorkspaceManage = "workspace:manage"
+	PermWorkspaceDelete = "workspace:delete"
+	PermUserAdmin       = "user:admin"
+)
+
+// RolePermissions lists what each workspace role may do.
+var RolePermissions = map[string][]string{
+	RoleOwner:  {PermTaskRead, PermTaskWrite, PermTaskDelete, PermWorkspaceManage, PermWorkspaceDelete},
+	RoleAdmin:  {PermTaskRead, PermTaskWrite, PermTaskDelete, PermWorkspaceManage},
+	RoleMember: {PermTaskRead, PermTaskWrite},
+	RoleViewer: {PermTaskRead},
+}
+
+// RoleHasPermission reports whether a workspace role grants permission.
+func RoleHasPermission(role, permission string) bool {
+	for _, granted := range RolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
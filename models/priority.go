@@ -0,0 +1,23 @@
+package models
+
+const (
+	PriorityNone   = "none"
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Priorities lists the task priorities from lowest to highest.
+var Priorities = []string{PriorityNone, PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// PriorityRank returns the position of priority in Priorities, or -1 when
+// the priority is unknown.
+func PriorityRank(priority string) int {
+	for i, known := range Priorities {
+		if known == priority {
+			return i
+		}
+	}
+	return -1
+}
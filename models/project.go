@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Project struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint      `gorm:"index;default:0" json:"-"`
+	Name        string    `gorm:"type:varchar(255);not null" json:"name"`
+	Color       string    `gorm:"type:varchar(7)" json:"color"`
+	Description string    `gorm:"type:text" json:"description"`
+	Archived    bool      `gorm:"default:false" json:"archived"`
+	WorkflowID  *uint     `json:"workflow_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
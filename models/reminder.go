@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Reminder delivery channels.
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+	ChannelInApp   = "in_app"
+)
+
+// Reminder states. A pending reminder is waiting in the delay queue.
+const (
+	ReminderPending = "pending"
+	ReminderSent    = "sent"
+	ReminderFailed  = "failed"
+	ReminderSkipped = "skipped"
+)
+
+// Reminder fires at RemindAt, or OffsetMinutes before the task is due. FireAt
+// is the resolved time and is recomputed when the due date moves. Target is
+// the webhook URL or email address; in-app reminders go to UserID.
+type Reminder struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	TaskID        uint       `gorm:"index;not null" json:"task_id"`
+	UserID        uint       `gorm:"index" json:"user_id"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	FireAt        time.Time  `gorm:"index" json:"fire_at"`
+	Channel       string     `gorm:"type:varchar(20);not null" json:"channel"`
+	Target        string     `gorm:"type:varchar(255)" json:"target"`
+	Status        string     `gorm:"type:varchar(20);default:'pending'" json:"status"`
+	Attempts      int        `gorm:"default:0" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	ClaimedUntil  *time.Time `json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Notification is an in-app message shown to a user.
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	TaskID    uint       `json:"task_id"`
+	Message   string     `gorm:"type:text;not null" json:"message"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// TaskSnapshot is the full editable state of a task at one point in time.
+type TaskSnapshot struct {
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	Status       string  `json:"status"`
+	Priority     string  `json:"priority"`
+	DueDate      DueDate `json:"due_date"`
+	ProjectID    *uint   `json:"project_id"`
+	ParentID     *uint   `json:"parent_id"`
+	AutoComplete bool    `json:"auto_complete"`
+	LabelIDs     []uint  `json:"label_ids"`
+}
+
+// TaskRevision is one numbered version of a task. Revisions are numbered per
+// task from 1 and, like activity entries, outlive the task. UserID is nil
+// for changes the system makes on its own.
+type TaskRevision struct {
+	ID        uint         `gorm:"primaryKey" json:"id"`
+	TaskID    uint         `gorm:"uniqueIndex:idx_task_revision;not null" json:"task_id"`
+	Revision  int          `gorm:"uniqueIndex:idx_task_revision;not null" json:"revision"`
+	Action    string       `gorm:"type:varchar(20);not null" json:"action"`
+	UserID    *uint        `gorm:"index" json:"user_id"`
+	RequestID string       `gorm:"type:varchar(64)" json:"request_id"`
+	Snapshot  TaskSnapshot `gorm:"type:jsonb;serializer:json" json:"snapshot"`
+	CreatedAt time.Time    `json:"created_at"`
+	User      *User        `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// Snapshot captures the task's current state. Labels must be loaded.
+func (t *Task) Snapshot() TaskSnapshot {
+	labelIDs := make([]uint, len(t.Labels))
+	for i, label := range t.Labels {
+		labelIDs[i] = label.ID
+	}
+	sort.Slice(labelIDs, func(i, j int) bool { return labelIDs[i] < labelIDs[j] })
+
+	return TaskSnapshot{
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		DueDate:      t.DueDate,
+		ProjectID:    t.ProjectID,
+		ParentID:     t.ParentID,
+		AutoComplete: t.AutoComplete,
+		LabelIDs:     labelIDs,
+	}
+}
+
+// Task returns a task with the snapshot's fields, without labels.
+func (s TaskSnapshot) Task() *Task {
+	return &Task{
+		Title:        s.Title,
+		Description:  s.Description,
+		Status:       s.Status,
+		Priority:     s.Priority,
+		DueDate:      s.DueDate,
+		ProjectID:    s.ProjectID,
+		ParentID:     s.ParentID,
+		AutoComplete: s.AutoComplete,
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type SavedView struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	Name      string     `gorm:"type:varchar(100);not null" json:"name"`
+	Filters   TaskFilter `gorm:"type:jsonb;serializer:json" json:"filters"`
+	Sort      string     `gorm:"type:varchar(50)" json:"sort"`
+	Columns   []string   `gorm:"type:jsonb;serializer:json" json:"columns"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
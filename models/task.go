@@ -1,12 +1,48 @@
 package models
 
-import "time"
+import (
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// Task is a to-do item in a workspace, optionally part of a project, a
+// parent task and a recurring series.
 type Task struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	Status      string    `gorm:"type:varchar(50);default:'pending'" json:"status"`
-	DueDate     string    `gorm:"type:date" json:"due_date"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Title       string `gorm:"type:varchar(255);not null" json:"title"`
+	Description string `gorm:"type:text" json:"description"`
+	Status      string `gorm:"type:varchar(50);default:'pending'" json:"status"`
+	// StatusCategory mirrors the workflow category of Status so queries can
+	// tell open tasks from finished ones without loading the workflow.
+	StatusCategory string  `gorm:"type:varchar(20);default:'open'" json:"status_category"`
+	Priority       string  `gorm:"type:varchar(20);default:'none';index" json:"priority"`
+	DueDate        DueDate `gorm:"embedded;embeddedPrefix:due_" json:"due_date"`
+	WorkspaceID    uint    `gorm:"index;default:0" json:"-"`
+	ProjectID      *uint   `gorm:"index" json:"project_id"`
+	ParentID       *uint   `gorm:"index" json:"parent_id"`
+	// AutoComplete completes the task once all its subtasks are.
+	AutoComplete bool `gorm:"default:false" json:"auto_complete"`
+	// SeriesID and Occurrence place a recurring task in its TaskSeries. They
+	// are never read from requests.
+	SeriesID      *uint `gorm:"index" json:"-"`
+	Occurrence    int   `gorm:"default:0" json:"-"`
+	NextGenerated bool  `gorm:"default:false" json:"-"`
+	// Recurrence and RecurrenceMode are only read from requests when the
+	// series is created or changed.
+	Recurrence     string     `gorm:"-" json:"recurrence"`
+	RecurrenceMode string     `gorm:"-" json:"recurrence_mode"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	// ArchivedAt hides the task from listings whatever its status. Only the
+	// archive endpoints and job set it.
+	ArchivedAt *time.Time `gorm:"index" json:"-"`
+	CreatedAt  time.Time  `json:"created_at"`
+	// DeletedAt keeps a deleted task in the trash until it is purged; gorm
+	// leaves it out of every query that isn't Unscoped.
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Labels    []Label        `gorm:"many2many:task_labels" json:"-"`
+	Subtasks  []Task         `gorm:"foreignKey:ParentID" json:"-"`
+	BlockedBy []Task         `gorm:"many2many:task_dependencies;joinForeignKey:TaskID;joinReferences:BlockerID" json:"-"`
+	Assignees []TaskAssignee `gorm:"foreignKey:TaskID" json:"-"`
+	Series    *TaskSeries    `gorm:"foreignKey:SeriesID" json:"-"`
 }
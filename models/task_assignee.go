@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TaskAssignee records that UserID is assigned to TaskID, and by whom.
+type TaskAssignee struct {
+	TaskID     uint      `gorm:"primaryKey" json:"task_id"`
+	UserID     uint      `gorm:"primaryKey;index" json:"user_id"`
+	AssignedBy uint      `json:"assigned_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	User       User      `gorm:"foreignKey:UserID" json:"-"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// TaskDependency records that BlockerID has to be finished before TaskID.
+type TaskDependency struct {
+	TaskID    uint      `gorm:"primaryKey" json:"task_id"`
+	BlockerID uint      `gorm:"primaryKey;index" json:"blocker_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TaskFilter describes which tasks a listing should return. Relative fields
+// (overdue, due/completed within N days) are resolved at query time so a
+// saved filter keeps its meaning as days go by. LabelMatch is "any" (the
+// default) or "all". Archived tasks are left out unless IncludeArchived is
+// set. Location decides which calendar day "today" is; it is
+// set per request and never saved.
+type TaskFilter struct {
+	ProjectID           uint           `json:"project_id,omitempty"`
+	Status              string         `json:"status,omitempty"`
+	StatusCategory      string         `json:"status_category,omitempty"`
+	Priorities          []string       `json:"priorities,omitempty"`
+	Search              string         `json:"search,omitempty"`
+	DueFrom             string         `json:"due_from,omitempty"`
+	DueTo               string         `json:"due_to,omitempty"`
+	Overdue             bool           `json:"overdue,omitempty"`
+	DueWithinDays       int            `json:"due_within_days,omitempty"`
+	CompletedWithinDays int            `json:"completed_within_days,omitempty"`
+	LabelIDs            []uint         `json:"label_ids,omitempty"`
+	LabelMatch          string         `json:"label_match,omitempty"`
+	AssigneeID          uint           `json:"assignee_id,omitempty"`
+	IncludeArchived     bool           `json:"include_archived,omitempty"`
+	Location            *time.Location `json:"-"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Recurrence modes. On-complete series generate the next occurrence when the
+// current one is done; scheduled series generate it once its due date arrives.
+const (
+	RecurrenceOnComplete = "on_complete"
+	RecurrenceScheduled  = "schedule"
+)
+
+var RecurrenceModes = []string{RecurrenceOnComplete, RecurrenceScheduled}
+
+// TaskSeries is the template for a recurring task. Rule is an RFC 5545 RRULE
+// evaluated from StartDate, which is the due date of occurrence number
+// FirstOccurrence; every occurrence is a Task pointing back here.
+type TaskSeries struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	Rule            string    `gorm:"type:varchar(255);not null" json:"rule"`
+	Mode            string    `gorm:"type:varchar(20);default:'on_complete'" json:"mode"`
+	StartDate       string    `gorm:"type:date;not null" json:"start_date"`
+	FirstOccurrence int       `gorm:"default:1" json:"first_occurrence"`
+	Title           string    `gorm:"type:varchar(255);not null" json:"title"`
+	Description     string    `gorm:"type:text" json:"description"`
+	Priority        string    `gorm:"type:varchar(20);default:'none'" json:"priority"`
+	WorkspaceID     uint      `gorm:"index;default:0" json:"-"`
+	ProjectID       *uint     `json:"project_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -6,4 +6,20 @@ type User struct {
 	gorm.Model
 	Username string `json:"username" gorm:"unique;not null"`
 	Password string `json:"password" gorm:"not null"`
+	// Timezone is an IANA zone name used to render the user's dates.
+	Timezone string `json:"timezone" gorm:"type:varchar(64);default:'UTC'"`
+	// IsAdmin grants user:admin. It is never read from requests.
+	IsAdmin bool `json:"-" gorm:"default:false"`
+	// CalendarTokenHash is the SHA-256 of the secret in the user's calendar
+	// feed URL; nil when the feed is off. The secret itself isn't stored.
+	CalendarTokenHash *string `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+}
+
+// Permissions returns the permissions a user has regardless of workspace.
+// They are embedded in the user's token at login.
+func (u *User) Permissions() []string {
+	if u.IsAdmin {
+		return []string{PermUserAdmin}
+	}
+	return []string{}
 }
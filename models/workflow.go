@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Status categories group workflow states by meaning, so features such as
+// "overdue" work the same whatever the states are called.
+const (
+	StatusCategoryOpen      = "open"
+	StatusCategoryDone      = "done"
+	StatusCategoryCancelled = "cancelled"
+)
+
+type Workflow struct {
+	ID          uint                 `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint                 `gorm:"index;default:0" json:"-"`
+	Name        string               `gorm:"type:varchar(100);not null" json:"name"`
+	States      []WorkflowState      `gorm:"type:jsonb;serializer:json" json:"states"`
+	Transitions []WorkflowTransition `gorm:"type:jsonb;serializer:json" json:"transitions"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// WorkflowState is a status a task can be in. The first state of a workflow
+// is the one new tasks start in.
+type WorkflowState struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+type WorkflowTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type TaskTransition struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TaskID     uint      `gorm:"index;not null" json:"task_id"`
+	FromStatus string    `gorm:"type:varchar(50)" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(50);not null" json:"to_status"`
+	UserID     uint      `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
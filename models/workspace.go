@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Workspace roles, from most to least privileged. Every workspace has exactly
+// one owner.
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+var WorkspaceRoles = []string{RoleOwner, RoleAdmin, RoleMember, RoleViewer}
+
+// Workspace is a team's shared space. Tasks belong to exactly one workspace
+// and are only visible to its members.
+type Workspace struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Name      string            `gorm:"type:varchar(100);not null" json:"name"`
+	OwnerID   uint              `gorm:"index" json:"owner_id"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Members   []WorkspaceMember `gorm:"foreignKey:WorkspaceID" json:"-"`
+}
+
+// WorkspaceMember gives UserID a role in WorkspaceID.
+type WorkspaceMember struct {
+	WorkspaceID uint      `gorm:"primaryKey" json:"workspace_id"`
+	UserID      uint      `gorm:"primaryKey;index" json:"user_id"`
+	Role        string    `gorm:"type:varchar(20);not null" json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	Workspace   Workspace `gorm:"foreignKey:WorkspaceID" json:"-"`
+	User        User      `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// WorkspaceInvitation lets whoever holds its signed token join the workspace
+// with Role, once, before ExpiresAt.
+type WorkspaceInvitation struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	WorkspaceID uint       `gorm:"index;not null" json:"workspace_id"`
+	Role        string     `gorm:"type:varchar(20);not null" json:"role"`
+	InvitedBy   uint       `json:"invited_by"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedBy  *uint      `json:"accepted_by"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
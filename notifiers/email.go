@@ -0,0 +1,124 @@
+package notifiers
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// emailTimeout bounds a whole SMTP conversation. It stays well below the
+// reminder service's delivery lease, so a stalled server can't keep a
+// reminder claimed until another worker sends it again.
+const emailTimeout = 20 * time.Second
+
+// errEmailFailed is what a failed delivery reports; like webhook errors, the
+// SMTP server's reply is only logged.
+var errEmailFailed = errors.New("delivery failed")
+
+// EmailChannel sends reminders to the reminder's target address over SMTP.
+type EmailChannel struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// NewEmailChannel reads the SMTP settings from the environment. It returns
+// nil when SMTP_HOST is not set, leaving email reminders disabled.
+func NewEmailChannel() *EmailChannel {
+	if os.Getenv("SMTP_HOST") == "" {
+		return nil
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return &EmailChannel{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+func (e *EmailChannel) Deliver(reminder *models.Reminder, task *models.Task) error {
+	if strings.ContainsAny(reminder.Target, "\r\n") {
+		return fmt.Errorf("invalid email address")
+	}
+
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(reminderMessage(task))
+	message := strings.Join([]string{
+		"From: " + e.From,
+		"To: " + reminder.Target,
+		"Subject: " + subject,
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		reminderMessage(task),
+		"",
+		task.Description,
+	}, "\r\n")
+
+	if err := e.send(reminder.Target, []byte(message)); err != nil {
+		log.Printf("[X] Email for reminder %d failed: %v\n", reminder.ID, err)
+		return errEmailFailed
+	}
+	return nil
+}
+
+// send does what smtp.SendMail does, within emailTimeout.
+func (e *EmailChannel) send(to string, message []byte) error {
+	conn, err := (&net.Dialer{Timeout: emailTimeout}).Dial("tcp", net.JoinHostPort(e.Host, e.Port))
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(emailTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: e.Host}); err != nil {
+			return err
+		}
+	}
+	if e.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", e.Username, e.Password, e.Host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(e.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	body, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := body.Write(message); err != nil {
+		return err
+	}
+	if err := body.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,23 @@
+package notifiers
+
+import (
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// InAppChannel stores reminders as notifications for the reminder's owner.
+type InAppChannel struct {
+	Repo repositories.ReminderRepository
+}
+
+func NewInAppChannel(repo repositories.ReminderRepository) *InAppChannel {
+	return &InAppChannel{Repo: repo}
+}
+
+func (i *InAppChannel) Deliver(reminder *models.Reminder, task *models.Task) error {
+	return i.Repo.CreateNotification(&models.Notification{
+		UserID:  reminder.UserID,
+		TaskID:  task.ID,
+		Message: reminderMessage(task),
+	})
+}
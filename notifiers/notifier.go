@@ -0,0 +1,16 @@
+// Package notifiers delivers task reminders over the supported channels.
+package notifiers
+
+import (
+	"fmt"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// reminderMessage is the text every channel sends for a reminder.
+func reminderMessage(task *models.Task) string {
+	if task.DueDate.IsZero() {
+		return fmt.Sprintf("Reminder: %s", task.Title)
+	}
+	return fmt.Sprintf("Reminder: %s is due %s", task.Title, task.DueDate)
+}
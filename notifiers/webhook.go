@@ -0,0 +1,118 @@
+package notifiers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+// errWebhookFailed is what a failed delivery reports. The reason is only
+// logged: reminder errors are shown to users, and dial errors would tell
+// them which hosts and ports the server can reach.
+var errWebhookFailed = errors.New("delivery failed")
+
+// WebhookChannel POSTs reminders as JSON to the reminder's target URL. When
+// Secret is set, the body is signed in the X-Signature header as
+// "sha256=<hex HMAC>".
+type WebhookChannel struct {
+	Client *http.Client
+	Secret string
+}
+
+func NewWebhookChannel() *WebhookChannel {
+	return &WebhookChannel{
+		Client: NewPublicClient(10 * time.Second),
+		Secret: os.Getenv("WEBHOOK_SECRET"),
+	}
+}
+
+// NewPublicClient returns an HTTP client for URLs chosen by users. It only
+// connects to public addresses, checking every address a name resolves to
+// when dialing so DNS rebinding can't slip through, ignores proxy settings
+// and doesn't follow redirects.
+func NewPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !utils.IsPublicIP(addrPort.Addr()) {
+				return fmt.Errorf("refusing to connect to non-public address %s", address)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+type webhookPayload struct {
+	Event      string `json:"event"`
+	ReminderID uint   `json:"reminder_id"`
+	TaskID     uint   `json:"task_id"`
+	Title      string `json:"title"`
+	DueDate    string `json:"due_date,omitempty"`
+	Message    string `json:"message"`
+	FiredAt    string `json:"fired_at"`
+}
+
+func (w *WebhookChannel) Deliver(reminder *models.Reminder, task *models.Task) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:      "task.reminder",
+		ReminderID: reminder.ID,
+		TaskID:     task.ID,
+		Title:      task.Title,
+		DueDate:    task.DueDate.String(),
+		Message:    reminderMessage(task),
+		FiredAt:    time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, reminder.Target, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[X] Webhook for reminder %d has an invalid target: %v\n", reminder.ID, err)
+		return errWebhookFailed
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		log.Printf("[X] Webhook for reminder %d failed: %v\n", reminder.ID, err)
+		return errWebhookFailed
+	}
+	defer resp.Body.Close()
+
+	// Redirects aren't followed, so a 3xx is a failure too.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w (status %d)", errWebhookFailed, resp.StatusCode)
+	}
+	return nil
+}
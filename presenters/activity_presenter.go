@@ -0,0 +1,46 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type ActivityResponse struct {
+	ID        string               `json:"id"`
+	TaskID    string               `json:"task_id"`
+	Action    string               `json:"action"`
+	Actor     *MemberResponse      `json:"actor"`
+	RequestID string               `json:"request_id,omitempty"`
+	Changes   []models.FieldChange `json:"changes"`
+	CreatedAt string               `json:"created_at"`
+}
+
+// FormatActivity presents a log entry. Actor is null for changes the system
+// made on its own.
+func FormatActivity(activity *models.TaskActivity) ActivityResponse {
+	response := ActivityResponse{
+		ID:        strconv.FormatUint(uint64(activity.ID), 10),
+		TaskID:    strconv.FormatUint(uint64(activity.TaskID), 10),
+		Action:    activity.Action,
+		RequestID: activity.RequestID,
+		Changes:   activity.Changes,
+		CreatedAt: formatTime(&activity.CreatedAt),
+	}
+	if response.Changes == nil {
+		response.Changes = []models.FieldChange{}
+	}
+	if activity.User != nil {
+		actor := FormatMember(activity.User)
+		response.Actor = &actor
+	}
+	return response
+}
+
+func FormatActivities(activities []models.TaskActivity) []ActivityResponse {
+	response := make([]ActivityResponse, len(activities))
+	for i := range activities {
+		response[i] = FormatActivity(&activities[i])
+	}
+	return response
+}
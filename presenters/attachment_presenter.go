@@ -0,0 +1,39 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type AttachmentResponse struct {
+	ID           string         `json:"id"`
+	Filename     string         `json:"filename"`
+	ContentType  string         `json:"content_type"`
+	Size         int64          `json:"size"`
+	Hash         string         `json:"sha256"`
+	UploadedBy   MemberResponse `json:"uploaded_by"`
+	CreatedAt    string         `json:"created_at"`
+	URL          string         `json:"url,omitempty"`
+	URLExpiresAt string         `json:"url_expires_at,omitempty"`
+}
+
+// FormatAttachment presents an attachment with a signed download URL, when
+// one was issued.
+func FormatAttachment(attachment *models.Attachment, url string, expiresAt time.Time) AttachmentResponse {
+	response := AttachmentResponse{
+		ID:          strconv.FormatUint(uint64(attachment.ID), 10),
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Hash:        attachment.Hash,
+		UploadedBy:  FormatMember(&attachment.Uploader),
+		CreatedAt:   formatTime(&attachment.CreatedAt),
+		URL:         url,
+	}
+	if url != "" {
+		response.URLExpiresAt = formatTime(&expiresAt)
+	}
+	return response
+}
@@ -0,0 +1,48 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type BulkResultResponse struct {
+	Index  int    `json:"index"`
+	TaskID string `json:"task_id"`
+	Op     string `json:"op"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkReportResponse struct {
+	Mode      string               `json:"mode"`
+	Committed bool                 `json:"committed"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []BulkResultResponse `json:"results"`
+}
+
+// FormatBulkReport presents the outcome of a bulk request. errorMessage
+// decides what a failed operation tells the client.
+func FormatBulkReport(report *usecases.BulkReport, errorMessage func(error) string) BulkReportResponse {
+	results := make([]BulkResultResponse, len(report.Results))
+	for i, result := range report.Results {
+		results[i] = BulkResultResponse{
+			Index:  result.Index,
+			TaskID: strconv.FormatUint(uint64(result.TaskID), 10),
+			Op:     result.Op,
+			Status: result.Status,
+		}
+		if result.Err != nil {
+			results[i].Error = errorMessage(result.Err)
+		}
+	}
+
+	return BulkReportResponse{
+		Mode:      report.Mode,
+		Committed: report.Committed,
+		Succeeded: report.Succeeded,
+		Failed:    report.Failed,
+		Results:   results,
+	}
+}
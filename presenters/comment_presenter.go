@@ -0,0 +1,82 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+type CommentResponse struct {
+	ID        string            `json:"id"`
+	ParentID  string            `json:"parent_id,omitempty"`
+	Author    MemberResponse    `json:"author"`
+	Body      string            `json:"body"`
+	HTML      string            `json:"html"`
+	Mentions  []string          `json:"mentions"`
+	Edited    bool              `json:"edited"`
+	EditedAt  string            `json:"edited_at,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	Replies   []CommentResponse `json:"replies,omitempty"`
+}
+
+type CommentRevisionResponse struct {
+	Body     string `json:"body"`
+	HTML     string `json:"html"`
+	EditedBy string `json:"edited_by"`
+	EditedAt string `json:"edited_at"`
+}
+
+// FormatComment renders a comment's Markdown body to sanitized HTML.
+func FormatComment(comment *models.Comment) CommentResponse {
+	mentions := utils.ParseMentions(comment.Body)
+	if mentions == nil {
+		mentions = []string{}
+	}
+	return CommentResponse{
+		ID:        strconv.FormatUint(uint64(comment.ID), 10),
+		ParentID:  formatOptionalID(comment.ParentID),
+		Author:    FormatMember(&comment.User),
+		Body:      comment.Body,
+		HTML:      utils.RenderMarkdown(comment.Body),
+		Mentions:  mentions,
+		Edited:    comment.EditedAt != nil,
+		EditedAt:  formatTime(comment.EditedAt),
+		CreatedAt: formatTime(&comment.CreatedAt),
+	}
+}
+
+// FormatCommentThreads nests replies under their top-level comment. Comments
+// are expected oldest first.
+func FormatCommentThreads(comments []models.Comment) []CommentResponse {
+	threads := []CommentResponse{}
+	index := map[uint]int{}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			index[comment.ID] = len(threads)
+			threads = append(threads, FormatComment(&comment))
+		}
+	}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			continue
+		}
+		if i, ok := index[*comment.ParentID]; ok {
+			threads[i].Replies = append(threads[i].Replies, FormatComment(&comment))
+		}
+	}
+	return threads
+}
+
+func FormatCommentRevisions(revisions []models.CommentRevision) []CommentRevisionResponse {
+	formattedRevisions := make([]CommentRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		formattedRevisions[i] = CommentRevisionResponse{
+			Body:     revision.Body,
+			HTML:     utils.RenderMarkdown(revision.Body),
+			EditedBy: strconv.FormatUint(uint64(revision.EditedBy), 10),
+			EditedAt: formatTime(&revision.CreatedAt),
+		}
+	}
+	return formattedRevisions
+}
@@ -0,0 +1,50 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type GraphNodeResponse struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	Status         string `json:"status"`
+	StatusCategory string `json:"status_category"`
+	Blocked        bool   `json:"blocked"`
+}
+
+// GraphEdgeResponse points from the blocking task to the task it blocks.
+type GraphEdgeResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type DependencyGraphResponse struct {
+	Nodes []GraphNodeResponse `json:"nodes"`
+	Edges []GraphEdgeResponse `json:"edges"`
+}
+
+func FormatDependencyGraph(tasks []models.Task, dependencies []models.TaskDependency) DependencyGraphResponse {
+	graph := DependencyGraphResponse{
+		Nodes: make([]GraphNodeResponse, len(tasks)),
+		Edges: make([]GraphEdgeResponse, len(dependencies)),
+	}
+
+	for i, task := range tasks {
+		graph.Nodes[i] = GraphNodeResponse{
+			ID:             strconv.FormatUint(uint64(task.ID), 10),
+			Title:          task.Title,
+			Status:         task.Status,
+			StatusCategory: task.StatusCategory,
+			Blocked:        isBlocked(task.BlockedBy),
+		}
+	}
+	for i, dependency := range dependencies {
+		graph.Edges[i] = GraphEdgeResponse{
+			From: strconv.FormatUint(uint64(dependency.BlockerID), 10),
+			To:   strconv.FormatUint(uint64(dependency.TaskID), 10),
+		}
+	}
+	return graph
+}
@@ -0,0 +1,180 @@
+package presenters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+const (
+	icalProductID = "-//technical-test//Tasks//EN"
+	icalDate      = "20060102"
+	icalDateTime  = "20060102T150405Z"
+	// icalLineLimit is the longest content line RFC 5545 allows, in octets,
+	// before it must be folded.
+	icalLineLimit = 75
+)
+
+// icalPriorities maps task priorities to the iCalendar scale, where 1 is the
+// highest and 0 means undefined.
+var icalPriorities = map[string]int{
+	models.PriorityUrgent: 1,
+	models.PriorityHigh:   3,
+	models.PriorityMedium: 5,
+	models.PriorityLow:    7,
+}
+
+// ICalendar writes tasks as an iCalendar (RFC 5545) document. The calendar
+// header is written with the first component; Close ends the document and
+// flushes it.
+type ICalendar struct {
+	w       *bufio.Writer
+	name    string
+	stamp   time.Time
+	started bool
+}
+
+// NewICalendar starts a calendar called name, which calendar apps show for
+// subscriptions. name may be empty.
+func NewICalendar(w io.Writer, name string) *ICalendar {
+	return &ICalendar{w: bufio.NewWriter(w), name: name, stamp: time.Now()}
+}
+
+// WriteTodo writes a task as a VTODO with its due date, status and labels.
+func (c *ICalendar) WriteTodo(task *models.Task) error {
+	c.begin()
+	c.line("BEGIN:VTODO")
+	c.taskProperties(task, task.Title)
+	if !task.DueDate.IsZero() {
+		c.dateProperty("DUE", task.DueDate)
+	}
+	c.line("STATUS:" + icalTodoStatus(task.StatusCategory))
+	if task.CompletedAt != nil {
+		c.line("COMPLETED:" + task.CompletedAt.UTC().Format(icalDateTime))
+	}
+	c.line("END:VTODO")
+	return nil
+}
+
+// WriteEvent writes a task as a VEVENT on its due date, for calendar apps
+// that don't show to-dos. Done tasks keep their event, marked with a check.
+func (c *ICalendar) WriteEvent(task *models.Task) error {
+	c.begin()
+	c.line("BEGIN:VEVENT")
+	summary := task.Title
+	if task.StatusCategory == models.StatusCategoryDone {
+		summary = "✓ " + summary
+	}
+	c.taskProperties(task, summary)
+	if !task.DueDate.IsZero() {
+		c.dateProperty("DTSTART", task.DueDate)
+	}
+	if task.StatusCategory == models.StatusCategoryCancelled {
+		c.line("STATUS:CANCELLED")
+	} else {
+		c.line("STATUS:CONFIRMED")
+	}
+	c.line("TRANSP:TRANSPARENT")
+	c.line("END:VEVENT")
+	return nil
+}
+
+// Flush writes the buffered components to the underlying writer.
+func (c *ICalendar) Flush() error {
+	return c.w.Flush()
+}
+
+func (c *ICalendar) Close() error {
+	c.begin()
+	c.line("END:VCALENDAR")
+	return c.Flush()
+}
+
+func (c *ICalendar) begin() {
+	if c.started {
+		return
+	}
+	c.started = true
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:" + icalProductID)
+	c.line("CALSCALE:GREGORIAN")
+	if c.name != "" {
+		c.line("X-WR-CALNAME:" + icalText(c.name))
+	}
+}
+
+// taskProperties writes the properties every component of a task has.
+func (c *ICalendar) taskProperties(task *models.Task, summary string) {
+	c.line(fmt.Sprintf("UID:task-%d@technical-test", task.ID))
+	c.line("DTSTAMP:" + c.stamp.UTC().Format(icalDateTime))
+	if !task.CreatedAt.IsZero() {
+		c.line("CREATED:" + task.CreatedAt.UTC().Format(icalDateTime))
+	}
+	c.line("SUMMARY:" + icalText(summary))
+	if task.Description != "" {
+		c.line("DESCRIPTION:" + icalText(task.Description))
+	}
+	if priority, ok := icalPriorities[task.Priority]; ok {
+		c.line("PRIORITY:" + strconv.Itoa(priority))
+	}
+	if len(task.Labels) > 0 {
+		names := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			names[i] = icalText(label.Name)
+		}
+		c.line("CATEGORIES:" + strings.Join(names, ","))
+	}
+}
+
+// dateProperty writes a due date as a DATE value, or a UTC DATE-TIME when
+// it has a time.
+func (c *ICalendar) dateProperty(name string, due models.DueDate) {
+	if due.HasTime {
+		c.line(name + ":" + due.Time.UTC().Format(icalDateTime))
+		return
+	}
+	c.line(name + ";VALUE=DATE:" + due.Date().Format(icalDate))
+}
+
+// line writes one content line, folded into lines of at most 75 octets.
+// Errors are kept by the buffered writer and returned by Flush.
+func (c *ICalendar) line(content string) {
+	limit := icalLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		c.w.WriteString(content[:cut])
+		c.w.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with the folding space.
+		limit = icalLineLimit - 1
+	}
+	c.w.WriteString(content)
+	c.w.WriteString("\r\n")
+}
+
+func icalTodoStatus(category string) string {
+	switch category {
+	case models.StatusCategoryDone:
+		return "COMPLETED"
+	case models.StatusCategoryCancelled:
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icalText escapes a TEXT value.
+func icalText(value string) string {
+	return icalTextEscaper.Replace(value)
+}
@@ -0,0 +1,70 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type ImportReportResponse struct {
+	DryRun   bool                    `json:"dry_run"`
+	Total    int                     `json:"total"`
+	Imported int                     `json:"imported"`
+	Failed   int                     `json:"failed"`
+	Errors   []models.ImportRowError `json:"errors"`
+}
+
+type ImportJobResponse struct {
+	ID         string                  `json:"id"`
+	Format     string                  `json:"format"`
+	Status     string                  `json:"status"`
+	Total      int                     `json:"total"`
+	Processed  int                     `json:"processed"`
+	Progress   int                     `json:"progress"`
+	Imported   int                     `json:"imported"`
+	Failed     int                     `json:"failed"`
+	Errors     []models.ImportRowError `json:"errors"`
+	Error      string                  `json:"error,omitempty"`
+	CreatedAt  string                  `json:"created_at"`
+	FinishedAt string                  `json:"finished_at"`
+}
+
+func FormatImportReport(report *usecases.ImportReport) ImportReportResponse {
+	return ImportReportResponse{
+		DryRun:   report.DryRun,
+		Total:    report.Total,
+		Imported: report.Imported,
+		Failed:   report.Failed,
+		Errors:   report.Errors,
+	}
+}
+
+// FormatImportJob presents an import job. Progress is the percentage of
+// rows processed so far.
+func FormatImportJob(job *models.ImportJob) ImportJobResponse {
+	progress := 100
+	if job.Total > 0 {
+		progress = job.Processed * 100 / job.Total
+	}
+	errors := job.Errors
+	if errors == nil {
+		errors = []models.ImportRowError{}
+	}
+
+	return ImportJobResponse{
+		ID:         strconv.FormatUint(uint64(job.ID), 10),
+		Format:     job.Format,
+		Status:     job.Status,
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Progress:   progress,
+		Imported:   job.Created,
+		Failed:     job.Failed,
+		Errors:     errors,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt.Format(time.RFC3339),
+		FinishedAt: formatTime(job.FinishedAt),
+	}
+}
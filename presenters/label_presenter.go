@@ -0,0 +1,45 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type LabelResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type LabelUsageResponse struct {
+	LabelResponse
+	UsageCount int `json:"usage_count"`
+}
+
+func FormatLabel(label *models.Label) LabelResponse {
+	return LabelResponse{
+		ID:    strconv.FormatUint(uint64(label.ID), 10),
+		Name:  label.Name,
+		Color: label.Color,
+	}
+}
+
+func FormatLabels(labels []models.Label) []LabelResponse {
+	formattedLabels := make([]LabelResponse, len(labels))
+	for i, label := range labels {
+		formattedLabels[i] = FormatLabel(&label)
+	}
+	return formattedLabels
+}
+
+func FormatLabelUsageList(labels []models.Label, usage map[uint]int) []LabelUsageResponse {
+	formattedLabels := make([]LabelUsageResponse, len(labels))
+	for i, label := range labels {
+		formattedLabels[i] = LabelUsageResponse{
+			LabelResponse: FormatLabel(&label),
+			UsageCount:    usage[label.ID],
+		}
+	}
+	return formattedLabels
+}
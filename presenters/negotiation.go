@@ -0,0 +1,286 @@
+package presenters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedBody is returned by a presenter given a body it can't show.
+var ErrUnsupportedBody = errors.New("Response can't be shown in this format")
+
+// Presenter writes a response body in one media type. The body is the value
+// a JSON response would hold, so every presenter shows the same fields.
+type Presenter struct {
+	ContentType string
+	Present     func(w io.Writer, body any) error
+	// Supports reports whether the presenter can show body, for formats
+	// like CSV that only fit some responses. Nil means any body.
+	Supports func(body any) bool
+}
+
+// TaskRows is implemented by responses that are a table of tasks, which
+// tabular formats like CSV present one task per row.
+type TaskRows interface {
+	TaskRows() []TaskResponse
+}
+
+// TaskListResponse is a list of tasks, with the pagination of the page when
+// the list is paginated.
+type TaskListResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	Pagination any            `json:"pagination,omitempty"`
+}
+
+func (r TaskListResponse) TaskRows() []TaskResponse {
+	return r.Tasks
+}
+
+func (r TaskResponse) TaskRows() []TaskResponse {
+	return []TaskResponse{r}
+}
+
+// ScoredTaskListResponse is a list of suggested tasks with their scores.
+// Tabular formats leave the scores out.
+type ScoredTaskListResponse struct {
+	Tasks []ScoredTaskResponse `json:"tasks"`
+}
+
+func (r ScoredTaskListResponse) TaskRows() []TaskResponse {
+	rows := make([]TaskResponse, len(r.Tasks))
+	for i, task := range r.Tasks {
+		rows[i] = task.TaskResponse
+	}
+	return rows
+}
+
+// TrashedTaskListResponse is a page of the trash. Tabular formats leave out
+// when the tasks were deleted.
+type TrashedTaskListResponse struct {
+	Tasks      []TrashedTaskResponse `json:"tasks"`
+	Pagination any                   `json:"pagination,omitempty"`
+}
+
+func (r TrashedTaskListResponse) TaskRows() []TaskResponse {
+	rows := make([]TaskResponse, len(r.Tasks))
+	for i, task := range r.Tasks {
+		rows[i] = task.TaskResponse
+	}
+	return rows
+}
+
+var (
+	presentersMu sync.RWMutex
+	// mediaTypes keeps the registration order, which breaks ties between
+	// equally acceptable types: JSON wins over */*.
+	mediaTypes []string
+	registered = map[string]Presenter{}
+)
+
+func init() {
+	RegisterPresenter("application/json", Presenter{ContentType: "application/json; charset=utf-8", Present: presentJSON})
+	RegisterPresenter("text/csv", Presenter{ContentType: "text/csv; charset=utf-8", Present: presentCSV, Supports: isTaskRows})
+	msgpack := Presenter{ContentType: "application/msgpack", Present: presentMsgpack}
+	RegisterPresenter("application/msgpack", msgpack)
+	RegisterPresenter("application/x-msgpack", msgpack)
+	yamlPresenter := Presenter{ContentType: "application/yaml; charset=utf-8", Present: presentYAML}
+	RegisterPresenter("application/yaml", yamlPresenter)
+	RegisterPresenter("application/x-yaml", yamlPresenter)
+	RegisterPresenter("text/yaml", yamlPresenter)
+}
+
+// RegisterPresenter adds a format for negotiated responses, or replaces the
+// presenter of a media type that is already registered. Like other
+// registries, it panics when the presenter can't present anything.
+func RegisterPresenter(mediaType string, presenter Presenter) {
+	if presenter.Present == nil {
+		panic("presenters: RegisterPresenter without a Present func for " + mediaType)
+	}
+
+	presentersMu.Lock()
+	defer presentersMu.Unlock()
+
+	mediaType = strings.ToLower(mediaType)
+	if _, ok := registered[mediaType]; !ok {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	registered[mediaType] = presenter
+}
+
+// UnregisterPresenter removes the format of a media type, if registered.
+func UnregisterPresenter(mediaType string) {
+	presentersMu.Lock()
+	defer presentersMu.Unlock()
+
+	mediaType = strings.ToLower(mediaType)
+	if _, ok := registered[mediaType]; !ok {
+		return
+	}
+	delete(registered, mediaType)
+	for i, registeredType := range mediaTypes {
+		if registeredType == mediaType {
+			mediaTypes = append(mediaTypes[:i:i], mediaTypes[i+1:]...)
+			break
+		}
+	}
+}
+
+// MediaTypes lists the registered media types, in registration order.
+func MediaTypes() []string {
+	presentersMu.RLock()
+	defer presentersMu.RUnlock()
+	return append([]string(nil), mediaTypes...)
+}
+
+// acceptRange is one media range of an Accept header.
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// Negotiate picks the presenter for an Accept header: the registered type
+// the client prefers most, among those that can show body. A nil body is only
+// shown by formats that show any body. A missing header accepts anything.
+func Negotiate(accept string, body any) (Presenter, bool) {
+	ranges := parseAccept(accept)
+
+	presentersMu.RLock()
+	defer presentersMu.RUnlock()
+
+	var best Presenter
+	bestQuality := 0.0
+	for _, mediaType := range mediaTypes {
+		presenter := registered[mediaType]
+		if presenter.Supports != nil && !presenter.Supports(body) {
+			continue
+		}
+		if quality := acceptQuality(ranges, mediaType); quality > bestQuality {
+			best, bestQuality = presenter, quality
+		}
+	}
+	return best, bestQuality > 0
+}
+
+func parseAccept(accept string) []acceptRange {
+	if strings.TrimSpace(accept) == "" {
+		return []acceptRange{{mediaType: "*/*", quality: 1}}
+	}
+
+	ranges := []acceptRange{}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// acceptQuality is the quality the most specific matching range gives
+// mediaType, or 0 when no range matches.
+func acceptQuality(ranges []acceptRange, mediaType string) float64 {
+	kind, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, 0
+	for _, r := range ranges {
+		match := 0
+		switch r.mediaType {
+		case mediaType:
+			match = 3
+		case kind + "/*":
+			match = 2
+		case "*/*":
+			match = 1
+		}
+		if match > specificity {
+			quality, specificity = r.quality, match
+		}
+	}
+	return quality
+}
+
+func presentJSON(w io.Writer, body any) error {
+	return json.NewEncoder(w).Encode(body)
+}
+
+func isTaskRows(body any) bool {
+	_, ok := body.(TaskRows)
+	return ok
+}
+
+// presentCSV writes the tasks of a TaskRows body with the columns of a CSV
+// export. Pagination isn't shown.
+func presentCSV(w io.Writer, body any) error {
+	rows, ok := body.(TaskRows)
+	if !ok {
+		return ErrUnsupportedBody
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write(csvColumns)
+	for _, response := range rows.TaskRows() {
+		writer.Write(csvRow(response))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// presentMsgpack encodes the body with the JSON field names, which the codec
+// reads from the json tags.
+func presentMsgpack(w io.Writer, body any) error {
+	var handle codec.MsgpackHandle
+	handle.WriteExt = true
+	return codec.NewEncoder(w, &handle).Encode(body)
+}
+
+// presentYAML goes through JSON so YAML has the same field names and order;
+// YAML is a superset of JSON, so the JSON parses as a YAML document whose
+// styles are then reset to plain block YAML.
+func presentYAML(w io.Writer, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	resetYAMLStyle(&document)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,48 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type ProjectResponse struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Color       string         `json:"color"`
+	Description string         `json:"description"`
+	Archived    bool           `json:"archived"`
+	WorkflowID  string         `json:"workflow_id,omitempty"`
+	TaskCounts  map[string]int `json:"task_counts"`
+	TotalTasks  int            `json:"total_tasks"`
+}
+
+func FormatProject(project *models.Project, counts map[string]int) ProjectResponse {
+	if counts == nil {
+		counts = map[string]int{}
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	return ProjectResponse{
+		ID:          strconv.FormatUint(uint64(project.ID), 10),
+		Name:        project.Name,
+		Color:       project.Color,
+		Description: project.Description,
+		Archived:    project.Archived,
+		WorkflowID:  formatOptionalID(project.WorkflowID),
+		TaskCounts:  counts,
+		TotalTasks:  total,
+	}
+}
+
+func FormatProjectList(projects []models.Project, counts map[uint]map[string]int) []ProjectResponse {
+	formattedProjects := make([]ProjectResponse, len(projects))
+	for i, project := range projects {
+		formattedProjects[i] = FormatProject(&project, counts[project.ID])
+	}
+	return formattedProjects
+}
@@ -0,0 +1,67 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type ReminderResponse struct {
+	ID            string `json:"id"`
+	TaskID        string `json:"task_id"`
+	RemindAt      string `json:"remind_at,omitempty"`
+	OffsetMinutes *int   `json:"offset_minutes,omitempty"`
+	FireAt        string `json:"fire_at"`
+	Channel       string `json:"channel"`
+	Target        string `json:"target,omitempty"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	LastError     string `json:"last_error,omitempty"`
+	SentAt        string `json:"sent_at,omitempty"`
+}
+
+type NotificationResponse struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"task_id"`
+	Message   string `json:"message"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
+func FormatReminder(reminder *models.Reminder) ReminderResponse {
+	return ReminderResponse{
+		ID:            strconv.FormatUint(uint64(reminder.ID), 10),
+		TaskID:        strconv.FormatUint(uint64(reminder.TaskID), 10),
+		RemindAt:      formatTime(reminder.RemindAt),
+		OffsetMinutes: reminder.OffsetMinutes,
+		FireAt:        formatTime(&reminder.FireAt),
+		Channel:       reminder.Channel,
+		Target:        reminder.Target,
+		Status:        reminder.Status,
+		Attempts:      reminder.Attempts,
+		LastError:     reminder.LastError,
+		SentAt:        formatTime(reminder.SentAt),
+	}
+}
+
+func FormatReminders(reminders []models.Reminder) []ReminderResponse {
+	formattedReminders := make([]ReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		formattedReminders[i] = FormatReminder(&reminder)
+	}
+	return formattedReminders
+}
+
+func FormatNotifications(notifications []models.Notification) []NotificationResponse {
+	formattedNotifications := make([]NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		formattedNotifications[i] = NotificationResponse{
+			ID:        strconv.FormatUint(uint64(notification.ID), 10),
+			TaskID:    strconv.FormatUint(uint64(notification.TaskID), 10),
+			Message:   notification.Message,
+			Read:      notification.ReadAt != nil,
+			CreatedAt: formatTime(&notification.CreatedAt),
+		}
+	}
+	return formattedNotifications
+}
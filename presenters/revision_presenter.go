@@ -0,0 +1,71 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type SnapshotResponse struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Status       string   `json:"status"`
+	Priority     string   `json:"priority"`
+	DueDate      string   `json:"due_date"`
+	ProjectID    string   `json:"project_id"`
+	ParentID     string   `json:"parent_id"`
+	AutoComplete bool     `json:"auto_complete"`
+	LabelIDs     []string `json:"label_ids"`
+}
+
+type RevisionResponse struct {
+	Revision  int              `json:"revision"`
+	TaskID    string           `json:"task_id"`
+	Action    string           `json:"action"`
+	Actor     *MemberResponse  `json:"actor"`
+	RequestID string           `json:"request_id,omitempty"`
+	Snapshot  SnapshotResponse `json:"snapshot"`
+	CreatedAt string           `json:"created_at"`
+}
+
+// FormatRevision presents a task revision. Actor is null for changes the
+// system made on its own.
+func FormatRevision(revision *models.TaskRevision) RevisionResponse {
+	snapshot := revision.Snapshot
+	labelIDs := make([]string, len(snapshot.LabelIDs))
+	for i, id := range snapshot.LabelIDs {
+		labelIDs[i] = strconv.FormatUint(uint64(id), 10)
+	}
+
+	response := RevisionResponse{
+		Revision:  revision.Revision,
+		TaskID:    strconv.FormatUint(uint64(revision.TaskID), 10),
+		Action:    revision.Action,
+		RequestID: revision.RequestID,
+		Snapshot: SnapshotResponse{
+			Title:        snapshot.Title,
+			Description:  snapshot.Description,
+			Status:       snapshot.Status,
+			Priority:     snapshot.Priority,
+			DueDate:      snapshot.DueDate.String(),
+			ProjectID:    formatOptionalID(snapshot.ProjectID),
+			ParentID:     formatOptionalID(snapshot.ParentID),
+			AutoComplete: snapshot.AutoComplete,
+			LabelIDs:     labelIDs,
+		},
+		CreatedAt: formatTime(&revision.CreatedAt),
+	}
+	if revision.User != nil {
+		actor := FormatMember(revision.User)
+		response.Actor = &actor
+	}
+	return response
+}
+
+func FormatRevisions(revisions []models.TaskRevision) []RevisionResponse {
+	response := make([]RevisionResponse, len(revisions))
+	for i := range revisions {
+		response[i] = FormatRevision(&revisions[i])
+	}
+	return response
+}
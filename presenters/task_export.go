@@ -0,0 +1,219 @@
+package presenters
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+// TaskWriter streams tasks in one export format. Flush writes what has been
+// buffered so far; Close finishes the document and flushes it, without
+// closing the underlying writer.
+type TaskWriter interface {
+	WriteTask(task *models.Task) error
+	Flush() error
+	Close() error
+}
+
+type ExportFormat struct {
+	ContentType string
+	NewWriter   func(w io.Writer) TaskWriter
+}
+
+// ExportFormats are the formats of GET /api/tasks/export, by the name used
+// in the format parameter, which is also the file extension.
+var ExportFormats = map[string]ExportFormat{
+	"csv":  {ContentType: "text/csv; charset=utf-8", NewWriter: newCSVTaskWriter},
+	"json": {ContentType: "application/json; charset=utf-8", NewWriter: newJSONTaskWriter},
+	"md":   {ContentType: "text/markdown; charset=utf-8", NewWriter: newMarkdownTaskWriter},
+	"ics":  {ContentType: "text/calendar; charset=utf-8", NewWriter: newICalTaskWriter},
+}
+
+// csvColumns are the columns of a CSV export. The first ones match the
+// import fields, so an export can be imported again.
+var csvColumns = []string{"id", "title", "description", "status", "priority", "due_date", "project_id", "labels", "recurrence", "status_category", "parent_id", "assignees", "completed_at", "archived_at"}
+
+type csvTaskWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+// newCSVTaskWriter writes one task per row after a header row. Labels and
+// assignees are comma separated names.
+func newCSVTaskWriter(w io.Writer) TaskWriter {
+	return &csvTaskWriter{w: csv.NewWriter(w)}
+}
+
+func (t *csvTaskWriter) WriteTask(task *models.Task) error {
+	t.header()
+	return t.w.Write(csvRow(FormatTask(task)))
+}
+
+// csvRow lays a task out in csvColumns. Cells a spreadsheet would run as a
+// formula are escaped; importing the file again removes the escaping.
+func csvRow(response TaskResponse) []string {
+	row := []string{
+		response.ID,
+		response.Title,
+		response.Description,
+		response.Status,
+		response.Priority,
+		response.DueDate,
+		response.ProjectID,
+		labelNames(response.Labels),
+		response.Recurrence,
+		response.StatusCategory,
+		response.ParentID,
+		assigneeNames(response.Assignees),
+		response.CompletedAt,
+		response.ArchivedAt,
+	}
+	for i, cell := range row {
+		row[i] = utils.EscapeCSVCell(cell)
+	}
+	return row
+}
+
+func (t *csvTaskWriter) Flush() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+func (t *csvTaskWriter) Close() error {
+	t.header()
+	return t.Flush()
+}
+
+func (t *csvTaskWriter) header() {
+	if !t.started {
+		t.started = true
+		t.w.Write(csvColumns)
+	}
+}
+
+// jsonTaskWriter writes a JSON array of tasks as GET /api/tasks presents
+// them.
+type jsonTaskWriter struct {
+	w     *bufio.Writer
+	count int
+}
+
+func newJSONTaskWriter(w io.Writer) TaskWriter {
+	return &jsonTaskWriter{w: bufio.NewWriter(w)}
+}
+
+func (t *jsonTaskWriter) WriteTask(task *models.Task) error {
+	data, err := json.Marshal(FormatTask(task))
+	if err != nil {
+		return err
+	}
+	if t.count == 0 {
+		t.w.WriteString("[\n")
+	} else {
+		t.w.WriteString(",\n")
+	}
+	t.count++
+	_, err = t.w.Write(data)
+	return err
+}
+
+func (t *jsonTaskWriter) Flush() error {
+	return t.w.Flush()
+}
+
+func (t *jsonTaskWriter) Close() error {
+	if t.count == 0 {
+		t.w.WriteString("[")
+	}
+	t.w.WriteString("\n]\n")
+	return t.Flush()
+}
+
+// markdownTaskWriter writes a Markdown table with one task per row.
+type markdownTaskWriter struct {
+	w       *bufio.Writer
+	started bool
+}
+
+func newMarkdownTaskWriter(w io.Writer) TaskWriter {
+	return &markdownTaskWriter{w: bufio.NewWriter(w)}
+}
+
+func (t *markdownTaskWriter) WriteTask(task *models.Task) error {
+	t.header()
+	response := FormatTask(task)
+	t.row(response.ID, response.Title, response.Status, response.Priority, response.DueDate,
+		labelNames(response.Labels), assigneeNames(response.Assignees))
+	return nil
+}
+
+func (t *markdownTaskWriter) Flush() error {
+	return t.w.Flush()
+}
+
+func (t *markdownTaskWriter) Close() error {
+	t.header()
+	return t.Flush()
+}
+
+func (t *markdownTaskWriter) header() {
+	if t.started {
+		return
+	}
+	t.started = true
+	t.w.WriteString("# Tasks\n\n")
+	t.row("ID", "Title", "Status", "Priority", "Due", "Labels", "Assignees")
+	t.row("---", "---", "---", "---", "---", "---", "---")
+}
+
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+func (t *markdownTaskWriter) row(cells ...string) {
+	t.w.WriteString("|")
+	for _, cell := range cells {
+		t.w.WriteString(" " + markdownCellEscaper.Replace(cell) + " |")
+	}
+	t.w.WriteString("\n")
+}
+
+// icalTaskWriter writes a calendar with one VTODO per task.
+type icalTaskWriter struct {
+	calendar *ICalendar
+}
+
+func newICalTaskWriter(w io.Writer) TaskWriter {
+	return &icalTaskWriter{calendar: NewICalendar(w, "Tasks")}
+}
+
+func (t *icalTaskWriter) WriteTask(task *models.Task) error {
+	return t.calendar.WriteTodo(task)
+}
+
+func (t *icalTaskWriter) Flush() error {
+	return t.calendar.Flush()
+}
+
+func (t *icalTaskWriter) Close() error {
+	return t.calendar.Close()
+}
+
+func labelNames(labels []LabelResponse) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+	}
+	return strings.Join(names, ",")
+}
+
+func assigneeNames(assignees []MemberResponse) string {
+	names := make([]string, len(assignees))
+	for i, assignee := range assignees {
+		names[i] = assignee.Username
+	}
+	return strings.Join(names, ",")
+}
@@ -2,33 +2,105 @@ package presenters
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
 )
 
 type TaskResponse struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
-	DueDate     string `json:"due_date"`
+	ID             string           `json:"id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	Status         string           `json:"status"`
+	StatusCategory string           `json:"status_category"`
+	Priority       string           `json:"priority"`
+	DueDate        string           `json:"due_date"`
+	ProjectID      string           `json:"project_id,omitempty"`
+	ParentID       string           `json:"parent_id,omitempty"`
+	AutoComplete   bool             `json:"auto_complete"`
+	SubtaskCount   int              `json:"subtask_count"`
+	Progress       *int             `json:"progress,omitempty"`
+	Blocked        bool             `json:"blocked"`
+	Recurrence     string           `json:"recurrence,omitempty"`
+	RecurrenceMode string           `json:"recurrence_mode,omitempty"`
+	SeriesID       string           `json:"series_id,omitempty"`
+	Occurrence     int              `json:"occurrence,omitempty"`
+	CompletedAt    string           `json:"completed_at,omitempty"`
+	Archived       bool             `json:"archived"`
+	ArchivedAt     string           `json:"archived_at,omitempty"`
+	Labels         []LabelResponse  `json:"labels"`
+	Assignees      []MemberResponse `json:"assignees"`
+}
+
+type ScoredTaskResponse struct {
+	TaskResponse
+	Score float64 `json:"score"`
+}
+
+type TrashedTaskResponse struct {
+	TaskResponse
+	DeletedAt string `json:"deleted_at"`
 }
 
 type TaskDetailResponse struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Status      string `json:"status"`
+	Priority    string `json:"priority"`
 	DueDate     string `json:"due_date"`
 }
 
+type QuickAddResponse struct {
+	Title      string   `json:"title"`
+	DueDate    string   `json:"due_date,omitempty"`
+	Recurrence string   `json:"recurrence,omitempty"`
+	Labels     []string `json:"labels"`
+	Priority   string   `json:"priority,omitempty"`
+}
+
+func FormatQuickAdd(parsed *usecases.QuickAdd) QuickAddResponse {
+	labels := parsed.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	return QuickAddResponse{
+		Title:      parsed.Title,
+		DueDate:    parsed.DueDate.String(),
+		Recurrence: parsed.Recurrence,
+		Labels:     labels,
+		Priority:   parsed.Priority,
+	}
+}
+
 func FormatTask(task *models.Task) TaskResponse {
-	return TaskResponse{
-		ID:          strconv.FormatUint(uint64(task.ID), 10),
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		DueDate:     task.DueDate,
+	response := TaskResponse{
+		ID:             strconv.FormatUint(uint64(task.ID), 10),
+		Title:          task.Title,
+		Description:    task.Description,
+		Status:         task.Status,
+		StatusCategory: task.StatusCategory,
+		Priority:       task.Priority,
+		DueDate:        task.DueDate.String(),
+		ProjectID:      formatOptionalID(task.ProjectID),
+		ParentID:       formatOptionalID(task.ParentID),
+		AutoComplete:   task.AutoComplete,
+		SubtaskCount:   len(task.Subtasks),
+		Progress:       subtaskProgress(task.Subtasks),
+		Blocked:        isBlocked(task.BlockedBy),
+		CompletedAt:    formatTime(task.CompletedAt),
+		Archived:       task.ArchivedAt != nil,
+		ArchivedAt:     formatTime(task.ArchivedAt),
+		Labels:         FormatLabels(task.Labels),
+		Assignees:      FormatAssignees(task.Assignees),
+	}
+	if task.Series != nil {
+		response.Recurrence = task.Series.Rule
+		response.RecurrenceMode = task.Series.Mode
+		response.SeriesID = formatOptionalID(task.SeriesID)
+		response.Occurrence = task.Occurrence
 	}
+	return response
 }
 
 func FormatTaskList(tasks []models.Task) []TaskResponse {
@@ -39,11 +111,79 @@ func FormatTaskList(tasks []models.Task) []TaskResponse {
 	return formattedTasks
 }
 
+func FormatScoredTasks(tasks []usecases.ScoredTask) []ScoredTaskResponse {
+	formattedTasks := make([]ScoredTaskResponse, len(tasks))
+	for i, scored := range tasks {
+		formattedTasks[i] = ScoredTaskResponse{
+			TaskResponse: FormatTask(&scored.Task),
+			Score:        scored.Score,
+		}
+	}
+	return formattedTasks
+}
+
+func FormatTrashedTasks(tasks []models.Task) []TrashedTaskResponse {
+	formattedTasks := make([]TrashedTaskResponse, len(tasks))
+	for i, task := range tasks {
+		formattedTasks[i] = TrashedTaskResponse{
+			TaskResponse: FormatTask(&task),
+			DeletedAt:    formatTime(&task.DeletedAt.Time),
+		}
+	}
+	return formattedTasks
+}
+
 func FormatTaskDetail(task *models.Task) TaskDetailResponse {
 	return TaskDetailResponse{
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      task.Status,
-		DueDate:     task.DueDate,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate.String(),
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+// subtaskProgress returns the percentage of done subtasks, ignoring
+// cancelled ones. It is nil for tasks without subtasks to count.
+func subtaskProgress(subtasks []models.Task) *int {
+	done, counted := 0, 0
+	for _, subtask := range subtasks {
+		switch subtask.StatusCategory {
+		case models.StatusCategoryCancelled:
+			continue
+		case models.StatusCategoryDone:
+			done++
+		}
+		counted++
+	}
+	if counted == 0 {
+		return nil
+	}
+
+	progress := done * 100 / counted
+	return &progress
+}
+
+// isBlocked reports whether any of the task's blockers is still open.
+func isBlocked(blockers []models.Task) bool {
+	for _, blocker := range blockers {
+		if blocker.StatusCategory == models.StatusCategoryOpen {
+			return true
+		}
 	}
+	return false
 }
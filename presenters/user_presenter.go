@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type UserResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Timezone string `json:"timezone"`
+	// CalendarFeed tells whether the user has a calendar feed URL.
+	CalendarFeed bool `json:"calendar_feed"`
+}
+
+// MemberResponse is the public view of another user, e.g. a task assignee.
+type MemberResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+func FormatUser(user *models.User) UserResponse {
+	timezone := user.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	return UserResponse{
+		ID:           strconv.FormatUint(uint64(user.ID), 10),
+		Username:     user.Username,
+		Timezone:     timezone,
+		CalendarFeed: user.CalendarTokenHash != nil,
+	}
+}
+
+func FormatMember(user *models.User) MemberResponse {
+	return MemberResponse{
+		ID:       strconv.FormatUint(uint64(user.ID), 10),
+		Username: user.Username,
+	}
+}
+
+func FormatMembers(users []models.User) []MemberResponse {
+	formattedUsers := make([]MemberResponse, len(users))
+	for i, user := range users {
+		formattedUsers[i] = FormatMember(&user)
+	}
+	return formattedUsers
+}
+
+func FormatAssignees(assignees []models.TaskAssignee) []MemberResponse {
+	formattedAssignees := make([]MemberResponse, len(assignees))
+	for i, assignee := range assignees {
+		formattedAssignees[i] = FormatMember(&assignee.User)
+	}
+	return formattedAssignees
+}
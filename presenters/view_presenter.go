@@ -0,0 +1,94 @@
+package presenters
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type ViewResponse struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Builtin bool              `json:"builtin"`
+	Filters models.TaskFilter `json:"filters"`
+	Sort    string            `json:"sort"`
+	Columns []string          `json:"columns"`
+}
+
+func FormatView(view *models.SavedView) ViewResponse {
+	return ViewResponse{
+		ID:      strconv.FormatUint(uint64(view.ID), 10),
+		Name:    view.Name,
+		Filters: view.Filters,
+		Sort:    view.Sort,
+		Columns: view.Columns,
+	}
+}
+
+func FormatBuiltinView(view usecases.BuiltinView) ViewResponse {
+	return ViewResponse{
+		ID:      view.Key,
+		Name:    view.Name,
+		Builtin: true,
+		Filters: view.Filters,
+		Sort:    view.Sort,
+	}
+}
+
+func FormatViewList(builtins []usecases.BuiltinView, views []models.SavedView) []ViewResponse {
+	formattedViews := make([]ViewResponse, 0, len(builtins)+len(views))
+	for _, view := range builtins {
+		formattedViews = append(formattedViews, FormatBuiltinView(view))
+	}
+	for _, view := range views {
+		formattedViews = append(formattedViews, FormatView(&view))
+	}
+	return formattedViews
+}
+
+// TaskColumns lists the fields of TaskResponse a view may select.
+func TaskColumns() []string {
+	var fields map[string]interface{}
+	raw, _ := json.Marshal(TaskResponse{})
+	_ = json.Unmarshal(raw, &fields)
+
+	columns := make([]string, 0, len(fields))
+	for column := range fields {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func IsTaskColumn(column string) bool {
+	for _, known := range TaskColumns() {
+		if known == column {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectTaskColumns formats tasks keeping only the requested columns. An
+// empty column list keeps every field.
+func SelectTaskColumns(tasks []models.Task, columns []string) []map[string]interface{} {
+	formattedTasks := make([]map[string]interface{}, len(tasks))
+	for i, task := range tasks {
+		var fields map[string]interface{}
+		raw, _ := json.Marshal(FormatTask(&task))
+		_ = json.Unmarshal(raw, &fields)
+
+		if len(columns) > 0 {
+			selected := make(map[string]interface{}, len(columns))
+			for _, column := range columns {
+				if value, ok := fields[column]; ok {
+					selected[column] = value
+				}
+			}
+			fields = selected
+		}
+		formattedTasks[i] = fields
+	}
+	return formattedTasks
+}
@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type WorkflowResponse struct {
+	ID          string                      `json:"id"`
+	Name        string                      `json:"name"`
+	States      []models.WorkflowState      `json:"states"`
+	Transitions []models.WorkflowTransition `json:"transitions"`
+}
+
+type TransitionResponse struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	UserID     string `json:"user_id"`
+	CreatedAt  string `json:"created_at"`
+}
+
+func FormatWorkflow(workflow *models.Workflow) WorkflowResponse {
+	id := "default"
+	if workflow.ID != 0 {
+		id = strconv.FormatUint(uint64(workflow.ID), 10)
+	}
+
+	return WorkflowResponse{
+		ID:          id,
+		Name:        workflow.Name,
+		States:      workflow.States,
+		Transitions: workflow.Transitions,
+	}
+}
+
+func FormatWorkflowList(workflows []models.Workflow) []WorkflowResponse {
+	formattedWorkflows := make([]WorkflowResponse, len(workflows))
+	for i, workflow := range workflows {
+		formattedWorkflows[i] = FormatWorkflow(&workflow)
+	}
+	return formattedWorkflows
+}
+
+func FormatTransitions(transitions []models.TaskTransition) []TransitionResponse {
+	formattedTransitions := make([]TransitionResponse, len(transitions))
+	for i, transition := range transitions {
+		formattedTransitions[i] = TransitionResponse{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			UserID:     strconv.FormatUint(uint64(transition.UserID), 10),
+			CreatedAt:  transition.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return formattedTransitions
+}
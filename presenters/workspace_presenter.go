@@ -0,0 +1,70 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type WorkspaceResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	OwnerID   string `json:"owner_id"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type WorkspaceMemberResponse struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+type InvitationResponse struct {
+	ID        string `json:"id"`
+	Role      string `json:"role"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// FormatWorkspace formats a workspace along with the caller's role in it.
+func FormatWorkspace(workspace *models.Workspace, role string) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:        strconv.FormatUint(uint64(workspace.ID), 10),
+		Name:      workspace.Name,
+		OwnerID:   strconv.FormatUint(uint64(workspace.OwnerID), 10),
+		Role:      role,
+		CreatedAt: formatTime(&workspace.CreatedAt),
+	}
+}
+
+func FormatWorkspaceList(memberships []models.WorkspaceMember) []WorkspaceResponse {
+	formattedWorkspaces := make([]WorkspaceResponse, len(memberships))
+	for i, member := range memberships {
+		formattedWorkspaces[i] = FormatWorkspace(&member.Workspace, member.Role)
+	}
+	return formattedWorkspaces
+}
+
+func FormatWorkspaceMembers(members []models.WorkspaceMember) []WorkspaceMemberResponse {
+	formattedMembers := make([]WorkspaceMemberResponse, len(members))
+	for i, member := range members {
+		formattedMembers[i] = WorkspaceMemberResponse{
+			UserID:   strconv.FormatUint(uint64(member.UserID), 10),
+			Username: member.User.Username,
+			Role:     member.Role,
+			JoinedAt: formatTime(&member.CreatedAt),
+		}
+	}
+	return formattedMembers
+}
+
+func FormatInvitation(invitation *models.WorkspaceInvitation, token string) InvitationResponse {
+	return InvitationResponse{
+		ID:        strconv.FormatUint(uint64(invitation.ID), 10),
+		Role:      invitation.Role,
+		Token:     token,
+		ExpiresAt: formatTime(&invitation.ExpiresAt),
+	}
+}
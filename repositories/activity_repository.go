@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type ActivityRepository interface {
+	RecordActivity(activity *models.TaskActivity) error
+	GetTaskActivity(taskID uint) ([]models.TaskActivity, error)
+	FindActivity(filter models.ActivityFilter, page, limit int) ([]models.TaskActivity, int, error)
+}
+
+type activityRepository struct{}
+
+func NewActivityRepository() ActivityRepository {
+	return &activityRepository{}
+}
+
+func (r *activityRepository) RecordActivity(activity *models.TaskActivity) error {
+	return config.DB.Create(activity).Error
+}
+
+// GetTaskActivity returns the activity of one task, newest first.
+func (r *activityRepository) GetTaskActivity(taskID uint) ([]models.TaskActivity, error) {
+	var activities []models.TaskActivity
+	if err := config.DB.Preload("User").Where("task_id = ?", taskID).Order("created_at DESC, id DESC").Find(&activities).Error; err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// FindActivity returns a page of the audit log, newest first, and the number
+// of entries matching the filter.
+func (r *activityRepository) FindActivity(filter models.ActivityFilter, page, limit int) ([]models.TaskActivity, int, error) {
+	query := config.DB.Model(&models.TaskActivity{})
+	if filter.TaskID != 0 {
+		query = query.Where("task_id = ?", filter.TaskID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.WorkspaceID != 0 {
+		query = query.Where("workspace_id = ?", filter.WorkspaceID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if from, err := time.Parse("2006-01-02", filter.From); err == nil {
+		query = query.Where("created_at >= ?", from)
+	}
+	if to, err := time.Parse("2006-01-02", filter.To); err == nil {
+		query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var activities []models.TaskActivity
+	offset := (page - 1) * limit
+	if err := query.Preload("User").Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&activities).Error; err != nil {
+		return nil, 0, err
+	}
+	return activities, int(total), nil
+}
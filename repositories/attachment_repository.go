@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(attachment *models.Attachment) error
+	GetAttachments(taskID uint) ([]models.Attachment, error)
+	GetAttachmentByID(id uint) (*models.Attachment, error)
+	DeleteAttachment(id uint) error
+	CountByHash(hash string) (int64, error)
+	GetHashes(taskID uint) ([]string, error)
+	LockHash(hash string, fn func(repo AttachmentRepository) error) error
+}
+
+// attachmentRepository runs its queries in tx while a hash is locked.
+type attachmentRepository struct {
+	tx *gorm.DB
+}
+
+func NewAttachmentRepository() AttachmentRepository {
+	return &attachmentRepository{}
+}
+
+// LockHash runs fn in a transaction that holds an advisory lock on hash
+// until it commits. Uploads and pruning of the same contents take turns, so
+// a file isn't deleted between counting its attachments and adding one.
+func (r *attachmentRepository) LockHash(hash string, fn func(repo AttachmentRepository) error) error {
+	return r.db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "attachment:"+hash).Error; err != nil {
+			return err
+		}
+		return fn(&attachmentRepository{tx: tx})
+	})
+}
+
+func (r *attachmentRepository) db() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return config.DB
+}
+
+func (r *attachmentRepository) CreateAttachment(attachment *models.Attachment) error {
+	if err := r.db().Create(attachment).Error; err != nil {
+		return err
+	}
+	return r.db().Preload("Uploader").First(attachment, attachment.ID).Error
+}
+
+// GetAttachments returns a task's attachments, newest first.
+func (r *attachmentRepository) GetAttachments(taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := r.db().Preload("Uploader").Where("task_id = ?", taskID).Order("created_at DESC, id DESC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) GetAttachmentByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := r.db().Preload("Uploader").First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) DeleteAttachment(id uint) error {
+	return r.db().Delete(&models.Attachment{}, id).Error
+}
+
+// CountByHash counts the attachments, on any task, stored under hash.
+func (r *attachmentRepository) CountByHash(hash string) (int64, error) {
+	var count int64
+	err := r.db().Model(&models.Attachment{}).Where("hash = ?", hash).Count(&count).Error
+	return count, err
+}
+
+// GetHashes returns the distinct content hashes of a task's attachments.
+func (r *attachmentRepository) GetHashes(taskID uint) ([]string, error) {
+	var hashes []string
+	err := r.db().Model(&models.Attachment{}).Where("task_id = ?", taskID).Distinct().Pluck("hash", &hashes).Error
+	return hashes, err
+}
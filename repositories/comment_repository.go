@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	CreateComment(comment *models.Comment) error
+	GetComments(taskID uint) ([]models.Comment, error)
+	GetCommentByID(taskID, id uint) (*models.Comment, error)
+	UpdateComment(comment *models.Comment, body string, editedBy uint) error
+	DeleteComment(taskID, id uint) error
+	GetRevisions(commentID uint) ([]models.CommentRevision, error)
+}
+
+type commentRepository struct{}
+
+func NewCommentRepository() CommentRepository {
+	return &commentRepository{}
+}
+
+func (r *commentRepository) CreateComment(comment *models.Comment) error {
+	if err := config.DB.Create(comment).Error; err != nil {
+		return err
+	}
+	return config.DB.Preload("User").First(comment, comment.ID).Error
+}
+
+// GetComments returns every comment on a task, oldest first.
+func (r *commentRepository) GetComments(taskID uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	if err := config.DB.Preload("User").Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) GetCommentByID(taskID, id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := config.DB.Preload("User").Where("task_id = ?", taskID).First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateComment replaces a comment's body, keeping the old one as a revision.
+func (r *commentRepository) UpdateComment(comment *models.Comment, body string, editedBy uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		revision := models.CommentRevision{CommentID: comment.ID, Body: comment.Body, EditedBy: editedBy}
+		if err := tx.Create(&revision).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if err := tx.Model(comment).Updates(map[string]interface{}{"body": body, "edited_at": now}).Error; err != nil {
+			return err
+		}
+		comment.Body = body
+		comment.EditedAt = &now
+		return nil
+	})
+}
+
+// DeleteComment deletes a comment together with its replies and their
+// history.
+func (r *commentRepository) DeleteComment(taskID, id uint) error {
+	var comment models.Comment
+	if err := config.DB.Where("task_id = ?", taskID).First(&comment, id).Error; err != nil {
+		return err
+	}
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		ids := config.DB.Model(&models.Comment{}).Select("id").Where("id = ? OR parent_id = ?", id, id)
+		if err := tx.Where("comment_id IN (?)", ids).Delete(&models.CommentRevision{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? OR parent_id = ?", id, id).Delete(&models.Comment{}).Error
+	})
+}
+
+// GetRevisions returns the earlier bodies of a comment, newest first.
+func (r *commentRepository) GetRevisions(commentID uint) ([]models.CommentRevision, error) {
+	var revisions []models.CommentRevision
+	if err := config.DB.Where("comment_id = ?", commentID).Order("created_at DESC, id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yasseryazid/technical-test/config"
+)
+
+const (
+	feedCachePrefix   = "ical:feed:"
+	feedVersionPrefix = "ical:workspace:"
+)
+
+// FeedCache keeps rendered calendar feeds. Instead of deleting the feeds a
+// task change affects, each workspace has a version that is bumped on every
+// change; feeds are cached under keys that include the versions they were
+// rendered from, so stale ones are never read again and simply expire.
+type FeedCache interface {
+	Versions(workspaceIDs []uint) ([]int64, error)
+	Bump(workspaceID uint) error
+	Get(key string) ([]byte, bool, error)
+	Set(key string, feed []byte, ttl time.Duration) error
+}
+
+type redisFeedCache struct{}
+
+func NewFeedCache() FeedCache {
+	return &redisFeedCache{}
+}
+
+func (c *redisFeedCache) Versions(workspaceIDs []uint) ([]int64, error) {
+	if len(workspaceIDs) == 0 {
+		return []int64{}, nil
+	}
+	keys := make([]string, len(workspaceIDs))
+	for i, id := range workspaceIDs {
+		keys[i] = feedVersionPrefix + strconv.FormatUint(uint64(id), 10)
+	}
+	values, err := config.RedisClient.MGet(context.Background(), keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int64, len(values))
+	for i, value := range values {
+		if text, ok := value.(string); ok {
+			versions[i], _ = strconv.ParseInt(text, 10, 64)
+		}
+	}
+	return versions, nil
+}
+
+func (c *redisFeedCache) Bump(workspaceID uint) error {
+	return config.RedisClient.Incr(context.Background(), feedVersionPrefix+strconv.FormatUint(uint64(workspaceID), 10)).Err()
+}
+
+func (c *redisFeedCache) Get(key string) ([]byte, bool, error) {
+	feed, err := config.RedisClient.Get(context.Background(), feedCachePrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return feed, true, nil
+}
+
+func (c *redisFeedCache) Set(key string, feed []byte, ttl time.Duration) error {
+	return config.RedisClient.Set(context.Background(), feedCachePrefix+key, feed, ttl).Err()
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository interface {
+	CreateImportJob(job *models.ImportJob) error
+	UpdateImportJob(job *models.ImportJob) error
+	GetImportJob(id, workspaceID uint) (*models.ImportJob, error)
+	FailUnfinishedImportJobs(message string) (int64, error)
+}
+
+type importJobRepository struct{}
+
+func NewImportJobRepository() ImportJobRepository {
+	return &importJobRepository{}
+}
+
+func (r *importJobRepository) CreateImportJob(job *models.ImportJob) error {
+	return config.DB.Create(job).Error
+}
+
+func (r *importJobRepository) UpdateImportJob(job *models.ImportJob) error {
+	return config.DB.Save(job).Error
+}
+
+func (r *importJobRepository) GetImportJob(id, workspaceID uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := config.DB.Where("workspace_id = ?", workspaceID).First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FailUnfinishedImportJobs marks the jobs a previous process left pending or
+// running as failed, since nothing will pick them up again.
+func (r *importJobRepository) FailUnfinishedImportJobs(message string) (int64, error) {
+	result := config.DB.Model(&models.ImportJob{}).
+		Where("status IN ?", []string{models.ImportPending, models.ImportRunning}).
+		Updates(map[string]interface{}{"status": models.ImportFailed, "error": message, "finished_at": gorm.Expr("NOW()")})
+	return result.RowsAffected, result.Error
+}
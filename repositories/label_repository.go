@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type LabelRepository interface {
+	GetLabels() ([]models.Label, error)
+	GetLabelsByIDs(ids []uint) ([]models.Label, error)
+	GetLabelByName(name string) (*models.Label, error)
+	CreateLabel(label *models.Label) error
+	GetLabelByID(id uint) (*models.Label, error)
+	UpdateLabel(id uint, updatedLabel *models.Label) error
+	DeleteLabel(id uint) error
+	CountUsage() (map[uint]int, error)
+	InWorkspace(workspaceID uint) LabelRepository
+	InTransaction(tasks TaskRepository) LabelRepository
+}
+
+// labelRepository only sees the labels of workspaceID. Zero means every
+// workspace. Inside a task transaction, tx is that transaction.
+type labelRepository struct {
+	workspaceID uint
+	tx          *gorm.DB
+}
+
+func NewLabelRepository() LabelRepository {
+	return &labelRepository{}
+}
+
+// InWorkspace returns a repository limited to one workspace's labels, which
+// also creates labels there.
+func (r *labelRepository) InWorkspace(workspaceID uint) LabelRepository {
+	return &labelRepository{workspaceID: workspaceID, tx: r.tx}
+}
+
+// InTransaction returns a repository whose changes are part of the
+// transaction tasks runs in, if any, so labels created for a task are rolled
+// back with it.
+func (r *labelRepository) InTransaction(tasks TaskRepository) LabelRepository {
+	if repo, ok := tasks.(*taskRepository); ok && repo.tx != nil {
+		return &labelRepository{workspaceID: r.workspaceID, tx: repo.tx}
+	}
+	return r
+}
+
+func (r *labelRepository) db() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return config.DB
+}
+
+// labels starts a query on the labels this repository can see.
+func (r *labelRepository) labels() *gorm.DB {
+	if r.workspaceID == 0 {
+		return r.db()
+	}
+	return r.db().Where("labels.workspace_id = ?", r.workspaceID)
+}
+
+func (r *labelRepository) GetLabels() ([]models.Label, error) {
+	var labels []models.Label
+	if err := r.labels().Order("name ASC").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) GetLabelsByIDs(ids []uint) ([]models.Label, error) {
+	var labels []models.Label
+	if err := r.labels().Where("id IN ?", ids).Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (r *labelRepository) GetLabelByName(name string) (*models.Label, error) {
+	var label models.Label
+	if err := r.labels().Where("LOWER(name) = LOWER(?)", name).First(&label).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) CreateLabel(label *models.Label) error {
+	if r.workspaceID != 0 {
+		label.WorkspaceID = r.workspaceID
+	}
+	return r.db().Create(label).Error
+}
+
+func (r *labelRepository) GetLabelByID(id uint) (*models.Label, error) {
+	var label models.Label
+	if err := r.labels().First(&label, id).Error; err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *labelRepository) UpdateLabel(id uint, updatedLabel *models.Label) error {
+	label, err := r.GetLabelByID(id)
+	if err != nil {
+		return err
+	}
+
+	label.Name = updatedLabel.Name
+	label.Color = updatedLabel.Color
+
+	if err := r.db().Save(label).Error; err != nil {
+		return err
+	}
+	*updatedLabel = *label
+	return nil
+}
+
+// DeleteLabel removes the label and detaches it from every task.
+func (r *labelRepository) DeleteLabel(id uint) error {
+	label, err := r.GetLabelByID(id)
+	if err != nil {
+		return err
+	}
+
+	return r.db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM task_labels WHERE label_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(label).Error
+	})
+}
+
+func (r *labelRepository) CountUsage() (map[uint]int, error) {
+	var rows []struct {
+		LabelID uint
+		Count   int
+	}
+
+	query := r.db().Table("task_labels").Select("label_id, COUNT(*) AS count")
+	if r.workspaceID != 0 {
+		query = query.Joins("JOIN labels ON labels.id = task_labels.label_id").Where("labels.workspace_id = ?", r.workspaceID)
+	}
+	if err := query.Group("label_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.LabelID] = row.Count
+	}
+	return counts, nil
+}
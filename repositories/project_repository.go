@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+	GetProjects(includeArchived bool) ([]models.Project, error)
+	CreateProject(project *models.Project) error
+	GetProjectByID(id uint) (*models.Project, error)
+	UpdateProject(id uint, updatedProject *models.Project) error
+	DeleteProject(id uint) error
+	CountTasksByStatus(projectIDs []uint) (map[uint]map[string]int, error)
+	InWorkspace(workspaceID uint) ProjectRepository
+}
+
+// projectRepository only sees the projects of workspaceID. Zero means every
+// workspace.
+type projectRepository struct {
+	workspaceID uint
+}
+
+func NewProjectRepository() ProjectRepository {
+	return &projectRepository{}
+}
+
+// InWorkspace returns a repository limited to one workspace's projects,
+// which also creates projects there.
+func (r *projectRepository) InWorkspace(workspaceID uint) ProjectRepository {
+	return &projectRepository{workspaceID: workspaceID}
+}
+
+// projects starts a query on the projects this repository can see.
+func (r *projectRepository) projects() *gorm.DB {
+	if r.workspaceID == 0 {
+		return config.DB
+	}
+	return config.DB.Where("projects.workspace_id = ?", r.workspaceID)
+}
+
+func (r *projectRepository) GetProjects(includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	query := r.projects().Model(&models.Project{})
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	if err := query.Order("name ASC").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) CreateProject(project *models.Project) error {
+	if r.workspaceID != 0 {
+		project.WorkspaceID = r.workspaceID
+	}
+	return config.DB.Create(project).Error
+}
+
+func (r *projectRepository) GetProjectByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := r.projects().First(&project, id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) UpdateProject(id uint, updatedProject *models.Project) error {
+	project, err := r.GetProjectByID(id)
+	if err != nil {
+		return err
+	}
+
+	project.Name = updatedProject.Name
+	project.Color = updatedProject.Color
+	project.Description = updatedProject.Description
+	project.Archived = updatedProject.Archived
+	project.WorkflowID = updatedProject.WorkflowID
+
+	if err := config.DB.Save(project).Error; err != nil {
+		return err
+	}
+	*updatedProject = *project
+	return nil
+}
+
+// DeleteProject removes the project and moves its tasks back to the inbox.
+func (r *projectRepository) DeleteProject(id uint) error {
+	project, err := r.GetProjectByID(id)
+	if err != nil {
+		return err
+	}
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Model(&models.Task{}).
+			Where("project_id = ? AND workspace_id = ?", id, project.WorkspaceID).
+			Update("project_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(project).Error
+	})
+}
+
+func (r *projectRepository) CountTasksByStatus(projectIDs []uint) (map[uint]map[string]int, error) {
+	var rows []struct {
+		ProjectID uint
+		Status    string
+		Count     int
+	}
+
+	query := config.DB.Model(&models.Task{}).
+		Select("project_id, status, COUNT(*) AS count").
+		Where("project_id IN ?", projectIDs)
+	if r.workspaceID != 0 {
+		query = query.Where("workspace_id = ?", r.workspaceID)
+	}
+	if err := query.Group("project_id, status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]map[string]int, len(projectIDs))
+	for _, id := range projectIDs {
+		counts[id] = map[string]int{}
+	}
+	for _, row := range rows {
+		counts[row.ProjectID][row.Status] = row.Count
+	}
+	return counts, nil
+}
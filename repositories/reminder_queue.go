@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yasseryazid/technical-test/config"
+)
+
+const (
+	reminderQueueKey      = "reminders:queue"
+	reminderProcessingKey = "reminders:processing"
+)
+
+// ReminderQueue is a delay queue of reminder IDs scored by fire time.
+// ClaimDue hands each due reminder to exactly one caller across instances;
+// the caller must Ack it once handled; otherwise it returns to the queue
+// after the lease expires.
+type ReminderQueue interface {
+	Schedule(id uint, at time.Time) error
+	Remove(id uint) error
+	ClaimDue(now time.Time, limit int, lease time.Duration) ([]uint, error)
+	Ack(id uint) error
+}
+
+// claimDueScript first returns expired leases to the queue, then moves up to
+// ARGV[2] due reminders into the processing set in one atomic step.
+var claimDueScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1])
+for _, id in ipairs(expired) do
+	redis.call('ZREM', KEYS[2], id)
+	redis.call('ZADD', KEYS[1], ARGV[1], id)
+end
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, id in ipairs(due) do
+	redis.call('ZREM', KEYS[1], id)
+	redis.call('ZADD', KEYS[2], ARGV[3], id)
+end
+return due
+`)
+
+type redisReminderQueue struct{}
+
+func NewReminderQueue() ReminderQueue {
+	return &redisReminderQueue{}
+}
+
+func (q *redisReminderQueue) Schedule(id uint, at time.Time) error {
+	return config.RedisClient.ZAdd(context.Background(), reminderQueueKey, redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: reminderMember(id),
+	}).Err()
+}
+
+func (q *redisReminderQueue) Remove(id uint) error {
+	ctx := context.Background()
+	if err := config.RedisClient.ZRem(ctx, reminderQueueKey, reminderMember(id)).Err(); err != nil {
+		return err
+	}
+	return config.RedisClient.ZRem(ctx, reminderProcessingKey, reminderMember(id)).Err()
+}
+
+func (q *redisReminderQueue) ClaimDue(now time.Time, limit int, lease time.Duration) ([]uint, error) {
+	members, err := claimDueScript.Run(context.Background(), config.RedisClient,
+		[]string{reminderQueueKey, reminderProcessingKey},
+		now.UnixMilli(), limit, now.Add(lease).UnixMilli(),
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+func (q *redisReminderQueue) Ack(id uint) error {
+	return config.RedisClient.ZRem(context.Background(), reminderProcessingKey, reminderMember(id)).Err()
+}
+
+func reminderMember(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type ReminderRepository interface {
+	CreateReminder(reminder *models.Reminder) error
+	GetReminderByID(id uint) (*models.Reminder, error)
+	GetReminders(taskID uint) ([]models.Reminder, error)
+	GetPendingOffsetReminders(taskID uint) ([]models.Reminder, error)
+	UpdateReminder(reminder *models.Reminder) error
+	ClaimReminder(id uint, now time.Time, lease time.Duration) (bool, error)
+	DeleteReminder(taskID, id uint) error
+	CreateNotification(notification *models.Notification) error
+	GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error)
+	MarkNotificationRead(userID, id uint) error
+}
+
+type reminderRepository struct{}
+
+func NewReminderRepository() ReminderRepository {
+	return &reminderRepository{}
+}
+
+func (r *reminderRepository) CreateReminder(reminder *models.Reminder) error {
+	return config.DB.Create(reminder).Error
+}
+
+func (r *reminderRepository) GetReminderByID(id uint) (*models.Reminder, error) {
+	var reminder models.Reminder
+	if err := config.DB.First(&reminder, id).Error; err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+func (r *reminderRepository) GetReminders(taskID uint) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	if err := config.DB.Where("task_id = ?", taskID).Order("fire_at ASC").Find(&reminders).Error; err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) GetPendingOffsetReminders(taskID uint) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := config.DB.
+		Where("task_id = ? AND status = ? AND offset_minutes IS NOT NULL", taskID, models.ReminderPending).
+		Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) UpdateReminder(reminder *models.Reminder) error {
+	return config.DB.Save(reminder).Error
+}
+
+// ClaimReminder marks a pending reminder as being delivered for lease. It
+// reports false when the reminder isn't pending or another worker's claim
+// hasn't run out, so only one worker delivers it at a time.
+func (r *reminderRepository) ClaimReminder(id uint, now time.Time, lease time.Duration) (bool, error) {
+	result := config.DB.Model(&models.Reminder{}).
+		Where("id = ? AND status = ? AND (claimed_until IS NULL OR claimed_until < ?)", id, models.ReminderPending, now).
+		Update("claimed_until", now.Add(lease))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *reminderRepository) DeleteReminder(taskID, id uint) error {
+	result := config.DB.Where("task_id = ?", taskID).Delete(&models.Reminder{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *reminderRepository) CreateNotification(notification *models.Notification) error {
+	return config.DB.Create(notification).Error
+}
+
+func (r *reminderRepository) GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := config.DB.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("id DESC").Limit(100).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *reminderRepository) MarkNotificationRead(userID, id uint) error {
+	result := config.DB.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevisionRepository interface {
+	CreateRevision(revision *models.TaskRevision) error
+	GetRevisions(taskID uint) ([]models.TaskRevision, error)
+	GetRevision(taskID uint, number int) (*models.TaskRevision, error)
+}
+
+type revisionRepository struct{}
+
+func NewRevisionRepository() RevisionRepository {
+	return &revisionRepository{}
+}
+
+// CreateRevision stores revision as the task's next numbered version. The
+// task row is locked so concurrent changes don't claim the same number.
+func (r *revisionRepository) CreateRevision(revision *models.TaskRevision) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&task, revision.TaskID).Error; err != nil {
+			return err
+		}
+
+		var latest int
+		if err := tx.Model(&models.TaskRevision{}).Where("task_id = ?", revision.TaskID).
+			Select("COALESCE(MAX(revision), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		revision.Revision = latest + 1
+		return tx.Create(revision).Error
+	})
+}
+
+// GetRevisions returns every revision of a task, newest first.
+func (r *revisionRepository) GetRevisions(taskID uint) ([]models.TaskRevision, error) {
+	var revisions []models.TaskRevision
+	if err := config.DB.Preload("User").Where("task_id = ?", taskID).Order("revision DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *revisionRepository) GetRevision(taskID uint, number int) (*models.TaskRevision, error) {
+	var revision models.TaskRevision
+	if err := config.DB.Preload("User").Where("task_id = ? AND revision = ?", taskID, number).First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
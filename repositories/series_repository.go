@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type SeriesRepository interface {
+	CreateSeries(series *models.TaskSeries) error
+	GetSeriesByID(id uint) (*models.TaskSeries, error)
+	UpdateSeries(id uint, updatedSeries *models.TaskSeries) error
+	InWorkspace(workspaceID uint) SeriesRepository
+	InTransaction(tasks TaskRepository) SeriesRepository
+}
+
+// seriesRepository only sees the series of workspaceID. Zero means every
+// workspace. Inside a task transaction, tx is that transaction.
+type seriesRepository struct {
+	workspaceID uint
+	tx          *gorm.DB
+}
+
+func NewSeriesRepository() SeriesRepository {
+	return &seriesRepository{}
+}
+
+// InWorkspace returns a repository limited to one workspace's series, which
+// also creates series there.
+func (r *seriesRepository) InWorkspace(workspaceID uint) SeriesRepository {
+	return &seriesRepository{workspaceID: workspaceID, tx: r.tx}
+}
+
+// InTransaction returns a repository whose changes are part of the
+// transaction tasks runs in, if any, so a series is rolled back with its
+// first task.
+func (r *seriesRepository) InTransaction(tasks TaskRepository) SeriesRepository {
+	if repo, ok := tasks.(*taskRepository); ok && repo.tx != nil {
+		return &seriesRepository{workspaceID: r.workspaceID, tx: repo.tx}
+	}
+	return r
+}
+
+func (r *seriesRepository) db() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
+	}
+	return config.DB
+}
+
+// series starts a query on the series this repository can see.
+func (r *seriesRepository) series() *gorm.DB {
+	if r.workspaceID == 0 {
+		return r.db()
+	}
+	return r.db().Where("task_series.workspace_id = ?", r.workspaceID)
+}
+
+func (r *seriesRepository) CreateSeries(series *models.TaskSeries) error {
+	if r.workspaceID != 0 {
+		series.WorkspaceID = r.workspaceID
+	}
+	return r.db().Create(series).Error
+}
+
+func (r *seriesRepository) GetSeriesByID(id uint) (*models.TaskSeries, error) {
+	var series models.TaskSeries
+	if err := r.series().First(&series, id).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *seriesRepository) UpdateSeries(id uint, updatedSeries *models.TaskSeries) error {
+	series, err := r.GetSeriesByID(id)
+	if err != nil {
+		return err
+	}
+
+	series.Rule = updatedSeries.Rule
+	series.Mode = updatedSeries.Mode
+	series.StartDate = updatedSeries.StartDate
+	series.FirstOccurrence = updatedSeries.FirstOccurrence
+	series.Title = updatedSeries.Title
+	series.Description = updatedSeries.Description
+	series.Priority = updatedSeries.Priority
+
+	return r.db().Save(series).Error
+}
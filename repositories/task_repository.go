@@ -1,37 +1,146 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/yasseryazid/technical-test/config"
 	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TaskRepository interface {
 	GetTasks(status, search string, page, limit int) ([]models.Task, int, error)
+	FindTasks(filter models.TaskFilter, sort string, page, limit int) ([]models.Task, int, error)
+	EachTask(filter models.TaskFilter, sort string, batchSize int, fn func(tasks []models.Task) error) error
+	GetCalendarTasks(workspaceIDs []uint, since time.Time, limit int) ([]models.Task, error)
 	CreateTask(task *models.Task) error
 	GetTaskByID(id uint) (*models.Task, error)
 	UpdateTask(id uint, updatedTask *models.Task) error
 	DeleteTask(id uint) error
+	GetTrash(page, limit int) ([]models.Task, int, error)
+	GetTrashedTask(id uint) (*models.Task, error)
+	RestoreTask(id uint) error
+	PurgeTask(id uint) error
+	GetExpiredTrash(before time.Time) ([]uint, error)
+	SetArchived(id uint, archivedAt *time.Time) error
+	ArchiveCompletedBefore(before time.Time) ([]uint, error)
+	MoveTask(id uint, projectID *uint) error
+	AddLabels(id uint, labelIDs []uint) error
+	RemoveLabel(id, labelID uint) error
+	GetSubtasks(id uint) ([]models.Task, error)
+	SetParent(id uint, parentID *uint) error
+	GetTasksByIDs(ids []uint) ([]models.Task, error)
+	AddDependency(id, blockerID uint) error
+	RemoveDependency(id, blockerID uint) error
+	GetDependencyEdges(ids []uint) ([]models.TaskDependency, error)
+	ClaimNextOccurrence(id uint) (bool, error)
+	GetDueOccurrences(now time.Time) ([]models.Task, error)
+	UpdateFutureOccurrences(seriesID uint, after int, updatedTask *models.Task) error
+	AddAssignees(id uint, userIDs []uint, assignedBy uint) ([]uint, error)
+	RemoveAssignee(id, userID uint) error
+	InWorkspace(workspaceID uint) TaskRepository
+	Transaction(fn func(repo TaskRepository) error) error
+}
+
+// taskSortColumns maps the sort keys accepted by the API to ORDER BY clauses.
+// A leading "-" on the key sorts descending.
+var taskSortColumns = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"status":       "status",
+	"due_date":     "due_date",
+	"created_at":   "created_at",
+	"completed_at": "completed_at",
+	"priority":     "CASE priority WHEN 'urgent' THEN 4 WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END",
 }
 
-type taskRepository struct{}
+// SortUrgency orders tasks by a rough "what to do next" score: priority plus
+// how close or how far past the due date is. It mirrors usecases.ScoreTask
+// without the age bonus, so the tasks NextTasks scores are the likely best
+// ones. It isn't offered to API clients.
+const SortUrgency = "urgency"
+
+const urgencyOrder = `(CASE priority WHEN 'urgent' THEN 50 WHEN 'high' THEN 30 WHEN 'medium' THEN 20 WHEN 'low' THEN 10 ELSE 0 END) +
+	(CASE WHEN due_date IS NULL THEN 0
+		WHEN due_date < CURRENT_DATE::timestamptz THEN 30 + LEAST(EXTRACT(EPOCH FROM CURRENT_DATE::timestamptz - due_date) / 86400, 14)
+		ELSE GREATEST(0, 25 - 2.5 * EXTRACT(EPOCH FROM due_date - CURRENT_DATE::timestamptz) / 86400) END) DESC, id DESC`
+
+// IsValidTaskSort reports whether sort is empty or a known sort key.
+func IsValidTaskSort(sort string) bool {
+	if sort == "" {
+		return true
+	}
+	if sort[0] == '-' {
+		sort = sort[1:]
+	}
+	_, ok := taskSortColumns[sort]
+	return ok
+}
+
+func taskOrderClause(sort string) string {
+	if sort == SortUrgency {
+		return urgencyOrder
+	}
+	if sort == "" || !IsValidTaskSort(sort) {
+		return "id DESC"
+	}
+	if sort[0] == '-' {
+		return taskSortColumns[sort[1:]] + " DESC NULLS LAST, id DESC"
+	}
+	return taskSortColumns[sort] + " ASC NULLS LAST, id DESC"
+}
+
+// taskRepository only sees the tasks of workspaceID. Zero means every
+// workspace, which is what the background jobs use. Inside Transaction, tx
+// is the transaction it runs in.
+type taskRepository struct {
+	workspaceID uint
+	tx          *gorm.DB
+}
 
 func NewTaskRepository() TaskRepository {
 	return &taskRepository{}
 }
 
-func (r *taskRepository) GetTasks(status, search string, page, limit int) ([]models.Task, int, error) {
-	offset := (page - 1) * limit
-	var tasks []models.Task
-	query := config.DB.Model(&models.Task{})
+// InWorkspace returns a repository limited to one workspace's tasks.
+func (r *taskRepository) InWorkspace(workspaceID uint) TaskRepository {
+	return &taskRepository{workspaceID: workspaceID, tx: r.tx}
+}
+
+// Transaction runs fn with a repository whose changes are committed together
+// when fn returns nil and rolled back otherwise. Nested calls use savepoints.
+func (r *taskRepository) Transaction(fn func(repo TaskRepository) error) error {
+	return r.db().Transaction(func(tx *gorm.DB) error {
+		return fn(&taskRepository{workspaceID: r.workspaceID, tx: tx})
+	})
+}
 
-	if status != "" {
-		query = query.Where("status = ?", status)
+func (r *taskRepository) db() *gorm.DB {
+	if r.tx != nil {
+		return r.tx
 	}
-	if search != "" {
-		query = query.Where("title ILIKE ? OR description ILIKE ?", "%"+search+"%", "%"+search+"%")
+	return config.DB
+}
+
+// tasks starts a query on the tasks this repository can see.
+func (r *taskRepository) tasks() *gorm.DB {
+	if r.workspaceID == 0 {
+		return r.db()
 	}
+	return r.db().Where("tasks.workspace_id = ?", r.workspaceID)
+}
+
+func (r *taskRepository) GetTasks(status, search string, page, limit int) ([]models.Task, int, error) {
+	return r.FindTasks(models.TaskFilter{Status: status, Search: search}, "", page, limit)
+}
+
+func (r *taskRepository) FindTasks(filter models.TaskFilter, sort string, page, limit int) ([]models.Task, int, error) {
+	offset := (page - 1) * limit
+	var tasks []models.Task
+	query := applyTaskFilter(r.tasks().Model(&models.Task{}), filter).Session(&gorm.Session{})
 
-	result := query.Limit(limit).Offset(offset).Order("id DESC").Find(&tasks)
+	result := withTaskAssociations(query).Limit(limit).Offset(offset).Order(taskOrderClause(sort)).Find(&tasks)
 	if result.Error != nil {
 		return nil, 0, result.Error
 	}
@@ -42,13 +151,157 @@ func (r *taskRepository) GetTasks(status, search string, page, limit int) ([]mod
 	return tasks, int(total), nil
 }
 
+// EachTask passes the tasks matching filter to fn in batches of batchSize,
+// in the order of sort, and stops at the first error fn returns. The IDs
+// of the matching tasks are read up front and each batch is loaded by ID,
+// so no connection is held while fn writes to a slow client. Tasks deleted
+// in the meantime are left out.
+func (r *taskRepository) EachTask(filter models.TaskFilter, sort string, batchSize int, fn func(tasks []models.Task) error) error {
+	var ids []uint
+	query := applyTaskFilter(r.tasks().Model(&models.Task{}), filter)
+	if err := query.Order(taskOrderClause(sort)).Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for start := 0; start < len(ids); start += batchSize {
+		batch := ids[start:min(start+batchSize, len(ids))]
+
+		var found []models.Task
+		if err := withTaskAssociations(r.tasks()).Where("id IN ?", batch).Find(&found).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]models.Task, len(found))
+		for _, task := range found {
+			byID[task.ID] = task
+		}
+		tasks := make([]models.Task, 0, len(found))
+		for _, id := range batch {
+			if task, ok := byID[id]; ok {
+				tasks = append(tasks, task)
+			}
+		}
+
+		if len(tasks) > 0 {
+			if err := fn(tasks); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetCalendarTasks returns the unarchived tasks with a due date in the given
+// workspaces, soonest first: every open task, and finished ones due since
+// since.
+func (r *taskRepository) GetCalendarTasks(workspaceIDs []uint, since time.Time, limit int) ([]models.Task, error) {
+	var tasks []models.Task
+	err := r.db().Preload("Labels").
+		Where("workspace_id IN ? AND due_date IS NOT NULL AND archived_at IS NULL", workspaceIDs).
+		Where("status_category = ? OR due_date >= ?", models.StatusCategoryOpen, since).
+		Order("due_date ASC, id ASC").Limit(limit).Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// withTaskAssociations preloads what the task presenters need.
+func withTaskAssociations(query *gorm.DB) *gorm.DB {
+	return query.Preload("Labels").Preload("Subtasks").Preload("BlockedBy").Preload("Series").Preload("Assignees.User")
+}
+
+// dueBefore and dueFrom compare due dates with the start of a calendar day.
+// Dates without a time are stored as midnight UTC and compare by day; timed
+// due dates compare with midnight in loc.
+func dueBefore(query *gorm.DB, day time.Time, loc *time.Location) *gorm.DB {
+	return query.Where("((due_has_time AND due_date < ?) OR (NOT due_has_time AND due_date < ?))",
+		startOfDay(day, loc), startOfDay(day, time.UTC))
+}
+
+func dueFrom(query *gorm.DB, day time.Time, loc *time.Location) *gorm.DB {
+	return query.Where("((due_has_time AND due_date >= ?) OR (NOT due_has_time AND due_date >= ?))",
+		startOfDay(day, loc), startOfDay(day, time.UTC))
+}
+
+func startOfDay(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+}
+
+func applyTaskFilter(query *gorm.DB, filter models.TaskFilter) *gorm.DB {
+	loc := filter.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now := time.Now().In(loc)
+
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.StatusCategory != "" {
+		query = query.Where("status_category = ?", filter.StatusCategory)
+	}
+	if len(filter.Priorities) > 0 {
+		query = query.Where("priority IN ?", filter.Priorities)
+	}
+	if filter.Search != "" {
+		query = query.Where("(title ILIKE ? OR description ILIKE ?)", "%"+filter.Search+"%", "%"+filter.Search+"%")
+	}
+	if from, err := time.Parse("2006-01-02", filter.DueFrom); err == nil {
+		query = dueFrom(query, from, loc)
+	}
+	if to, err := time.Parse("2006-01-02", filter.DueTo); err == nil {
+		query = dueBefore(query, to.AddDate(0, 0, 1), loc)
+	}
+	if filter.Overdue {
+		query = query.Where("((due_has_time AND due_date < ?) OR (NOT due_has_time AND due_date < ?)) AND status_category = ?",
+			now, startOfDay(now, time.UTC), models.StatusCategoryOpen)
+	}
+	if filter.DueWithinDays > 0 {
+		query = dueFrom(query, now, loc)
+		query = dueBefore(query, now.AddDate(0, 0, filter.DueWithinDays+1), loc)
+		query = query.Where("status_category = ?", models.StatusCategoryOpen)
+	}
+	if filter.CompletedWithinDays > 0 {
+		since := time.Now().AddDate(0, 0, -filter.CompletedWithinDays)
+		query = query.Where("completed_at >= ?", since)
+	}
+	if len(filter.LabelIDs) > 0 {
+		if filter.LabelMatch == "all" {
+			query = query.Where("id IN (?)", config.DB.Table("task_labels").
+				Select("task_id").
+				Where("label_id IN ?", filter.LabelIDs).
+				Group("task_id").
+				Having("COUNT(DISTINCT label_id) = ?", len(filter.LabelIDs)))
+		} else {
+			query = query.Where("id IN (?)", config.DB.Table("task_labels").
+				Select("task_id").
+				Where("label_id IN ?", filter.LabelIDs))
+		}
+	}
+	if filter.AssigneeID != 0 {
+		query = query.Where("id IN (?)", config.DB.Model(&models.TaskAssignee{}).
+			Select("task_id").
+			Where("user_id = ?", filter.AssigneeID))
+	}
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	return query
+}
+
 func (r *taskRepository) CreateTask(task *models.Task) error {
-	return config.DB.Create(task).Error
+	if r.workspaceID != 0 {
+		task.WorkspaceID = r.workspaceID
+	}
+	return r.db().Create(task).Error
 }
 
 func (r *taskRepository) GetTaskByID(id uint) (*models.Task, error) {
 	var task models.Task
-	result := config.DB.First(&task, id)
+	result := withTaskAssociations(r.tasks()).First(&task, id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -58,23 +311,355 @@ func (r *taskRepository) GetTaskByID(id uint) (*models.Task, error) {
 func (r *taskRepository) UpdateTask(id uint, updatedTask *models.Task) error {
 	var task models.Task
 
-	if err := config.DB.First(&task, id).Error; err != nil {
+	if err := r.tasks().First(&task, id).Error; err != nil {
 		return err
 	}
 
 	task.Title = updatedTask.Title
 	task.Description = updatedTask.Description
 	task.Status = updatedTask.Status
+	task.StatusCategory = updatedTask.StatusCategory
+	task.Priority = updatedTask.Priority
+	task.AutoComplete = updatedTask.AutoComplete
 	task.DueDate = updatedTask.DueDate
+	if task.CompletedAt == nil || updatedTask.CompletedAt == nil {
+		task.CompletedAt = updatedTask.CompletedAt
+	}
 
-	return config.DB.Save(&task).Error
+	return r.db().Save(&task).Error
 }
 
+// DeleteTask moves a task and its subtasks to the trash. They keep their
+// dependencies, assignees, comments and attachments until they are purged.
 func (r *taskRepository) DeleteTask(id uint) error {
+	if err := r.tasks().First(&models.Task{}, id).Error; err != nil {
+		return err
+	}
+
+	ids, err := r.subtreeIDs(id, nil)
+	if err != nil {
+		return err
+	}
+	return r.db().Where("id IN ?", ids).Delete(&models.Task{}).Error
+}
+
+// GetTrash returns a page of trashed tasks, most recently deleted first.
+func (r *taskRepository) GetTrash(page, limit int) ([]models.Task, int, error) {
+	offset := (page - 1) * limit
+	query := r.tasks().Unscoped().Model(&models.Task{}).Where("tasks.deleted_at IS NOT NULL").Session(&gorm.Session{})
+
+	var tasks []models.Task
+	if err := withTaskAssociations(query).Limit(limit).Offset(offset).Order("deleted_at DESC, id DESC").Find(&tasks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	query.Count(&total)
+	return tasks, int(total), nil
+}
+
+func (r *taskRepository) GetTrashedTask(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := withTaskAssociations(r.tasks().Unscoped()).Where("tasks.deleted_at IS NOT NULL").First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// RestoreTask takes a task out of the trash, along with the subtasks that
+// were trashed with it. A task whose parent is still in the trash comes back
+// as a top-level task.
+func (r *taskRepository) RestoreTask(id uint) error {
+	task, err := r.GetTrashedTask(id)
+	if err != nil {
+		return err
+	}
+
+	ids, err := r.subtreeIDs(id, &task.DeletedAt.Time)
+	if err != nil {
+		return err
+	}
+	return r.db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Task{}).Where("id IN ?", ids).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if task.ParentID == nil {
+			return nil
+		}
+		var parents int64
+		if err := tx.Model(&models.Task{}).Where("id = ?", *task.ParentID).Count(&parents).Error; err != nil {
+			return err
+		}
+		if parents > 0 {
+			return nil
+		}
+		return tx.Model(&models.Task{}).Where("id = ?", id).Update("parent_id", nil).Error
+	})
+}
+
+// PurgeTask permanently deletes a trashed task and what belongs to it.
+// Subtasks still in the trash become top-level tasks there.
+func (r *taskRepository) PurgeTask(id uint) error {
+	task, err := r.GetTrashedTask(id)
+	if err != nil {
+		return err
+	}
+
+	return r.db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Task{}).Where("parent_id = ?", id).Update("parent_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id = ? OR blocker_id = ?", id, id).Delete(&models.TaskDependency{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id = ?", id).Delete(&models.TaskAssignee{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id IN (?)", config.DB.Model(&models.Comment{}).Select("id").Where("task_id = ?", id)).Delete(&models.CommentRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id = ?", id).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("task_id = ?", id).Delete(&models.Attachment{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Select("Labels").Delete(task).Error
+	})
+}
+
+// GetExpiredTrash returns the IDs of tasks trashed before the given time.
+func (r *taskRepository) GetExpiredTrash(before time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.tasks().Unscoped().Model(&models.Task{}).
+		Where("tasks.deleted_at IS NOT NULL AND tasks.deleted_at < ?", before).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// subtreeIDs returns id followed by the IDs of every task nested under it.
+// With deletedAt set, it follows the subtasks trashed at that moment instead
+// of live ones.
+func (r *taskRepository) subtreeIDs(id uint, deletedAt *time.Time) ([]uint, error) {
+	ids := []uint{id}
+	for frontier := ids; len(frontier) > 0; {
+		query := r.db().Model(&models.Task{}).Where("parent_id IN ?", frontier)
+		if deletedAt != nil {
+			query = query.Unscoped().Where("deleted_at = ?", *deletedAt)
+		}
+
+		var children []uint
+		if err := query.Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+		ids = append(ids, children...)
+		frontier = children
+	}
+	return ids, nil
+}
+
+// SetArchived archives a task at archivedAt, or unarchives it when
+// archivedAt is nil.
+func (r *taskRepository) SetArchived(id uint, archivedAt *time.Time) error {
+	var task models.Task
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	return r.db().Model(&task).Update("archived_at", archivedAt).Error
+}
+
+// ArchiveCompletedBefore archives the done tasks completed before the given
+// time and returns their IDs.
+func (r *taskRepository) ArchiveCompletedBefore(before time.Time) ([]uint, error) {
+	var archived []models.Task
+	err := r.tasks().Model(&archived).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("archived_at IS NULL AND status_category = ? AND completed_at < ?", models.StatusCategoryDone, before).
+		Update("archived_at", time.Now()).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(archived))
+	for i, task := range archived {
+		ids[i] = task.ID
+	}
+	return ids, nil
+}
+
+func (r *taskRepository) MoveTask(id uint, projectID *uint) error {
+	var task models.Task
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	return r.db().Model(&task).Update("project_id", projectID).Error
+}
+
+func (r *taskRepository) AddLabels(id uint, labelIDs []uint) error {
+	var task models.Task
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	labels := make([]models.Label, len(labelIDs))
+	for i, labelID := range labelIDs {
+		labels[i].ID = labelID
+	}
+	return r.db().Model(&task).Omit("Labels.*").Association("Labels").Append(labels)
+}
+
+func (r *taskRepository) RemoveLabel(id, labelID uint) error {
+	var task models.Task
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	return r.db().Model(&task).Association("Labels").Delete(&models.Label{ID: labelID})
+}
+
+func (r *taskRepository) GetSubtasks(id uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := withTaskAssociations(r.tasks()).Where("parent_id = ?", id).Order("id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) SetParent(id uint, parentID *uint) error {
+	var task models.Task
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return err
+	}
+
+	return r.db().Model(&task).Update("parent_id", parentID).Error
+}
+
+func (r *taskRepository) GetTasksByIDs(ids []uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := withTaskAssociations(r.tasks()).Where("id IN ?", ids).Order("id ASC").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) AddDependency(id, blockerID uint) error {
+	dependency := models.TaskDependency{TaskID: id, BlockerID: blockerID}
+	return r.db().Where(dependency).FirstOrCreate(&dependency).Error
+}
+
+func (r *taskRepository) RemoveDependency(id, blockerID uint) error {
+	if err := r.tasks().First(&models.Task{}, id).Error; err != nil {
+		return err
+	}
+
+	result := r.db().Where("task_id = ? AND blocker_id = ?", id, blockerID).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetDependencyEdges returns every dependency that touches one of ids, in
+// either direction. Dependencies on trashed tasks are left out.
+func (r *taskRepository) GetDependencyEdges(ids []uint) ([]models.TaskDependency, error) {
+	live := config.DB.Model(&models.Task{}).Select("id")
+	var dependencies []models.TaskDependency
+	if err := r.db().Where("task_id IN ? OR blocker_id IN ?", ids, ids).
+		Where("task_id IN (?) AND blocker_id IN (?)", live, live).
+		Find(&dependencies).Error; err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// ClaimNextOccurrence marks a recurring task as having generated its next
+// occurrence. It reports false when another caller got there first, so each
+// occurrence is generated once.
+func (r *taskRepository) ClaimNextOccurrence(id uint) (bool, error) {
+	result := r.tasks().Model(&models.Task{}).
+		Where("id = ? AND next_generated = ?", id, false).
+		Update("next_generated", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// GetDueOccurrences returns occurrences of scheduled series that are due by
+// now and have not generated their successor yet.
+func (r *taskRepository) GetDueOccurrences(now time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := withTaskAssociations(r.tasks()).
+		Where("next_generated = ?", false).
+		Where("((due_has_time AND due_date <= ?) OR (NOT due_has_time AND due_date <= ?))", now, startOfDay(now, time.UTC)).
+		Where("series_id IN (?)", config.DB.Model(&models.TaskSeries{}).
+			Select("id").
+			Where("mode = ?", models.RecurrenceScheduled)).
+		Order("id ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// UpdateFutureOccurrences copies the series fields of updatedTask onto the
+// open occurrences that come after the given one.
+func (r *taskRepository) UpdateFutureOccurrences(seriesID uint, after int, updatedTask *models.Task) error {
+	return r.tasks().Model(&models.Task{}).
+		Where("series_id = ? AND occurrence > ? AND status_category = ?", seriesID, after, models.StatusCategoryOpen).
+		Updates(map[string]interface{}{
+			"title":       updatedTask.Title,
+			"description": updatedTask.Description,
+			"priority":    updatedTask.Priority,
+		}).Error
+}
+
+// AddAssignees assigns users to a task and returns the ones that were not
+// assigned yet.
+func (r *taskRepository) AddAssignees(id uint, userIDs []uint, assignedBy uint) ([]uint, error) {
 	var task models.Task
-	if err := config.DB.First(&task, id).Error; err != nil {
+	if err := r.tasks().First(&task, id).Error; err != nil {
+		return nil, err
+	}
+
+	var added []uint
+	err := r.db().Transaction(func(tx *gorm.DB) error {
+		for _, userID := range userIDs {
+			assignee := models.TaskAssignee{TaskID: id, UserID: userID, AssignedBy: assignedBy}
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&assignee)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				added = append(added, userID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+func (r *taskRepository) RemoveAssignee(id, userID uint) error {
+	if err := r.tasks().First(&models.Task{}, id).Error; err != nil {
 		return err
 	}
 
-	return config.DB.Delete(&task).Error
+	result := r.db().Where("task_id = ? AND user_id = ?", id, userID).Delete(&models.TaskAssignee{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
@@ -8,6 +8,13 @@ import (
 type UserRepository interface {
 	CreateUser(user *models.User) error
 	GetUserByUsername(username string) (*models.User, error)
+	GetUserByID(id uint) (*models.User, error)
+	GetUsers() ([]models.User, error)
+	GetUsersByIDs(ids []uint) ([]models.User, error)
+	GetUsersByUsernames(usernames []string) ([]models.User, error)
+	UpdateTimezone(id uint, timezone string) error
+	GetUserByCalendarToken(tokenHash string) (*models.User, error)
+	SetCalendarToken(id uint, tokenHash *string) error
 }
 
 type userRepository struct{}
@@ -27,3 +34,53 @@ func (r *userRepository) GetUserByUsername(username string) (*models.User, error
 	}
 	return &user, nil
 }
+
+func (r *userRepository) GetUserByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := config.DB.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) UpdateTimezone(id uint, timezone string) error {
+	return config.DB.Model(&models.User{}).Where("id = ?", id).Update("timezone", timezone).Error
+}
+
+func (r *userRepository) GetUserByCalendarToken(tokenHash string) (*models.User, error) {
+	var user models.User
+	if err := config.DB.Where("calendar_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetCalendarToken replaces the user's calendar feed token, or turns the
+// feed off when tokenHash is nil.
+func (r *userRepository) SetCalendarToken(id uint, tokenHash *string) error {
+	return config.DB.Model(&models.User{}).Where("id = ?", id).Update("calendar_token_hash", tokenHash).Error
+}
+
+func (r *userRepository) GetUsers() ([]models.User, error) {
+	var users []models.User
+	if err := config.DB.Order("username ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) GetUsersByIDs(ids []uint) ([]models.User, error) {
+	var users []models.User
+	if err := config.DB.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) GetUsersByUsernames(usernames []string) ([]models.User, error) {
+	var users []models.User
+	if err := config.DB.Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
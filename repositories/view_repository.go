@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+)
+
+type ViewRepository interface {
+	GetViews(userID uint) ([]models.SavedView, error)
+	CreateView(view *models.SavedView) error
+	GetViewByID(id, userID uint) (*models.SavedView, error)
+	UpdateView(id, userID uint, updatedView *models.SavedView) error
+	DeleteView(id, userID uint) error
+}
+
+type viewRepository struct{}
+
+func NewViewRepository() ViewRepository {
+	return &viewRepository{}
+}
+
+func (r *viewRepository) GetViews(userID uint) ([]models.SavedView, error) {
+	var views []models.SavedView
+	if err := config.DB.Where("user_id = ?", userID).Order("name ASC").Find(&views).Error; err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+func (r *viewRepository) CreateView(view *models.SavedView) error {
+	return config.DB.Create(view).Error
+}
+
+func (r *viewRepository) GetViewByID(id, userID uint) (*models.SavedView, error) {
+	var view models.SavedView
+	if err := config.DB.Where("user_id = ?", userID).First(&view, id).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+func (r *viewRepository) UpdateView(id, userID uint, updatedView *models.SavedView) error {
+	view, err := r.GetViewByID(id, userID)
+	if err != nil {
+		return err
+	}
+
+	view.Name = updatedView.Name
+	view.Filters = updatedView.Filters
+	view.Sort = updatedView.Sort
+	view.Columns = updatedView.Columns
+
+	if err := config.DB.Save(view).Error; err != nil {
+		return err
+	}
+	*updatedView = *view
+	return nil
+}
+
+func (r *viewRepository) DeleteView(id, userID uint) error {
+	view, err := r.GetViewByID(id, userID)
+	if err != nil {
+		return err
+	}
+	return config.DB.Delete(view).Error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type WorkflowRepository interface {
+	GetWorkflows() ([]models.Workflow, error)
+	CreateWorkflow(workflow *models.Workflow) error
+	GetWorkflowByID(id uint) (*models.Workflow, error)
+	UpdateWorkflow(id uint, updatedWorkflow *models.Workflow) error
+	DeleteWorkflow(id uint) error
+	RecordTransition(transition *models.TaskTransition) error
+	GetTransitions(taskID uint) ([]models.TaskTransition, error)
+	InWorkspace(workspaceID uint) WorkflowRepository
+}
+
+// workflowRepository only sees the workflows of workspaceID. Zero means
+// every workspace.
+type workflowRepository struct {
+	workspaceID uint
+}
+
+func NewWorkflowRepository() WorkflowRepository {
+	return &workflowRepository{}
+}
+
+// InWorkspace returns a repository limited to one workspace's workflows,
+// which also creates workflows there.
+func (r *workflowRepository) InWorkspace(workspaceID uint) WorkflowRepository {
+	return &workflowRepository{workspaceID: workspaceID}
+}
+
+// workflows starts a query on the workflows this repository can see.
+func (r *workflowRepository) workflows() *gorm.DB {
+	if r.workspaceID == 0 {
+		return config.DB
+	}
+	return config.DB.Where("workflows.workspace_id = ?", r.workspaceID)
+}
+
+func (r *workflowRepository) GetWorkflows() ([]models.Workflow, error) {
+	var workflows []models.Workflow
+	if err := r.workflows().Order("name ASC").Find(&workflows).Error; err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+func (r *workflowRepository) CreateWorkflow(workflow *models.Workflow) error {
+	if r.workspaceID != 0 {
+		workflow.WorkspaceID = r.workspaceID
+	}
+	return config.DB.Create(workflow).Error
+}
+
+func (r *workflowRepository) GetWorkflowByID(id uint) (*models.Workflow, error) {
+	var workflow models.Workflow
+	if err := r.workflows().First(&workflow, id).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func (r *workflowRepository) UpdateWorkflow(id uint, updatedWorkflow *models.Workflow) error {
+	workflow, err := r.GetWorkflowByID(id)
+	if err != nil {
+		return err
+	}
+
+	workflow.Name = updatedWorkflow.Name
+	workflow.States = updatedWorkflow.States
+	workflow.Transitions = updatedWorkflow.Transitions
+
+	if err := config.DB.Save(workflow).Error; err != nil {
+		return err
+	}
+	*updatedWorkflow = *workflow
+	return nil
+}
+
+// DeleteWorkflow removes the workflow; projects using it fall back to the
+// default workflow.
+func (r *workflowRepository) DeleteWorkflow(id uint) error {
+	workflow, err := r.GetWorkflowByID(id)
+	if err != nil {
+		return err
+	}
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Project{}).
+			Where("workflow_id = ? AND workspace_id = ?", id, workflow.WorkspaceID).
+			Update("workflow_id", nil).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(workflow).Error
+	})
+}
+
+func (r *workflowRepository) RecordTransition(transition *models.TaskTransition) error {
+	return config.DB.Create(transition).Error
+}
+
+func (r *workflowRepository) GetTransitions(taskID uint) ([]models.TaskTransition, error) {
+	var transitions []models.TaskTransition
+	if err := config.DB.Where("task_id = ?", taskID).Order("created_at ASC, id ASC").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/yasseryazid/technical-test/config"
+	"github.com/yasseryazid/technical-test/models"
+	"gorm.io/gorm"
+)
+
+type WorkspaceRepository interface {
+	CreateWorkspace(workspace *models.Workspace) error
+	GetWorkspaceByID(id uint) (*models.Workspace, error)
+	UpdateWorkspace(id uint, updatedWorkspace *models.Workspace) error
+	DeleteWorkspace(id uint) error
+	CountTasks(id uint) (int64, error)
+	GetMemberships(userID uint) ([]models.WorkspaceMember, error)
+	GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error)
+	GetMembers(workspaceID uint) ([]models.WorkspaceMember, error)
+	GetMembersByUserIDs(workspaceID uint, userIDs []uint) ([]models.WorkspaceMember, error)
+	UpdateMemberRole(workspaceID, userID uint, role string) error
+	RemoveMember(workspaceID, userID uint) error
+	CreateInvitation(invitation *models.WorkspaceInvitation) error
+	GetInvitationByID(id uint) (*models.WorkspaceInvitation, error)
+	AcceptInvitation(invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) (bool, error)
+}
+
+type workspaceRepository struct{}
+
+func NewWorkspaceRepository() WorkspaceRepository {
+	return &workspaceRepository{}
+}
+
+// CreateWorkspace creates a workspace with its owner as the first member.
+func (r *workspaceRepository) CreateWorkspace(workspace *models.Workspace) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.WorkspaceMember{
+			WorkspaceID: workspace.ID,
+			UserID:      workspace.OwnerID,
+			Role:        models.RoleOwner,
+		}).Error
+	})
+}
+
+func (r *workspaceRepository) GetWorkspaceByID(id uint) (*models.Workspace, error) {
+	var workspace models.Workspace
+	if err := config.DB.First(&workspace, id).Error; err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) UpdateWorkspace(id uint, updatedWorkspace *models.Workspace) error {
+	var workspace models.Workspace
+	if err := config.DB.First(&workspace, id).Error; err != nil {
+		return err
+	}
+
+	workspace.Name = updatedWorkspace.Name
+	return config.DB.Save(&workspace).Error
+}
+
+func (r *workspaceRepository) DeleteWorkspace(id uint) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceInvitation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("workspace_id = ?", id).Delete(&models.WorkspaceMember{}).Error; err != nil {
+			return err
+		}
+		for _, model := range []any{&models.Project{}, &models.Label{}, &models.Workflow{}} {
+			if err := tx.Where("workspace_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Delete(&models.Workspace{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// CountTasks counts the workspace's tasks, including those in the trash.
+func (r *workspaceRepository) CountTasks(id uint) (int64, error) {
+	var count int64
+	err := config.DB.Unscoped().Model(&models.Task{}).Where("workspace_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// GetMemberships returns the workspaces a user belongs to, oldest first.
+func (r *workspaceRepository) GetMemberships(userID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	if err := config.DB.Preload("Workspace").Where("user_id = ?", userID).Order("workspace_id ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *workspaceRepository) GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	var member models.WorkspaceMember
+	if err := config.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *workspaceRepository) GetMembers(workspaceID uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	if err := config.DB.Preload("User").Where("workspace_id = ?", workspaceID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *workspaceRepository) GetMembersByUserIDs(workspaceID uint, userIDs []uint) ([]models.WorkspaceMember, error) {
+	var members []models.WorkspaceMember
+	if err := config.DB.Where("workspace_id = ? AND user_id IN ?", workspaceID, userIDs).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *workspaceRepository) UpdateMemberRole(workspaceID, userID uint, role string) error {
+	result := config.DB.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceID, userID).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *workspaceRepository) RemoveMember(workspaceID, userID uint) error {
+	result := config.DB.Where("workspace_id = ? AND user_id = ?", workspaceID, userID).Delete(&models.WorkspaceMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *workspaceRepository) CreateInvitation(invitation *models.WorkspaceInvitation) error {
+	return config.DB.Create(invitation).Error
+}
+
+func (r *workspaceRepository) GetInvitationByID(id uint) (*models.WorkspaceInvitation, error) {
+	var invitation models.WorkspaceInvitation
+	if err := config.DB.First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// AcceptInvitation marks an invitation as used and adds the member. It
+// reports false when the invitation was already used, so each one only
+// admits one user.
+func (r *workspaceRepository) AcceptInvitation(invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) (bool, error) {
+	accepted := false
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.WorkspaceInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Updates(map[string]interface{}{"accepted_by": member.UserID, "accepted_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		accepted = true
+		return tx.Create(member).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return accepted, nil
+}
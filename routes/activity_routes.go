@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterActivityRoutes adds the activity log route to the tasks group.
+func RegisterActivityRoutes(api *gin.RouterGroup, activityHandler *handlers.ActivityHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	{
+		api.GET("/:id/activity", read, activityHandler.GetTaskActivity)
+	}
+}
+
+func RegisterAuditRoutes(api *gin.RouterGroup, activityHandler *handlers.ActivityHandler) {
+	{
+		api.GET("", activityHandler.GetAudit)
+	}
+}
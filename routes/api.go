@@ -4,22 +4,139 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/yasseryazid/technical-test/handlers"
 	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
 	"github.com/yasseryazid/technical-test/repositories"
 )
 
-func RegisterAPIRoutes(router *gin.Engine, taskHandler *handlers.TaskHandler) {
+// Handlers groups the handlers built in main so new resources don't keep
+// widening RegisterAPIRoutes.
+type Handlers struct {
+	Task       *handlers.TaskHandler
+	View       *handlers.ViewHandler
+	Project    *handlers.ProjectHandler
+	Label      *handlers.LabelHandler
+	Workflow   *handlers.WorkflowHandler
+	Reminder   *handlers.ReminderHandler
+	Workspace  *handlers.WorkspaceHandler
+	Comment    *handlers.CommentHandler
+	Attachment *handlers.AttachmentHandler
+	Activity   *handlers.ActivityHandler
+	Calendar   *handlers.CalendarHandler
+}
+
+func RegisterAPIRoutes(router *gin.Engine, h Handlers) {
 	api := router.Group("/api")
+	api.Use(middlewares.RequestIDMiddleware())
 
 	userRepo := repositories.NewUserRepository()
-	authHandler := &handlers.AuthHandler{UserRepo: userRepo}
+	authHandler := &handlers.AuthHandler{UserRepo: userRepo, Workspaces: h.Workspace.Service}
 
 	api.POST("/register", authHandler.Register)
 	api.POST("/login", authHandler.Login)
 	api.POST("/logout", authHandler.Logout)
 
+	userHandler := &handlers.UserHandler{UserRepo: userRepo}
+	meRoutes := api.Group("/me")
+	meRoutes.Use(middlewares.AuthMiddleware())
+	{
+		meRoutes.GET("", userHandler.GetMe)
+		meRoutes.PUT("", userHandler.UpdateMe)
+		meRoutes.POST("/calendar/token", h.Calendar.RegenerateFeedToken)
+		meRoutes.DELETE("/calendar/token", h.Calendar.RevokeFeedToken)
+	}
+
+	// Members of the active workspace, for picking assignees without user:admin.
+	memberRoutes := api.Group("/members")
+	memberRoutes.Use(middlewares.AuthMiddleware(), middlewares.WorkspaceMiddleware(), middlewares.RequirePermission(models.PermTaskRead))
+	{
+		memberRoutes.GET("", h.Workspace.GetWorkspaceUsers)
+	}
+
+	userRoutes := api.Group("/users")
+	userRoutes.Use(middlewares.AuthMiddleware(), middlewares.RequirePermission(models.PermUserAdmin))
+	{
+		userRoutes.GET("", userHandler.GetUsers)
+	}
+
+	auditRoutes := api.Group("/audit")
+	auditRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.RequirePermission(models.PermUserAdmin))
+	{
+		RegisterAuditRoutes(auditRoutes, h.Activity)
+	}
+
+	workspaceRoutes := api.Group("/workspaces")
+	workspaceRoutes.Use(middlewares.AuthMiddleware())
+	{
+		RegisterWorkspaceRoutes(workspaceRoutes, h.Workspace)
+	}
+
+	invitationRoutes := api.Group("/invitations")
+	invitationRoutes.Use(middlewares.AuthMiddleware())
+	{
+		RegisterInvitationRoutes(invitationRoutes, h.Workspace)
+	}
+
+	// Task routes work in the workspace named by the X-Workspace-ID header,
+	// or in the one named in the path under /api/workspaces/:workspaceId.
 	taskRoutes := api.Group("/tasks")
-	taskRoutes.Use(middlewares.AuthMiddleware())
+	taskRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterTaskRoutes(taskRoutes, h.Task)
+		RegisterReminderRoutes(taskRoutes, h.Reminder)
+		RegisterCommentRoutes(taskRoutes, h.Comment)
+		RegisterAttachmentRoutes(taskRoutes, h.Attachment)
+		RegisterActivityRoutes(taskRoutes, h.Activity)
+	}
+
+	workspaceTaskRoutes := api.Group("/workspaces/:workspaceId/tasks")
+	workspaceTaskRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterTaskRoutes(workspaceTaskRoutes, h.Task)
+		RegisterReminderRoutes(workspaceTaskRoutes, h.Reminder)
+		RegisterCommentRoutes(workspaceTaskRoutes, h.Comment)
+		RegisterAttachmentRoutes(workspaceTaskRoutes, h.Attachment)
+		RegisterActivityRoutes(workspaceTaskRoutes, h.Activity)
+	}
+
+	trashRoutes := api.Group("/trash")
+	trashRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterTrashRoutes(trashRoutes, h.Task)
+	}
+
+	// Signed download links carry their own token instead of a session.
+	api.GET("/attachments/download", h.Attachment.ServeDownload)
+
+	// Calendar feeds are read by calendar apps, which only have the URL.
+	router.GET("/ical/:token", h.Calendar.ServeFeed)
+
+	viewRoutes := api.Group("/views")
+	viewRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterViewRoutes(viewRoutes, h.View)
+	}
+
+	projectRoutes := api.Group("/projects")
+	projectRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterProjectRoutes(projectRoutes, h.Project)
+	}
+
+	labelRoutes := api.Group("/labels")
+	labelRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterLabelRoutes(labelRoutes, h.Label)
+	}
+
+	workflowRoutes := api.Group("/workflows")
+	workflowRoutes.Use(middlewares.AuthMiddleware(), middlewares.TimezoneMiddleware(), middlewares.WorkspaceMiddleware())
+	{
+		RegisterWorkflowRoutes(workflowRoutes, h.Workflow)
+	}
+
+	notificationRoutes := api.Group("/notifications")
+	notificationRoutes.Use(middlewares.AuthMiddleware())
 	{
-		RegisterTaskRoutes(taskRoutes, taskHandler)
+		RegisterNotificationRoutes(notificationRoutes, h.Reminder)
 	}
 }
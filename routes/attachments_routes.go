@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAttachmentRoutes adds the attachment routes to the tasks group.
+func RegisterAttachmentRoutes(api *gin.RouterGroup, attachmentHandler *handlers.AttachmentHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	{
+		api.GET("/:id/attachments", read, attachmentHandler.GetAttachments)
+		api.POST("/:id/attachments", write, attachmentHandler.UploadAttachment)
+		api.GET("/:id/attachments/:attachmentId", read, attachmentHandler.GetAttachment)
+		api.GET("/:id/attachments/:attachmentId/download", read, attachmentHandler.DownloadAttachment)
+		api.DELETE("/:id/attachments/:attachmentId", write, attachmentHandler.DeleteAttachment)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCommentRoutes adds the comment routes to the tasks group.
+func RegisterCommentRoutes(api *gin.RouterGroup, commentHandler *handlers.CommentHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	{
+		api.GET("/:id/comments", read, commentHandler.GetComments)
+		api.POST("/:id/comments", write, commentHandler.CreateComment)
+		api.PUT("/:id/comments/:commentId", write, commentHandler.UpdateComment)
+		api.DELETE("/:id/comments/:commentId", write, commentHandler.DeleteComment)
+		api.GET("/:id/comments/:commentId/history", read, commentHandler.GetCommentHistory)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLabelRoutes(api *gin.RouterGroup, labelHandler *handlers.LabelHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	{
+		api.GET("", read, labelHandler.GetLabels)
+		api.POST("", write, labelHandler.CreateLabel)
+		api.PUT("/:id", write, labelHandler.UpdateLabel)
+		api.DELETE("/:id", write, labelHandler.DeleteLabel)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterProjectRoutes(api *gin.RouterGroup, projectHandler *handlers.ProjectHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	{
+		api.GET("", read, projectHandler.GetProjects)
+		api.POST("", write, projectHandler.CreateProject)
+		api.GET("/:id", read, projectHandler.GetProjectByID)
+		api.PUT("/:id", write, projectHandler.UpdateProject)
+		api.DELETE("/:id", write, projectHandler.DeleteProject)
+		api.GET("/:id/tasks", read, middlewares.NegotiationMiddleware(presenters.TaskListResponse{}), projectHandler.GetProjectTasks)
+		api.POST("/:id/tasks", write, projectHandler.CreateProjectTask)
+	}
+}
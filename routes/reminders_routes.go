@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReminderRoutes adds the reminder routes to the tasks group.
+func RegisterReminderRoutes(api *gin.RouterGroup, reminderHandler *handlers.ReminderHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	{
+		api.GET("/:id/reminders", read, reminderHandler.GetReminders)
+		api.POST("/:id/reminders", write, reminderHandler.CreateReminder)
+		api.DELETE("/:id/reminders/:reminderId", write, reminderHandler.DeleteReminder)
+	}
+}
+
+func RegisterNotificationRoutes(api *gin.RouterGroup, reminderHandler *handlers.ReminderHandler) {
+	{
+		api.GET("", reminderHandler.GetNotifications)
+		api.POST("/:id/read", reminderHandler.MarkNotificationRead)
+	}
+}
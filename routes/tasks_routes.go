@@ -2,16 +2,52 @@ package routes
 
 import (
 	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
 
 	"github.com/gin-gonic/gin"
 )
 
 func RegisterTaskRoutes(api *gin.RouterGroup, taskHandler *handlers.TaskHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	write := middlewares.RequirePermission(models.PermTaskWrite)
+	remove := middlewares.RequirePermission(models.PermTaskDelete)
+	// Routes answering with tasks honor Accept; see presenters.Negotiate.
+	// Only task lists and single tasks can be shown as CSV.
+	rows := middlewares.NegotiationMiddleware(presenters.TaskListResponse{})
+	negotiate := middlewares.NegotiationMiddleware(nil)
 	{
-		api.GET("", taskHandler.GetTasks)
-		api.POST("", taskHandler.CreateTask)
-		api.GET("/:id", taskHandler.GetTaskByID)
-		api.PUT("/:id", taskHandler.UpdateTask)
-		api.DELETE("/:id", taskHandler.DeleteTask)
+		api.GET("", read, rows, taskHandler.GetTasks)
+		api.POST("", write, negotiate, taskHandler.CreateTask)
+		api.GET("/next", read, rows, taskHandler.GetNextTasks)
+		api.POST("/quick", write, negotiate, taskHandler.QuickAddTask)
+		api.POST("/bulk", write, taskHandler.BulkTasks)
+		api.POST("/import", write, taskHandler.ImportTasks)
+		api.GET("/import/:jobId", read, taskHandler.GetImportJob)
+		api.GET("/export", read, taskHandler.ExportTasks)
+		api.GET("/:id", read, rows, taskHandler.GetTaskByID)
+		api.PUT("/:id", write, negotiate, taskHandler.UpdateTask)
+		api.DELETE("/:id", remove, taskHandler.DeleteTask)
+		api.POST("/:id/restore", remove, taskHandler.RestoreTask)
+		api.POST("/:id/move", write, taskHandler.MoveTask)
+		api.POST("/:id/archive", write, taskHandler.ArchiveTask)
+		api.POST("/:id/unarchive", write, taskHandler.UnarchiveTask)
+		api.GET("/:id/subtasks", read, rows, taskHandler.GetSubtasks)
+		api.POST("/:id/subtasks", write, negotiate, taskHandler.CreateSubtask)
+		api.PUT("/:id/parent", write, taskHandler.SetTaskParent)
+		api.POST("/:id/dependencies", write, taskHandler.AddTaskDependency)
+		api.DELETE("/:id/dependencies/:blockerId", write, taskHandler.RemoveTaskDependency)
+		api.GET("/:id/graph", read, taskHandler.GetDependencyGraph)
+		api.POST("/:id/transition", write, taskHandler.TransitionTask)
+		api.GET("/:id/transitions", read, taskHandler.GetTaskTransitions)
+		api.GET("/:id/revisions", read, taskHandler.GetTaskRevisions)
+		api.GET("/:id/revisions/diff", read, taskHandler.DiffTaskRevisions)
+		api.GET("/:id/revisions/:rev", read, taskHandler.GetTaskRevision)
+		api.POST("/:id/revisions/:rev/restore", write, taskHandler.RestoreTaskRevision)
+		api.POST("/:id/labels", write, taskHandler.AddTaskLabels)
+		api.DELETE("/:id/labels/:labelId", write, taskHandler.RemoveTaskLabel)
+		api.POST("/:id/assignees", write, taskHandler.AssignTask)
+		api.DELETE("/:id/assignees/:userId", write, taskHandler.UnassignTask)
 	}
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTrashRoutes(api *gin.RouterGroup, taskHandler *handlers.TaskHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	remove := middlewares.RequirePermission(models.PermTaskDelete)
+	{
+		api.GET("", read, middlewares.NegotiationMiddleware(presenters.TrashedTaskListResponse{}), taskHandler.GetTrash)
+		api.DELETE("", remove, taskHandler.EmptyTrash)
+		api.DELETE("/:id", remove, taskHandler.PurgeTask)
+	}
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterViewRoutes(api *gin.RouterGroup, viewHandler *handlers.ViewHandler) {
+	{
+		api.GET("", viewHandler.GetViews)
+		api.POST("", viewHandler.CreateView)
+		api.GET("/:id", viewHandler.GetViewByID)
+		api.PUT("/:id", viewHandler.UpdateView)
+		api.DELETE("/:id", viewHandler.DeleteView)
+		// View columns are chosen per view, so views aren't shown as CSV.
+		api.GET("/:id/tasks", middlewares.RequirePermission(models.PermTaskRead), middlewares.NegotiationMiddleware(nil), viewHandler.GetViewTasks)
+	}
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Workflows decide how every task in a project moves, so changing them is
+// left to those who manage the workspace.
+func RegisterWorkflowRoutes(api *gin.RouterGroup, workflowHandler *handlers.WorkflowHandler) {
+	read := middlewares.RequirePermission(models.PermTaskRead)
+	manage := middlewares.RequirePermission(models.PermWorkspaceManage)
+	{
+		api.GET("", read, workflowHandler.GetWorkflows)
+		api.POST("", manage, workflowHandler.CreateWorkflow)
+		api.GET("/:id", read, workflowHandler.GetWorkflowByID)
+		api.PUT("/:id", manage, workflowHandler.UpdateWorkflow)
+		api.DELETE("/:id", manage, workflowHandler.DeleteWorkflow)
+	}
+}
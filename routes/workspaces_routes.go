@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/yasseryazid/technical-test/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWorkspaceRoutes(api *gin.RouterGroup, workspaceHandler *handlers.WorkspaceHandler) {
+	{
+		api.GET("", workspaceHandler.GetWorkspaces)
+		api.POST("", workspaceHandler.CreateWorkspace)
+		api.GET("/:workspaceId", workspaceHandler.GetWorkspaceByID)
+		api.PUT("/:workspaceId", workspaceHandler.UpdateWorkspace)
+		api.DELETE("/:workspaceId", workspaceHandler.DeleteWorkspace)
+		api.GET("/:workspaceId/members", workspaceHandler.GetMembers)
+		api.PUT("/:workspaceId/members/:userId", workspaceHandler.UpdateMemberRole)
+		api.DELETE("/:workspaceId/members/:userId", workspaceHandler.RemoveMember)
+		api.POST("/:workspaceId/invitations", workspaceHandler.CreateInvitation)
+	}
+}
+
+func RegisterInvitationRoutes(api *gin.RouterGroup, workspaceHandler *handlers.WorkspaceHandler) {
+	{
+		api.POST("/accept", workspaceHandler.AcceptInvitation)
+	}
+}
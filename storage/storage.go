@@ -0,0 +1,51 @@
+// Package storage keeps the contents of task attachments, on the local disk
+// or in an S3-compatible bucket.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("object not found")
+
+// Backend is implemented by every storage backend.
+type Backend interface {
+	Put(key string, body io.Reader, size int64, contentType string) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// FromEnv picks the backend named by STORAGE_DRIVER: "local" (the default)
+// keeps files under ATTACHMENT_DIR, "s3" uses the S3_* settings.
+func FromEnv() (Backend, error) {
+	switch driver := strings.ToLower(os.Getenv("STORAGE_DRIVER")); driver {
+	case "", "local":
+		root := os.Getenv("ATTACHMENT_DIR")
+		if root == "" {
+			root = "uploads"
+		}
+		return NewLocalStorage(root)
+	case "s3":
+		return NewS3StorageFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
+
+// validKey rejects keys that could escape the storage root or bucket prefix.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid storage key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid storage key %q", key)
+		}
+	}
+	return nil
+}
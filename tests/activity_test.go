@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type MockActivityRepository struct {
+	mock.Mock
+}
+
+func (m *MockActivityRepository) RecordActivity(activity *models.TaskActivity) error {
+	args := m.Called(activity)
+	return args.Error(0)
+}
+
+func (m *MockActivityRepository) GetTaskActivity(taskID uint) ([]models.TaskActivity, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.TaskActivity), args.Error(1)
+}
+
+func (m *MockActivityRepository) FindActivity(filter models.ActivityFilter, page, limit int) ([]models.TaskActivity, int, error) {
+	args := m.Called(filter, page, limit)
+	return args.Get(0).([]models.TaskActivity), args.Int(1), args.Error(2)
+}
+
+// recorded returns the activities passed to RecordActivity, in order.
+func (m *MockActivityRepository) recorded() []*models.TaskActivity {
+	var activities []*models.TaskActivity
+	for _, call := range m.Calls {
+		if call.Method == "RecordActivity" {
+			activities = append(activities, call.Arguments.Get(0).(*models.TaskActivity))
+		}
+	}
+	return activities
+}
+
+// ✅ Test Field-Level Diffs
+func Test_DiffTasks(t *testing.T) {
+	projectID := uint(4)
+	before := &models.Task{Title: "Draft", Status: "pending", Priority: "none"}
+	after := &models.Task{Title: "Final", Status: "pending", Priority: "none", ProjectID: &projectID}
+
+	assert.Equal(t, []models.FieldChange{
+		{Field: "title", From: "Draft", To: "Final"},
+		{Field: "project_id", From: nil, To: uint(4)},
+	}, usecases.DiffTasks(before, after))
+
+	created := usecases.DiffTasks(nil, before)
+	assert.Len(t, created, 3, "Only the fields a new task has set should be listed")
+	assert.Empty(t, usecases.DiffTasks(before, before))
+}
+
+// ✅ Test Updates Are Recorded With Actor and Request
+func Test_UpdateTask_RecordsActivity(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	current := &models.Task{ID: 3, WorkspaceID: 2, Title: "Write report", Status: "pending", StatusCategory: models.StatusCategoryOpen, Priority: "none"}
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	mockRepo.On("UpdateTask", uint(3), mock.Anything).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	ctx := usecases.ChangeContext{UserID: 9, RequestID: "req-1"}
+	err := service.UpdateTaskAs(3, &models.Task{Title: "Write the report", Priority: "high"}, ctx)
+	assert.Nil(t, err)
+
+	_, err = service.TransitionTask(3, "completed", ctx)
+	assert.Nil(t, err)
+
+	recorded := activity.recorded()
+	assert.Len(t, recorded, 2)
+	assert.Equal(t, models.ActivityUpdated, recorded[0].Action)
+	assert.Equal(t, uint(9), *recorded[0].UserID)
+	assert.Equal(t, "req-1", recorded[0].RequestID)
+	assert.Equal(t, uint(2), recorded[0].WorkspaceID)
+	assert.Equal(t, []models.FieldChange{
+		{Field: "title", From: "Write report", To: "Write the report"},
+		{Field: "priority", From: "none", To: "high"},
+	}, recorded[0].Changes)
+
+	assert.Equal(t, models.ActivityTransitioned, recorded[1].Action, "A status-only change is a transition")
+	assert.Equal(t, []models.FieldChange{{Field: "status", From: "pending", To: "completed"}}, recorded[1].Changes)
+}
+
+// ✅ Test Deletions Are Attributed Through As
+func Test_DeleteTask_RecordsActivity(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, Title: "Old task", Status: "pending"}, nil)
+	mockRepo.On("DeleteTask", uint(3)).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	err := service.As(usecases.ChangeContext{UserID: 5, RequestID: "req-2"}).DeleteTask(3)
+	assert.Nil(t, err)
+
+	recorded := activity.recorded()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, models.ActivityDeleted, recorded[0].Action)
+	assert.Equal(t, uint(5), *recorded[0].UserID)
+	assert.Contains(t, recorded[0].Changes, models.FieldChange{Field: "title", From: "Old task", To: nil})
+}
+
+// ✅ Test Request IDs
+func Test_RequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.RequestIDMiddleware())
+	router.GET("/check", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("request_id"))
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/check", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "client-123")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "client-123", w.Body.String(), "A well-formed client ID should be kept")
+	assert.Equal(t, "client-123", w.Header().Get(middlewares.RequestIDHeader))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/check", nil)
+	req.Header.Set(middlewares.RequestIDHeader, "bad id\nInjected: yes")
+	router.ServeHTTP(w, req)
+	assert.Len(t, w.Body.String(), 32, "Malformed IDs should be replaced")
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// ✅ Test Archiving Keeps the Status
+func Test_ArchiveTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, Status: "pending"}, nil)
+	mockRepo.On("SetArchived", uint(3), mock.AnythingOfType("*time.Time")).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	task, err := service.ArchiveTask(3)
+	assert.Nil(t, err)
+	assert.NotNil(t, task.ArchivedAt)
+	assert.Equal(t, "pending", task.Status, "Archiving should not change the status")
+
+	recorded := activity.recorded()
+	assert.Equal(t, models.ActivityArchived, recorded[0].Action)
+	assert.Equal(t, []models.FieldChange{{Field: "archived", From: false, To: true}}, recorded[0].Changes)
+}
+
+// ✅ Test Archiving Twice or Unarchiving an Active Task
+func Test_ArchiveTask_State(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	archivedAt := time.Now()
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, ArchivedAt: &archivedAt}, nil)
+	mockRepo.On("GetTaskByID", uint(4)).Return(&models.Task{ID: 4}, nil)
+
+	_, err := service.ArchiveTask(3)
+	assert.Equal(t, usecases.ErrTaskArchived, err)
+	_, err = service.UnarchiveTask(4)
+	assert.Equal(t, usecases.ErrTaskNotArchived, err)
+	mockRepo.AssertNotCalled(t, "SetArchived", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Requests Can't Archive a Task Directly
+func Test_TaskJSON_IgnoresArchivedAt(t *testing.T) {
+	var task models.Task
+	err := json.Unmarshal([]byte(`{"title": "Hidden", "archived_at": "2025-01-06T09:00:00Z"}`), &task)
+	assert.Nil(t, err)
+	assert.Nil(t, task.ArchivedAt, "Only the archive endpoints and job should archive tasks")
+}
+
+// ✅ Test the Auto-Archive Job
+func Test_ArchiveCompleted(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	mockRepo.On("ArchiveCompletedBefore", now.AddDate(0, 0, -30)).Return([]uint{5, 6}, nil)
+	mockRepo.On("GetTaskByID", mock.Anything).Return(&models.Task{ID: 5}, nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	archived, err := service.ArchiveCompleted(now, usecases.DefaultAutoArchiveAge)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, archived)
+	assert.Len(t, activity.recorded(), 2)
+	assert.Nil(t, activity.recorded()[0].UserID, "Auto-archiving is a system change")
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+	locked []string
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(attachment *models.Attachment) error {
+	args := m.Called(attachment)
+	attachment.ID = 3
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) GetAttachments(taskID uint) ([]models.Attachment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentByID(id uint) (*models.Attachment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) CountByHash(hash string) (int64, error) {
+	args := m.Called(hash)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetHashes(taskID uint) ([]string, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) LockHash(hash string, fn func(repo repositories.AttachmentRepository) error) error {
+	m.locked = append(m.locked, hash)
+	return fn(m)
+}
+
+// memoryStorage is a storage.Backend that keeps objects in a map.
+type memoryStorage struct {
+	objects map[string][]byte
+	puts    int
+}
+
+func (s *memoryStorage) Put(key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.objects[key] = data
+	s.puts++
+	return nil
+}
+
+func (s *memoryStorage) Open(key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.objects[key])), nil
+}
+
+func (s *memoryStorage) Delete(key string) error {
+	delete(s.objects, key)
+	return nil
+}
+
+func attachmentService() (*usecases.AttachmentService, *MockAttachmentRepository, *memoryStorage) {
+	mockRepo := new(MockAttachmentRepository)
+	mockTasks := new(MockTaskRepository)
+	mockTasks.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	files := &memoryStorage{objects: map[string][]byte{}}
+	return usecases.NewAttachmentService(mockRepo, mockTasks, files), mockRepo, files
+}
+
+// ✅ Test Identical Uploads Are Stored Once
+func Test_UploadAttachment_Dedup(t *testing.T) {
+	service, mockRepo, files := attachmentService()
+
+	const hash = "09ca7e4eaa6e8ae9c7d261167129184883644d07dfba7cbfbc4c8a2e08360d5b" // sha256("hello, world")
+	mockRepo.On("CountByHash", hash).Return(int64(0), nil).Once()
+	mockRepo.On("CountByHash", hash).Return(int64(1), nil)
+	mockRepo.On("CreateAttachment", mock.Anything).Return(nil)
+
+	first, err := service.Upload(1, 2, "../../notes.txt", strings.NewReader("hello, world"))
+	assert.Nil(t, err)
+	assert.Equal(t, hash, first.Hash)
+	assert.Equal(t, "notes.txt", first.Filename, "Directories should be stripped from file names")
+	assert.Equal(t, "text/plain", first.ContentType)
+	assert.Equal(t, int64(12), first.Size)
+
+	_, err = service.Upload(1, 3, "copy.txt", strings.NewReader("hello, world"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, files.puts, "The same contents should only be stored once")
+	assert.Equal(t, []byte("hello, world"), files.objects["attachments/09/"+hash])
+	assert.Equal(t, []string{hash, hash}, mockRepo.locked, "Files should only be stored under their hash's lock")
+}
+
+// ✅ Test Upload Limits
+func Test_UploadAttachment_Limits(t *testing.T) {
+	service, mockRepo, files := attachmentService()
+	service.MaxSize = 16
+
+	_, err := service.Upload(1, 2, "big.txt", strings.NewReader(strings.Repeat("a", 17)))
+	assert.ErrorIs(t, err, usecases.ErrAttachmentTooLarge)
+
+	_, err = service.Upload(1, 2, "page.txt", strings.NewReader("<html><script>"))
+	assert.ErrorIs(t, err, usecases.ErrAttachmentType, "The type should be sniffed, not taken from the name")
+
+	_, err = service.Upload(1, 2, "empty.txt", strings.NewReader(""))
+	assert.Equal(t, usecases.ErrAttachmentEmpty, err)
+
+	mockRepo.AssertNotCalled(t, "CreateAttachment", mock.Anything)
+	assert.Equal(t, 0, files.puts)
+}
+
+// ✅ Test Deleting Keeps Shared Contents
+func Test_DeleteAttachment_PrunesUnusedFiles(t *testing.T) {
+	service, mockRepo, files := attachmentService()
+
+	files.objects["attachments/ab/abc"] = []byte("shared")
+	files.objects["attachments/cd/cde"] = []byte("own")
+	mockRepo.On("GetAttachmentByID", uint(5)).Return(&models.Attachment{ID: 5, TaskID: 1, UploadedBy: 2, Hash: "abc"}, nil)
+	mockRepo.On("GetAttachmentByID", uint(6)).Return(&models.Attachment{ID: 6, TaskID: 1, UploadedBy: 2, Hash: "cde"}, nil)
+	mockRepo.On("DeleteAttachment", mock.Anything).Return(nil)
+	mockRepo.On("CountByHash", "abc").Return(int64(1), nil)
+	mockRepo.On("CountByHash", "cde").Return(int64(0), nil)
+
+	err := service.DeleteAttachment(1, 5, 3, false)
+	assert.Equal(t, usecases.ErrAttachmentForbidden, err, "Only the uploader or a moderator can delete")
+
+	assert.Nil(t, service.DeleteAttachment(1, 5, 2, false))
+	assert.Contains(t, files.objects, "attachments/ab/abc", "Contents used by another attachment should stay")
+
+	assert.Nil(t, service.DeleteAttachment(1, 6, 3, true))
+	assert.NotContains(t, files.objects, "attachments/cd/cde")
+}
+
+// ✅ Test Signed Download Links
+func Test_AttachmentDownloadToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	service, mockRepo, files := attachmentService()
+
+	attachment := &models.Attachment{ID: 5, TaskID: 1, Filename: "a.txt", Hash: "abc"}
+	files.objects["attachments/ab/abc"] = []byte("contents")
+	mockRepo.On("GetAttachmentByID", uint(5)).Return(attachment, nil)
+
+	url, _, err := service.DownloadURL(attachment)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(url, "/api/attachments/download?token="))
+
+	token := url[strings.Index(url, "=")+1:]
+	found, contents, err := service.OpenDownload(token)
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(contents)
+	assert.Equal(t, "a.txt", found.Filename)
+	assert.Equal(t, "contents", string(data))
+
+	_, _, err = service.OpenDownload(token + "x")
+	assert.Equal(t, usecases.ErrInvalidDownloadURL, err, "Tampered links should be rejected")
+
+	invitation, _ := utils.GenerateInvitationToken(5, 1, time.Now().Add(time.Hour))
+	_, _, err = service.OpenDownload(invitation)
+	assert.Equal(t, usecases.ErrInvalidDownloadURL, err, "Tokens issued for another purpose should be rejected")
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+func bulkDeletes(ids ...uint) []usecases.BulkOperation {
+	operations := make([]usecases.BulkOperation, len(ids))
+	for i, id := range ids {
+		operations[i] = usecases.BulkOperation{Op: usecases.BulkDelete, TaskID: id}
+	}
+	return operations
+}
+
+// ✅ Test All-or-Nothing Rolls Back on the First Failure
+func Test_Bulk_AllOrNothing(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1, Title: "One"}, nil)
+	mockRepo.On("GetTaskByID", uint(2)).Return((*models.Task)(nil), errors.New("record not found"))
+	mockRepo.On("DeleteTask", uint(1)).Return(nil)
+
+	report, err := service.Bulk(usecases.BulkRequest{Operations: bulkDeletes(1, 2, 3)}, nil, usecases.ChangeContext{})
+	assert.Nil(t, err)
+	assert.False(t, report.Committed)
+	assert.Equal(t, usecases.BulkAllOrNothing, report.Mode)
+	assert.Equal(t, 0, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, usecases.BulkRolledBack, report.Results[0].Status)
+	assert.Equal(t, usecases.BulkFailed, report.Results[1].Status)
+	assert.Equal(t, usecases.BulkSkipped, report.Results[2].Status)
+	activity.AssertNotCalled(t, "RecordActivity", mock.Anything)
+}
+
+// ✅ Test Best-Effort Keeps the Operations That Succeeded
+func Test_Bulk_BestEffort(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1, Title: "One"}, nil)
+	mockRepo.On("GetTaskByID", uint(2)).Return((*models.Task)(nil), errors.New("record not found"))
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, Title: "Three"}, nil)
+	mockRepo.On("DeleteTask", mock.Anything).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	request := usecases.BulkRequest{Mode: usecases.BulkBestEffort, Operations: bulkDeletes(1, 2, 3)}
+	report, err := service.Bulk(request, nil, usecases.ChangeContext{})
+	assert.Nil(t, err)
+	assert.True(t, report.Committed)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, usecases.BulkFailed, report.Results[1].Status)
+	assert.Len(t, activity.recorded(), 2, "Only the deleted tasks should be recorded")
+}
+
+// ✅ Test Updates Are Validated Like Single Updates
+func Test_Bulk_UpdateValidates(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1, Title: "One", Status: "pending"}, nil)
+
+	blank := ""
+	request := usecases.BulkRequest{Operations: []usecases.BulkOperation{
+		{Op: usecases.BulkUpdate, TaskID: 1, Fields: &usecases.BulkFields{Title: &blank}},
+	}}
+	validate := func(task *models.Task) error {
+		if task.Title == "" {
+			return errors.New("Title is required")
+		}
+		return nil
+	}
+
+	report, err := service.Bulk(request, validate, usecases.ChangeContext{})
+	assert.Nil(t, err)
+	assert.ErrorIs(t, report.Results[0].Err, usecases.ErrInvalidTaskFields)
+	mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Invalid Bulk Requests Are Rejected Upfront
+func Test_Bulk_InvalidRequest(t *testing.T) {
+	service := usecases.NewTaskService(new(MockTaskRepository))
+
+	_, err := service.Bulk(usecases.BulkRequest{Mode: "sometimes", Operations: bulkDeletes(1)}, nil, usecases.ChangeContext{})
+	assert.Equal(t, usecases.ErrInvalidBulkMode, err)
+
+	_, err = service.Bulk(usecases.BulkRequest{}, nil, usecases.ChangeContext{})
+	assert.Equal(t, usecases.ErrNoOperations, err)
+
+	request := usecases.BulkRequest{Operations: []usecases.BulkOperation{{Op: "archive", TaskID: 1}}}
+	_, err = service.Bulk(request, nil, usecases.ChangeContext{})
+	assert.ErrorIs(t, err, usecases.ErrInvalidBulkOperation)
+
+	_, err = service.Bulk(usecases.BulkRequest{Operations: bulkDeletes(make([]uint, usecases.MaxBulkOperations+1)...)}, nil, usecases.ChangeContext{})
+	assert.Equal(t, usecases.ErrTooManyOperations, err)
+}
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+	"gorm.io/gorm"
+)
+
+// feedCache keeps feeds in memory in place of Redis.
+type feedCache struct {
+	mu       sync.Mutex
+	versions map[uint]int64
+	feeds    map[string][]byte
+}
+
+func newFeedCache() *feedCache {
+	return &feedCache{versions: map[uint]int64{}, feeds: map[string][]byte{}}
+}
+
+func (c *feedCache) Versions(workspaceIDs []uint) ([]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	versions := make([]int64, len(workspaceIDs))
+	for i, id := range workspaceIDs {
+		versions[i] = c.versions[id]
+	}
+	return versions, nil
+}
+
+func (c *feedCache) Bump(workspaceID uint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[workspaceID]++
+	return nil
+}
+
+func (c *feedCache) Get(key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	feed, ok := c.feeds[key]
+	return feed, ok, nil
+}
+
+func (c *feedCache) Set(key string, feed []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.feeds[key] = feed
+	return nil
+}
+
+type MockFeedInvalidator struct {
+	mock.Mock
+}
+
+func (m *MockFeedInvalidator) InvalidateWorkspace(workspaceID uint) {
+	m.Called(workspaceID)
+}
+
+// calendarService returns a service whose user 5 has a feed token, a viewer
+// in workspace 1 and a member of workspace 2.
+func calendarService(t *testing.T) (*usecases.CalendarService, *MockTaskRepository, string) {
+	users := new(MockUserRepository)
+	workspaces := new(MockWorkspaceRepository)
+	tasks := new(MockTaskRepository)
+	service := usecases.NewCalendarService(users, workspaces, tasks)
+
+	var stored string
+	users.On("SetCalendarToken", uint(5), mock.Anything).Run(func(args mock.Arguments) {
+		stored = *args.Get(1).(*string)
+	}).Return(nil)
+	token, err := service.RegenerateToken(5)
+	assert.Nil(t, err)
+	assert.Len(t, token, 64)
+	assert.NotEqual(t, token, stored, "Only a hash of the token should be stored")
+
+	user := &models.User{Username: "alice"}
+	user.ID = 5
+	users.On("GetUserByCalendarToken", stored).Return(user, nil)
+	users.On("GetUserByCalendarToken", mock.Anything).Return((*models.User)(nil), gorm.ErrRecordNotFound)
+	workspaces.On("GetMemberships", uint(5)).Return([]models.WorkspaceMember{
+		{WorkspaceID: 1, UserID: 5, Role: models.RoleViewer},
+		{WorkspaceID: 2, UserID: 5, Role: models.RoleMember},
+	}, nil)
+	return service, tasks, token
+}
+
+// ✅ Test a Feed Token Opens Only Its Own Feed
+func Test_CalendarFeed_Token(t *testing.T) {
+	service, tasks, token := calendarService(t)
+	due := []models.Task{{ID: 1, Title: "Pay rent", WorkspaceID: 1, DueDate: dueDate("2025-03-01")}}
+	tasks.On("GetCalendarTasks", []uint{1, 2}, mock.Anything, usecases.MaxFeedTasks).Return(due, nil)
+
+	var rendered []models.Task
+	render := func(user *models.User, tasks []models.Task) ([]byte, error) {
+		rendered = tasks
+		return []byte("feed of " + user.Username), nil
+	}
+
+	feed, err := service.Feed(token, usecases.FeedEvents, render)
+	assert.Nil(t, err)
+	assert.Equal(t, "feed of alice", string(feed))
+	assert.Equal(t, due, rendered)
+
+	_, err = service.Feed(strings.Repeat("0", 64), usecases.FeedEvents, render)
+	assert.ErrorIs(t, err, usecases.ErrFeedNotFound)
+	_, err = service.Feed(token, "journal", render)
+	assert.ErrorIs(t, err, usecases.ErrInvalidFeedType)
+}
+
+// ✅ Test Feeds Are Cached Until a Workspace Changes
+func Test_CalendarFeed_Cache(t *testing.T) {
+	service, tasks, token := calendarService(t)
+	service.Cache = newFeedCache()
+	tasks.On("GetCalendarTasks", []uint{1, 2}, mock.Anything, usecases.MaxFeedTasks).Return([]models.Task{}, nil)
+
+	renders := 0
+	render := func(user *models.User, tasks []models.Task) ([]byte, error) {
+		renders++
+		return []byte("feed"), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := service.Feed(token, usecases.FeedEvents, render)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 1, renders, "The second request should be served from the cache")
+
+	_, err := service.Feed(token, usecases.FeedTodos, render)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, renders, "Each feed type should be cached separately")
+
+	service.InvalidateWorkspace(3)
+	_, err = service.Feed(token, usecases.FeedEvents, render)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, renders, "Changes in other workspaces should keep the cache")
+
+	service.InvalidateWorkspace(2)
+	_, err = service.Feed(token, usecases.FeedEvents, render)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, renders)
+}
+
+// ✅ Test Task Changes Invalidate Calendar Feeds
+func Test_UpdateTask_InvalidatesFeeds(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	feeds := new(MockFeedInvalidator)
+	service := usecases.NewTaskService(mockRepo)
+	service.Feeds = feeds
+
+	current := &models.Task{ID: 3, WorkspaceID: 4, Title: "Write report", Status: "pending", StatusCategory: models.StatusCategoryOpen, Priority: "none"}
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	mockRepo.On("UpdateTask", uint(3), mock.Anything).Return(nil)
+	feeds.On("InvalidateWorkspace", uint(4)).Return()
+
+	assert.Nil(t, service.UpdateTask(3, &models.Task{Title: "Write the report"}))
+	feeds.AssertCalled(t, "InvalidateWorkspace", uint(4))
+}
+
+// ✅ Test Project Tasks and Series Updates Invalidate Calendar Feeds
+func Test_ProjectTaskAndSeries_InvalidateFeeds(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockProjects := new(MockProjectRepository)
+	mockSeries := new(MockSeriesRepository)
+	feeds := new(MockFeedInvalidator)
+	tasks := usecases.NewTaskService(mockRepo)
+	tasks.Series = mockSeries
+	tasks.Feeds = feeds
+	projects := usecases.NewProjectService(mockProjects, tasks).InWorkspace(4)
+
+	mockProjects.On("GetProjectByID", uint(2)).Return(&models.Project{ID: 2, WorkspaceID: 4}, nil)
+	mockRepo.On("CreateTask", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Task).WorkspaceID = 4
+	})
+	feeds.On("InvalidateWorkspace", uint(4)).Return()
+
+	assert.Nil(t, projects.CreateProjectTask(2, &models.Task{Title: "Plan sprint", Priority: "none"}))
+	feeds.AssertNumberOfCalls(t, "InvalidateWorkspace", 1)
+
+	seriesID := uint(6)
+	current := &models.Task{ID: 3, WorkspaceID: 4, Title: "Standup", Status: "pending", StatusCategory: models.StatusCategoryOpen, Priority: "none", SeriesID: &seriesID, Occurrence: 2}
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	mockRepo.On("UpdateTask", uint(3), mock.Anything).Return(nil)
+	mockSeries.On("GetSeriesByID", seriesID).Return(&models.TaskSeries{ID: seriesID, Rule: "FREQ=DAILY"}, nil)
+	mockSeries.On("UpdateSeries", seriesID, mock.Anything).Return(nil)
+	mockRepo.On("UpdateFutureOccurrences", seriesID, 2, mock.Anything).Return(nil).Run(func(mock.Arguments) {
+		feeds.AssertNumberOfCalls(t, "InvalidateWorkspace", 2)
+	})
+
+	assert.Nil(t, tasks.UpdateTaskSeries(3, &models.Task{Title: "Daily standup"}, usecases.ChangeContext{}))
+	feeds.AssertNumberOfCalls(t, "InvalidateWorkspace", 3)
+}
+
+// ✅ Test Serving a Feed as Events or To-dos
+func Test_ServeCalendarFeed(t *testing.T) {
+	service, tasks, token := calendarService(t)
+	due := []models.Task{
+		{ID: 1, Title: "Pay rent", WorkspaceID: 1, StatusCategory: models.StatusCategoryOpen, DueDate: dueDate("2025-03-01")},
+		{ID: 2, Title: "File taxes", WorkspaceID: 2, StatusCategory: models.StatusCategoryDone, DueDate: dueDate("2025-02-20")},
+	}
+	tasks.On("GetCalendarTasks", []uint{1, 2}, mock.Anything, usecases.MaxFeedTasks).Return(due, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ical/:token", (&handlers.CalendarHandler{Service: service}).ServeFeed)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := serve("/ical/" + token + ".ics")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "X-WR-CALNAME:Tasks of alice\r\n")
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"))
+	assert.Contains(t, body, "DTSTART;VALUE=DATE:20250301\r\n")
+	assert.Contains(t, body, "SUMMARY:✓ File taxes\r\n")
+
+	w = serve("/ical/" + token + ".ics?type=todo")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, strings.Count(w.Body.String(), "BEGIN:VTODO"))
+
+	w = serve("/ical/" + strings.Repeat("0", 64) + ".ics")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
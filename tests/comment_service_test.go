@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) CreateComment(comment *models.Comment) error {
+	args := m.Called(comment)
+	comment.ID = 10
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetComments(taskID uint) ([]models.Comment, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetCommentByID(taskID, id uint) (*models.Comment, error) {
+	args := m.Called(taskID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) UpdateComment(comment *models.Comment, body string, editedBy uint) error {
+	args := m.Called(comment, body, editedBy)
+	comment.Body = body
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) DeleteComment(taskID, id uint) error {
+	args := m.Called(taskID, id)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) GetRevisions(commentID uint) ([]models.CommentRevision, error) {
+	args := m.Called(commentID)
+	return args.Get(0).([]models.CommentRevision), args.Error(1)
+}
+
+type MockMentionNotifier struct {
+	mock.Mock
+}
+
+func (m *MockMentionNotifier) NotifyMentioned(task *models.Task, comment *models.Comment, userID uint) error {
+	args := m.Called(task, comment, userID)
+	return args.Error(0)
+}
+
+func commentService() (*usecases.CommentService, *MockCommentRepository, *MockTaskRepository, *MockUserRepository, *MockMentionNotifier) {
+	mockRepo := new(MockCommentRepository)
+	mockTasks := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	notifier := new(MockMentionNotifier)
+	service := usecases.NewCommentService(mockRepo, mockTasks)
+	service.Users = mockUsers
+	service.Mentions = notifier
+	return service, mockRepo, mockTasks, mockUsers, notifier
+}
+
+// ✅ Test Mentioned Users Are Notified
+func Test_CreateComment_NotifiesMentions(t *testing.T) {
+	service, mockRepo, mockTasks, mockUsers, notifier := commentService()
+
+	task := &models.Task{ID: 1, Title: "Review PR"}
+	mockTasks.On("GetTaskByID", uint(1)).Return(task, nil)
+	mockRepo.On("CreateComment", mock.Anything).Return(nil)
+	mockUsers.On("GetUsersByUsernames", []string{"alice", "bob"}).Return(users(2, 3), nil)
+	notifier.On("NotifyMentioned", task, mock.Anything, uint(3)).Return(nil)
+
+	// User 2 mentions themselves, which should not notify them.
+	comment := &models.Comment{UserID: 2, Body: "@alice and @bob, please take a look"}
+	err := service.CreateComment(1, comment)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), comment.TaskID)
+	notifier.AssertNumberOfCalls(t, "NotifyMentioned", 1)
+	notifier.AssertNotCalled(t, "NotifyMentioned", task, mock.Anything, uint(2))
+}
+
+// ✅ Test Replies Join the Top-Level Thread
+func Test_CreateComment_FlattensReplies(t *testing.T) {
+	service, mockRepo, mockTasks, _, _ := commentService()
+
+	topLevel := uint(4)
+	mockTasks.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockRepo.On("GetCommentByID", uint(1), uint(5)).Return(&models.Comment{ID: 5, TaskID: 1, ParentID: &topLevel}, nil)
+	mockRepo.On("GetCommentByID", uint(1), uint(9)).Return(nil, assert.AnError)
+	mockRepo.On("CreateComment", mock.Anything).Return(nil)
+
+	parent := uint(5)
+	reply := &models.Comment{UserID: 2, Body: "Agreed", ParentID: &parent}
+	assert.Nil(t, service.CreateComment(1, reply))
+	assert.Equal(t, topLevel, *reply.ParentID, "A reply to a reply should join the top-level thread")
+
+	missing := uint(9)
+	err := service.CreateComment(1, &models.Comment{UserID: 2, Body: "Hi", ParentID: &missing})
+	assert.Equal(t, usecases.ErrCommentParent, err)
+}
+
+// ✅ Test Only the Author Edits, and Only New Mentions Are Notified
+func Test_UpdateComment(t *testing.T) {
+	service, mockRepo, mockTasks, mockUsers, notifier := commentService()
+
+	task := &models.Task{ID: 1, Title: "Review PR"}
+	comment := &models.Comment{ID: 5, TaskID: 1, UserID: 2, Body: "cc @alice"}
+	mockTasks.On("GetTaskByID", uint(1)).Return(task, nil)
+	mockRepo.On("GetCommentByID", uint(1), uint(5)).Return(comment, nil)
+
+	_, err := service.UpdateComment(1, 5, "hijacked", 3)
+	assert.Equal(t, usecases.ErrCommentForbidden, err, "Only the author can edit a comment")
+
+	_, err = service.UpdateComment(1, 5, "cc @alice", 2)
+	assert.Equal(t, usecases.ErrCommentNotChanged, err)
+
+	mockRepo.On("UpdateComment", comment, "cc @alice @carol", uint(2)).Return(nil)
+	mockUsers.On("GetUsersByUsernames", []string{"carol"}).Return(users(4), nil)
+	notifier.On("NotifyMentioned", task, comment, uint(4)).Return(nil)
+
+	updated, err := service.UpdateComment(1, 5, "cc @alice @carol", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "cc @alice @carol", updated.Body)
+	notifier.AssertNumberOfCalls(t, "NotifyMentioned", 1)
+}
+
+// ✅ Test Moderators Can Delete Others' Comments
+func Test_DeleteComment(t *testing.T) {
+	service, mockRepo, mockTasks, _, _ := commentService()
+
+	mockTasks.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockRepo.On("GetCommentByID", uint(1), uint(5)).Return(&models.Comment{ID: 5, TaskID: 1, UserID: 2}, nil)
+	mockRepo.On("DeleteComment", uint(1), uint(5)).Return(nil)
+
+	err := service.DeleteComment(1, 5, 3, false)
+	assert.Equal(t, usecases.ErrCommentForbidden, err, "Members cannot delete others' comments")
+
+	err = service.DeleteComment(1, 5, 3, true)
+	assert.Nil(t, err, "Moderators can delete any comment")
+}
+
+// ✅ Test Markdown Rendering Is Sanitized
+func Test_RenderMarkdown(t *testing.T) {
+	rendered := utils.RenderMarkdown("**Hi** @alice\n\n<script>alert(1)</script>\n\n[bad](javascript:alert(1)) [good](https://example.com)")
+
+	assert.Contains(t, rendered, "<strong>Hi</strong>")
+	assert.Contains(t, rendered, `<span class="mention">@alice</span>`)
+	assert.Contains(t, rendered, "&lt;script&gt;", "Raw HTML should be escaped")
+	assert.NotContains(t, rendered, "<script>")
+	assert.NotContains(t, rendered, "javascript:", "Unsafe links should be dropped")
+	assert.Contains(t, rendered, `<a href="https://example.com" rel="nofollow noopener">good</a>`)
+
+	code := utils.RenderMarkdown("```\n**not bold** <b>\n```")
+	assert.True(t, strings.HasPrefix(code, "<pre><code>**not bold** &lt;b&gt;"))
+}
+
+// ✅ Test Parsing Mentions
+func Test_ParseMentions(t *testing.T) {
+	mentions := utils.ParseMentions("@alice, mail bob@example.com or ping @bob. Again @alice")
+	assert.Equal(t, []string{"alice", "bob"}, mentions)
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// ✅ Test Parsing Due Dates
+func Test_ParseDueDate(t *testing.T) {
+	due, err := models.ParseDueDate("2025-01-06")
+	assert.Nil(t, err)
+	assert.False(t, due.HasTime, "Plain dates should have no time")
+	assert.Equal(t, "2025-01-06", due.String())
+
+	due, err = models.ParseDueDate("2025-01-06T09:00:00+07:00")
+	assert.Nil(t, err)
+	assert.True(t, due.HasTime)
+	assert.Equal(t, "2025-01-06T02:00:00Z", due.In(time.UTC).String(), "Timed due dates should convert between zones")
+
+	for _, invalid := range []string{"06/01/2025", "2025-02-30", "2025-01-06T09:00:00", "tomorrow"} {
+		_, err := models.ParseDueDate(invalid)
+		var dueDateErr *models.DueDateError
+		assert.True(t, errors.As(err, &dueDateErr), "'%s' should be rejected", invalid)
+	}
+}
+
+// ✅ Test Due Date JSON
+func Test_DueDateJSON(t *testing.T) {
+	var task models.Task
+	err := json.Unmarshal([]byte(`{"title": "Call", "due_date": "2025-01-06T09:00:00-05:00"}`), &task)
+	assert.Nil(t, err)
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	assert.Equal(t, "2025-01-06T21:00:00+07:00", task.DueDate.In(jakarta).String(), "Responses should render in the user's timezone")
+
+	err = json.Unmarshal([]byte(`{"title": "Call", "due_date": "next week"}`), &task)
+	var dueDateErr *models.DueDateError
+	assert.True(t, errors.As(err, &dueDateErr), "Invalid due dates should surface a clear error")
+
+	data, _ := json.Marshal(models.Task{Title: "Empty"})
+	assert.Contains(t, string(data), `"due_date":null`)
+}
+
+// ✅ Test Dates Without a Time Keep Their Day in Any Session Zone
+func Test_DueDateFromOtherZone(t *testing.T) {
+	// Midnight UTC on the 6th, as a database in New York hands it back.
+	newYork, _ := time.LoadLocation("America/New_York")
+	stored := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC).In(newYork)
+	due := models.DueDate{Time: &stored}
+
+	assert.Equal(t, "2025-01-06", due.String())
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), due.Date())
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, jakarta), due.Deadline(jakarta))
+}
@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/notifiers"
+)
+
+// ✅ Test SMTP Errors Aren't Shown to Users
+func Test_EmailChannel_HidesServerErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("554 relay.internal.example refuses service\r\n"))
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	channel := &notifiers.EmailChannel{Host: host, Port: port, From: "tasks@example.com"}
+
+	err = channel.Deliver(&models.Reminder{ID: 1, Target: "user@example.com"}, &models.Task{Title: "Pay rent"})
+	assert.EqualError(t, err, "delivery failed")
+}
@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+func exportTasks() []models.Task {
+	completedAt := time.Date(2025, 2, 27, 16, 30, 0, 0, time.UTC)
+	meeting := time.Date(2025, 3, 3, 9, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	return []models.Task{
+		{ID: 1, Title: "Pay rent; utilities", Status: "pending", StatusCategory: models.StatusCategoryOpen, Priority: models.PriorityHigh,
+			DueDate: dueDate("2025-03-01"), Labels: []models.Label{{ID: 2, Name: "finance"}, {ID: 3, Name: "home"}}},
+		{ID: 2, Title: "Plan | review", Status: "completed", StatusCategory: models.StatusCategoryDone, Priority: models.PriorityNone,
+			DueDate: models.DueDate{Time: &meeting, HasTime: true}, CompletedAt: &completedAt},
+	}
+}
+
+func writeExport(t *testing.T, format string, tasks []models.Task) string {
+	var out bytes.Buffer
+	writer := presenters.ExportFormats[format].NewWriter(&out)
+	for i := range tasks {
+		assert.Nil(t, writer.WriteTask(&tasks[i]))
+	}
+	assert.Nil(t, writer.Close())
+	return out.String()
+}
+
+// ✅ Test the iCalendar Export
+func Test_ExportICal(t *testing.T) {
+	ics := writeExport(t, "ics", exportTasks())
+
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VTODO"))
+	assert.Contains(t, ics, "SUMMARY:Pay rent\\; utilities\r\n")
+	assert.Contains(t, ics, "DUE;VALUE=DATE:20250301\r\n")
+	assert.Contains(t, ics, "STATUS:NEEDS-ACTION\r\n")
+	assert.Contains(t, ics, "PRIORITY:3\r\n")
+	assert.Contains(t, ics, "CATEGORIES:finance,home\r\n")
+	assert.Contains(t, ics, "DUE:20250303T020000Z\r\n", "Timed due dates should be in UTC")
+	assert.Contains(t, ics, "STATUS:COMPLETED\r\nCOMPLETED:20250227T163000Z\r\n")
+}
+
+// ✅ Test Long iCalendar Lines Are Folded
+func Test_ExportICal_Folding(t *testing.T) {
+	tasks := []models.Task{{ID: 1, Title: strings.Repeat("é", 60)}}
+	ics := writeExport(t, "ics", tasks)
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+strings.Repeat("é", 60)+"\r\n")
+}
+
+// ✅ Test the CSV, JSON and Markdown Exports
+func Test_ExportFormats(t *testing.T) {
+	csv := writeExport(t, "csv", exportTasks())
+	lines := strings.Split(strings.TrimSpace(csv), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "id,title,description,status,priority,due_date"))
+	assert.Contains(t, lines[1], `"finance,home"`)
+
+	var tasks []presenters.TaskResponse
+	assert.Nil(t, json.Unmarshal([]byte(writeExport(t, "json", exportTasks())), &tasks))
+	assert.Len(t, tasks, 2)
+	assert.Nil(t, json.Unmarshal([]byte(writeExport(t, "json", nil)), &tasks), "An empty export should still be valid JSON")
+	assert.Len(t, tasks, 0)
+
+	md := writeExport(t, "md", exportTasks())
+	assert.Contains(t, md, "| 2 | Plan \\| review | completed |")
+}
+
+// ✅ Test CSV Exports Can't Run Formulas and Import Back Unchanged
+func Test_ExportCSV_Formulas(t *testing.T) {
+	titles := []string{"=HYPERLINK(\"http://evil.example\")", "+1", "-call mom", "@sum", "'=already quoted", "'quoted", "Plain"}
+	tasks := make([]models.Task, len(titles))
+	for i, title := range titles {
+		tasks[i] = models.Task{ID: uint(i + 1), Title: title, Status: "pending", Priority: models.PriorityNone}
+	}
+
+	export := writeExport(t, "csv", tasks)
+	assert.Contains(t, export, `"'=HYPERLINK(""http://evil.example"")"`)
+	assert.Contains(t, export, ",'-call mom,")
+	assert.Contains(t, export, ",''=already quoted,")
+	assert.Contains(t, export, ",'quoted,", "Quotes that don't hide a formula are left alone")
+
+	rows, err := usecases.ParseImport(usecases.ImportCSV, strings.NewReader(export), nil)
+	assert.Nil(t, err)
+	for i, row := range rows {
+		assert.Equal(t, titles[i], row.Task.Title, "Titles should survive a round trip")
+	}
+}
+
+// ✅ Test the Export Endpoint Streams a Download
+func Test_ExportTasksHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockRepo := new(MockTaskRepository)
+	handler := &handlers.TaskHandler{Service: usecases.NewTaskService(mockRepo)}
+	router := gin.New()
+	router.GET("/api/tasks/export", handler.ExportTasks)
+
+	mockRepo.On("EachTask", mock.MatchedBy(func(filter models.TaskFilter) bool {
+		return filter.Priorities[0] == "high"
+	}), "due_date", mock.Anything).Return(exportTasks(), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/tasks/export?format=ics&priority=high&sort=due_date", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".ics")
+	assert.Equal(t, 2, strings.Count(w.Body.String(), "BEGIN:VTODO"))
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/tasks/export?format=xlsx", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/tasks/export?sort=owner", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), usecases.ErrInvalidSort.Error())
+}
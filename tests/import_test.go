@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// importJobs keeps the latest saved state of each import job.
+type importJobs struct {
+	mu   sync.Mutex
+	jobs map[uint]models.ImportJob
+}
+
+func (f *importJobs) CreateImportJob(job *models.ImportJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.jobs == nil {
+		f.jobs = map[uint]models.ImportJob{}
+	}
+	job.ID = uint(len(f.jobs) + 1)
+	f.jobs[job.ID] = *job
+	return nil
+}
+
+func (f *importJobs) UpdateImportJob(job *models.ImportJob) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[job.ID] = *job
+	return nil
+}
+
+func (f *importJobs) GetImportJob(id, workspaceID uint) (*models.ImportJob, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[id]
+	if !ok || job.WorkspaceID != workspaceID {
+		return nil, errors.New("record not found")
+	}
+	return &job, nil
+}
+
+func (f *importJobs) FailUnfinishedImportJobs(message string) (int64, error) {
+	return 0, nil
+}
+
+func requireTitle(task *models.Task) error {
+	if task.Title == "" {
+		return errors.New("Title is required")
+	}
+	return nil
+}
+
+// ✅ Test Reading CSV with a Column Mapping
+func Test_ParseImport_CSV(t *testing.T) {
+	file := "Task Name,Notes,Due,priority\n" +
+		"Pay rent,Monthly,2025-03-01,High\n" +
+		"Call bank,,next week,\n"
+
+	rows, err := usecases.ParseImport(usecases.ImportCSV, strings.NewReader(file), map[string]string{
+		"title":       "Task Name",
+		"description": "notes",
+		"due_date":    "Due",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, 2, rows[0].Row, "Rows should be numbered by their line in the file")
+	assert.Nil(t, rows[0].Err)
+	assert.Equal(t, "Pay rent", rows[0].Task.Title)
+	assert.Equal(t, "Monthly", rows[0].Task.Description)
+	assert.Equal(t, "high", rows[0].Task.Priority, "Unmapped columns named after a field should be read")
+	assert.Equal(t, "2025-03-01", rows[0].Task.DueDate.Date().Format("2006-01-02"))
+
+	assert.Equal(t, 3, rows[1].Row)
+	var dueDateErr *models.DueDateError
+	assert.ErrorAs(t, rows[1].Err, &dueDateErr)
+}
+
+// ✅ Test Rejecting Unusable Files and Mappings
+func Test_ParseImport_Invalid(t *testing.T) {
+	_, err := usecases.ParseImport(usecases.ImportCSV, strings.NewReader("name\nPay rent\n"), nil)
+	assert.ErrorIs(t, err, usecases.ErrInvalidImportMapping, "A title column is required")
+
+	_, err = usecases.ParseImport(usecases.ImportCSV, strings.NewReader("title\nPay rent\n"), map[string]string{"owner": "title"})
+	assert.ErrorIs(t, err, usecases.ErrInvalidImportMapping)
+
+	_, err = usecases.ParseImport(usecases.ImportCSV, strings.NewReader("title\n"), nil)
+	assert.Equal(t, usecases.ErrEmptyImport, err)
+
+	_, err = usecases.ParseImport(usecases.ImportJSON, strings.NewReader(`{"title": "Pay rent"}`), nil)
+	assert.ErrorIs(t, err, usecases.ErrInvalidImportFile)
+
+	_, err = usecases.ParseImport("xlsx", strings.NewReader(""), nil)
+	assert.Equal(t, usecases.ErrInvalidImportFormat, err)
+}
+
+// ✅ Test Reading JSON
+func Test_ParseImport_JSON(t *testing.T) {
+	file := `[
+		{"title": "Pay rent", "priority": "high", "project_id": 2, "labels": ["finance", "home"]},
+		{"title": "Call bank", "due_date": "soon"}
+	]`
+
+	rows, err := usecases.ParseImport(usecases.ImportJSON, strings.NewReader(file), nil)
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, uint(2), *rows[0].Task.ProjectID)
+	assert.Equal(t, []string{"finance", "home"}, rows[0].Labels)
+	assert.Equal(t, 2, rows[1].Row)
+	assert.Error(t, rows[1].Err)
+}
+
+// ✅ Test a Dry Run Reports Row Errors Without Creating Tasks
+func Test_Import_DryRun(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	rows := []usecases.ImportRow{
+		{Row: 2, Task: models.Task{Title: "Pay rent"}},
+		{Row: 3, Task: models.Task{Title: ""}},
+		{Row: 4, Task: models.Task{Title: "Call bank", Status: "someday"}},
+	}
+	report, job, err := service.Import(rows, usecases.ImportCSV, usecases.ImportOptions{DryRun: true, Validate: requireTitle})
+	assert.Nil(t, err)
+	assert.Nil(t, job)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 3, report.Errors[0].Row)
+	assert.Equal(t, "Invalid task fields: Title is required", report.Errors[0].Message)
+	assert.Equal(t, 4, report.Errors[1].Row)
+	mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+}
+
+// ✅ Test Importing Creates the Valid Rows
+func Test_Import_CreatesTasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	mockRepo.On("CreateTask", mock.Anything).Return(nil)
+
+	rows := []usecases.ImportRow{
+		{Row: 1, Task: models.Task{Title: "Pay rent", Priority: "high"}},
+		{Row: 2, Err: &models.DueDateError{Value: "soon"}},
+	}
+	report, _, err := service.Import(rows, usecases.ImportJSON, usecases.ImportOptions{Validate: requireTitle})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	mockRepo.AssertNumberOfCalls(t, "CreateTask", 1)
+	mockRepo.AssertCalled(t, "CreateTask", mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Pay rent" && task.Status == "pending"
+	}))
+}
+
+// ✅ Test Large Imports Run as a Background Job
+func Test_Import_BackgroundJob(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	jobs := &importJobs{}
+	service := usecases.NewTaskService(mockRepo).InWorkspace(7)
+	service.Imports = jobs
+
+	mockRepo.On("CreateTask", mock.Anything).Return(nil)
+
+	rows := make([]usecases.ImportRow, usecases.ImportBackgroundRows+1)
+	for i := range rows {
+		rows[i] = usecases.ImportRow{Row: i + 2, Task: models.Task{Title: fmt.Sprintf("Task %d", i)}}
+	}
+	rows[10].Task.Title = ""
+
+	report, job, err := service.Import(rows, usecases.ImportCSV, usecases.ImportOptions{Validate: requireTitle})
+	assert.Nil(t, err)
+	assert.Nil(t, report)
+	assert.Equal(t, models.ImportPending, job.Status)
+	assert.Equal(t, len(rows), job.Total)
+
+	assert.Eventually(t, func() bool {
+		saved, err := service.GetImportJob(job.ID)
+		return err == nil && saved.Status == models.ImportCompleted
+	}, time.Second, 10*time.Millisecond)
+
+	saved, _ := service.GetImportJob(job.ID)
+	assert.Equal(t, len(rows), saved.Processed)
+	assert.Equal(t, len(rows)-1, saved.Created)
+	assert.Equal(t, []models.ImportRowError{{Row: 12, Message: "Invalid task fields: Title is required"}}, saved.Errors)
+	assert.NotNil(t, saved.FinishedAt)
+
+	other := usecases.NewTaskService(mockRepo).InWorkspace(8)
+	other.Imports = jobs
+	_, err = other.GetImportJob(job.ID)
+	assert.Equal(t, usecases.ErrImportJobNotFound, err, "Jobs of other workspaces should not be visible")
+}
+
+// ✅ Test a Panicking Background Import Fails Its Job
+func Test_Import_BackgroundJobPanics(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo).InWorkspace(7)
+	service.Imports = &importJobs{}
+
+	mockRepo.On("CreateTask", mock.Anything).Run(func(args mock.Arguments) {
+		panic("connection reset")
+	}).Return(nil)
+
+	rows := make([]usecases.ImportRow, usecases.ImportBackgroundRows+1)
+	for i := range rows {
+		rows[i] = usecases.ImportRow{Row: i + 2, Task: models.Task{Title: fmt.Sprintf("Task %d", i)}}
+	}
+
+	_, job, err := service.Import(rows, usecases.ImportCSV, usecases.ImportOptions{})
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		saved, err := service.GetImportJob(job.ID)
+		return err == nil && saved.Status == models.ImportFailed
+	}, time.Second, 10*time.Millisecond)
+
+	saved, _ := service.GetImportJob(job.ID)
+	assert.NotEmpty(t, saved.Error)
+	assert.NotNil(t, saved.FinishedAt)
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// Mock repository
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) InWorkspace(workspaceID uint) repositories.LabelRepository {
+	return m
+}
+
+func (m *MockLabelRepository) InTransaction(tasks repositories.TaskRepository) repositories.LabelRepository {
+	return m
+}
+
+func (m *MockLabelRepository) GetLabels() ([]models.Label, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetLabelsByIDs(ids []uint) ([]models.Label, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetLabelByName(name string) (*models.Label, error) {
+	args := m.Called(name)
+	return args.Get(0).(*models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) CreateLabel(label *models.Label) error {
+	args := m.Called(label)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) GetLabelByID(id uint) (*models.Label, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) UpdateLabel(id uint, updatedLabel *models.Label) error {
+	args := m.Called(id, updatedLabel)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) DeleteLabel(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) CountUsage() (map[uint]int, error) {
+	args := m.Called()
+	return args.Get(0).(map[uint]int), args.Error(1)
+}
+
+// ✅ Test Attach Labels
+func Test_AddLabels(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockLabels := new(MockLabelRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Labels = mockLabels
+
+	labelIDs := []uint{1, 2}
+	mockLabels.On("GetLabelsByIDs", labelIDs).Return([]models.Label{{ID: 1}, {ID: 2}}, nil)
+	mockRepo.On("AddLabels", uint(5), labelIDs).Return(nil)
+
+	err := service.AddLabels(5, labelIDs)
+	assert.Nil(t, err, "Expected no error when attaching labels")
+	mockRepo.AssertExpectations(t)
+}
+
+// ✅ Test Error Handling
+func Test_AddLabels_UnknownLabel(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockLabels := new(MockLabelRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Labels = mockLabels
+
+	labelIDs := []uint{1, 42}
+	mockLabels.On("GetLabelsByIDs", labelIDs).Return([]models.Label{{ID: 1}}, nil)
+
+	err := service.AddLabels(5, labelIDs)
+	assert.Equal(t, usecases.ErrLabelNotFound, err, "Unknown label should be rejected")
+	mockRepo.AssertNotCalled(t, "AddLabels", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Duplicate Label Name
+func Test_CreateLabel_Duplicate(t *testing.T) {
+	mockLabels := new(MockLabelRepository)
+	service := usecases.NewLabelService(mockLabels)
+
+	mockLabels.On("GetLabelByName", "finance").Return(&models.Label{ID: 1, Name: "Finance"}, nil)
+	mockLabels.On("GetLabelByName", "home").Return((*models.Label)(nil), errors.New("record not found"))
+	mockLabels.On("CreateLabel", mock.Anything).Return(nil)
+
+	assert.Equal(t, usecases.ErrLabelExists, service.CreateLabel(&models.Label{Name: "finance"}))
+	assert.Nil(t, service.CreateLabel(&models.Label{Name: "home"}))
+	mockLabels.AssertNumberOfCalls(t, "CreateLabel", 1)
+}
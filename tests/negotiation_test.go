@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ugorji/go/codec"
+	"github.com/yasseryazid/technical-test/handlers"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/presenters"
+	"github.com/yasseryazid/technical-test/usecases"
+	"gopkg.in/yaml.v3"
+)
+
+func negotiatedType(t *testing.T, accept string, body any) string {
+	presenter, ok := presenters.Negotiate(accept, body)
+	if !ok {
+		return ""
+	}
+	return presenter.ContentType
+}
+
+// ✅ Test Choosing a Format from the Accept Header
+func Test_Negotiate(t *testing.T) {
+	task := presenters.TaskResponse{ID: "1"}
+
+	assert.Equal(t, "application/json; charset=utf-8", negotiatedType(t, "", task), "No Accept header should mean JSON")
+	assert.Equal(t, "application/json; charset=utf-8", negotiatedType(t, "*/*", task))
+	assert.Equal(t, "application/yaml; charset=utf-8", negotiatedType(t, "text/html, application/x-yaml", task))
+	assert.Equal(t, "text/csv; charset=utf-8", negotiatedType(t, "application/json;q=0.5, text/csv", task))
+	assert.Equal(t, "application/msgpack", negotiatedType(t, "application/msgpack, */*;q=0.1", task))
+	assert.Equal(t, "application/json; charset=utf-8", negotiatedType(t, "application/*", task), "Ties should go to the first registered type")
+	assert.Equal(t, "text/csv; charset=utf-8", negotiatedType(t, "*/*, application/json;q=0", task), "q=0 should exclude a type")
+	assert.Equal(t, "", negotiatedType(t, "text/html, image/*", task))
+
+	message := map[string]any{"message": "Task created successfully"}
+	assert.Equal(t, "application/json; charset=utf-8", negotiatedType(t, "text/csv, */*;q=0.1", message), "CSV should only present tasks")
+	assert.Equal(t, "", negotiatedType(t, "text/csv", message))
+	assert.Equal(t, "", negotiatedType(t, "text/csv", nil), "CSV can't show a body that isn't declared as tasks")
+	assert.Equal(t, "text/csv; charset=utf-8", negotiatedType(t, "text/csv", presenters.TrashedTaskListResponse{}))
+}
+
+// ✅ Test Registering a New Format
+func Test_RegisterPresenter(t *testing.T) {
+	assert.Equal(t, "", negotiatedType(t, "text/plain", nil))
+	assert.Panics(t, func() {
+		presenters.RegisterPresenter("text/plain", presenters.Presenter{ContentType: "text/plain; charset=utf-8"})
+	}, "A presenter without a Present func should be rejected")
+	assert.NotContains(t, presenters.MediaTypes(), "text/plain")
+
+	presenters.RegisterPresenter("text/plain", presenters.Presenter{
+		ContentType: "text/plain; charset=utf-8",
+		Present: func(w io.Writer, body any) error {
+			_, err := fmt.Fprint(w, body)
+			return err
+		},
+	})
+	defer presenters.UnregisterPresenter("text/plain")
+	assert.Equal(t, "text/plain; charset=utf-8", negotiatedType(t, "text/plain", nil))
+	assert.Contains(t, presenters.MediaTypes(), "text/plain")
+
+	presenters.UnregisterPresenter("text/plain")
+	assert.Equal(t, "", negotiatedType(t, "text/plain", nil))
+	assert.NotContains(t, presenters.MediaTypes(), "text/plain")
+}
+
+func negotiationRouter(mockRepo *MockTaskRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := &handlers.TaskHandler{Service: usecases.NewTaskService(mockRepo)}
+	router.GET("/tasks/:id", middlewares.NegotiationMiddleware(presenters.TaskListResponse{}), handler.GetTaskByID)
+	router.POST("/tasks", middlewares.NegotiationMiddleware(nil), handler.CreateTask)
+	return router
+}
+
+// ✅ Test Task Responses in Every Format
+func Test_GetTaskByID_Negotiated(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockRepo.On("GetTaskByID", uint(7)).Return(&models.Task{ID: 7, Title: "Pay rent: March", Status: "pending", Priority: models.PriorityHigh,
+		Labels: []models.Label{{ID: 2, Name: "finance"}}}, nil)
+	router := negotiationRouter(mockRepo)
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/tasks/7", nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("application/json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var fromJSON map[string]any
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &fromJSON))
+	assert.Equal(t, "7", fromJSON["id"])
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	w = get("application/yaml")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "id: \"7\"\n", "IDs should stay strings")
+	var fromYAML map[string]any
+	assert.Nil(t, yaml.Unmarshal(w.Body.Bytes(), &fromYAML))
+	assert.Equal(t, "Pay rent: March", fromYAML["title"])
+	assert.Equal(t, "finance", fromYAML["labels"].([]any)[0].(map[string]any)["name"])
+
+	w = get("application/msgpack")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+	var fromMsgpack map[string]any
+	handle := codec.MsgpackHandle{}
+	handle.RawToString = true
+	assert.Nil(t, codec.NewDecoderBytes(w.Body.Bytes(), &handle).Decode(&fromMsgpack))
+	assert.Equal(t, "7", fromMsgpack["id"])
+	assert.Equal(t, "Pay rent: March", fromMsgpack["title"], "MessagePack should use the JSON field names")
+
+	w = get("text/csv")
+	assert.Equal(t, http.StatusOK, w.Code)
+	rows, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, []string{"7", "Pay rent: March"}, rows[1][:2])
+
+	w = get("text/html")
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Contains(t, w.Body.String(), "application/msgpack")
+}
+
+// ✅ Test Writes Refuse Formats That Can't Show Their Response
+func Test_CreateTask_NotAcceptable(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	router := negotiationRouter(mockRepo)
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title": "Pay rent"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// ✅ Test Task Scoring
+func Test_ScoreTask(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	urgent := &models.Task{Priority: models.PriorityUrgent, CreatedAt: now}
+	low := &models.Task{Priority: models.PriorityLow, CreatedAt: now}
+	overdue := &models.Task{Priority: models.PriorityLow, DueDate: dueDate("2025-03-05"), CreatedAt: now}
+	dueLater := &models.Task{Priority: models.PriorityLow, DueDate: dueDate("2025-03-30"), CreatedAt: now}
+	old := &models.Task{Priority: models.PriorityLow, CreatedAt: now.AddDate(0, 0, -20)}
+
+	assert.Greater(t, usecases.ScoreTask(urgent, now), usecases.ScoreTask(low, now), "Higher priority should score higher")
+	assert.Greater(t, usecases.ScoreTask(overdue, now), usecases.ScoreTask(dueLater, now), "Overdue tasks should score higher")
+	assert.Greater(t, usecases.ScoreTask(old, now), usecases.ScoreTask(low, now), "Older tasks should score higher")
+}
+
+// ✅ Test Next Tasks
+func Test_NextTasks(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	tasks := []models.Task{
+		{ID: 1, Title: "Someday", Priority: models.PriorityNone},
+		{ID: 2, Title: "Fire", Priority: models.PriorityUrgent},
+		{ID: 3, Title: "Soon", Priority: models.PriorityHigh},
+	}
+	mockRepo.On("FindTasks", mock.MatchedBy(func(filter models.TaskFilter) bool {
+		return filter.StatusCategory == models.StatusCategoryOpen
+	}), repositories.SortUrgency, 1, 500).Return(tasks, len(tasks), nil)
+
+	result, err := service.NextTasks(models.TaskFilter{}, 2)
+	assert.Nil(t, err, "Expected no error")
+	assert.Len(t, result, 2, "Result should be limited")
+	assert.Equal(t, uint(2), result[0].Task.ID, "Urgent task should come first")
+	assert.Equal(t, uint(3), result[1].Task.ID, "High priority task should come second")
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/yasseryazid/technical-test/middlewares"
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// permissionRouter serves GET /check as a user with the given workspace role
+// and token permissions.
+func permissionRouter(role string, tokenPermissions []string, required ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/check", func(c *gin.Context) {
+		c.Set("workspace_role", role)
+		c.Set("permissions", tokenPermissions)
+	}, middlewares.RequirePermission(required...), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	return router
+}
+
+// ✅ Test Role Permissions
+func Test_RequirePermission_Roles(t *testing.T) {
+	cases := []struct {
+		role       string
+		permission string
+		status     int
+	}{
+		{models.RoleViewer, models.PermTaskRead, http.StatusOK},
+		{models.RoleViewer, models.PermTaskWrite, http.StatusForbidden},
+		{models.RoleMember, models.PermTaskWrite, http.StatusOK},
+		{models.RoleMember, models.PermTaskDelete, http.StatusForbidden},
+		{models.RoleAdmin, models.PermTaskDelete, http.StatusOK},
+		{models.RoleOwner, models.PermUserAdmin, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/check", nil)
+		permissionRouter(tc.role, nil, tc.permission).ServeHTTP(w, req)
+		assert.Equal(t, tc.status, w.Code, "%s asking for %s", tc.role, tc.permission)
+	}
+}
+
+// ✅ Test Missing Permission Is Named
+func Test_RequirePermission_NamesMissingPermission(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/check", nil)
+	permissionRouter(models.RoleMember, nil, models.PermTaskRead, models.PermTaskDelete).ServeHTTP(w, req)
+
+	var response map[string]string
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, models.PermTaskDelete, response["missing_permission"])
+	assert.Equal(t, "Missing permission 'task:delete'", response["error"])
+}
+
+// ✅ Test Token Permissions
+func Test_RequirePermission_Token(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/check", nil)
+	permissionRouter("", []string{models.PermUserAdmin}, models.PermUserAdmin).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "Permissions from the token should count outside workspaces")
+}
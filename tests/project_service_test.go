@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// Mock repository
+type MockProjectRepository struct {
+	mock.Mock
+	WorkspaceID uint
+}
+
+func (m *MockProjectRepository) InWorkspace(workspaceID uint) repositories.ProjectRepository {
+	m.WorkspaceID = workspaceID
+	return m
+}
+
+func (m *MockProjectRepository) GetProjects(includeArchived bool) ([]models.Project, error) {
+	args := m.Called(includeArchived)
+	return args.Get(0).([]models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) CreateProject(project *models.Project) error {
+	args := m.Called(project)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) GetProjectByID(id uint) (*models.Project, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) UpdateProject(id uint, updatedProject *models.Project) error {
+	args := m.Called(id, updatedProject)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) DeleteProject(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) CountTasksByStatus(projectIDs []uint) (map[uint]map[string]int, error) {
+	args := m.Called(projectIDs)
+	return args.Get(0).(map[uint]map[string]int), args.Error(1)
+}
+
+// ✅ Test Move Task
+func Test_MoveTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockProjects := new(MockProjectRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Projects = mockProjects
+
+	projectID := uint(2)
+	mockProjects.On("GetProjectByID", projectID).Return(&models.Project{ID: projectID, Name: "Home"}, nil)
+	mockRepo.On("MoveTask", uint(1), &projectID).Return(nil)
+
+	err := service.MoveTask(1, &projectID)
+	assert.Nil(t, err, "Expected no error when moving task")
+	mockRepo.AssertExpectations(t)
+}
+
+// ✅ Test Error Handling
+func Test_MoveTask_ArchivedProject(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockProjects := new(MockProjectRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Projects = mockProjects
+
+	projectID := uint(3)
+	mockProjects.On("GetProjectByID", projectID).Return(&models.Project{ID: projectID, Archived: true}, nil)
+
+	err := service.MoveTask(1, &projectID)
+	assert.Equal(t, usecases.ErrProjectArchived, err, "Moving into an archived project should fail")
+	mockRepo.AssertNotCalled(t, "MoveTask", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Projects of Other Workspaces Can't Be Used
+func Test_ProjectOfOtherWorkspace(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockProjects := new(MockProjectRepository)
+	tasks := usecases.NewTaskService(mockRepo)
+	tasks.Projects = mockProjects
+	service := usecases.NewProjectService(mockProjects, tasks).InWorkspace(7)
+
+	// The scoped repository doesn't see project 4, which is in another workspace.
+	projectID := uint(4)
+	mockProjects.On("GetProjectByID", projectID).Return((*models.Project)(nil), errors.New("record not found"))
+
+	err := service.CreateProjectTask(projectID, &models.Task{Title: "Leak"})
+	assert.Equal(t, usecases.ErrProjectNotFound, err, "Creating a task in another workspace's project should fail")
+	assert.Equal(t, uint(7), mockProjects.WorkspaceID, "Projects should be looked up in the request's workspace")
+
+	err = tasks.InWorkspace(7).MoveTask(1, &projectID)
+	assert.Equal(t, usecases.ErrProjectNotFound, err, "Moving a task into another workspace's project should fail")
+	mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+	mockRepo.AssertNotCalled(t, "MoveTask", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Project Task Counts
+func Test_ProjectTaskCounts(t *testing.T) {
+	mockProjects := new(MockProjectRepository)
+	service := usecases.NewProjectService(mockProjects, nil)
+
+	projects := []models.Project{{ID: 1}, {ID: 2}}
+	counts := map[uint]map[string]int{1: {"pending": 2, "completed": 1}, 2: {}}
+	mockProjects.On("CountTasksByStatus", []uint{1, 2}).Return(counts, nil)
+
+	result, err := service.TaskCounts(projects)
+	assert.Nil(t, err, "Expected no error")
+	assert.Equal(t, 2, result[1]["pending"], "Pending count should match")
+	mockProjects.AssertExpectations(t)
+
+	mockProjects.On("GetProjectByID", uint(9)).Return((*models.Project)(nil), errors.New("record not found"))
+	_, err = service.GetProjectByID(9)
+	assert.NotNil(t, err, "Error should not be nil when project is not found")
+}
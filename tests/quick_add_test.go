@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+	"gorm.io/gorm"
+)
+
+// ✅ Test Parsing Quick Add Text
+func Test_ParseQuickAdd(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	// Wednesday afternoon.
+	now := time.Date(2025, 1, 8, 14, 0, 0, 0, jakarta)
+
+	cases := []struct {
+		text       string
+		title      string
+		due        string
+		recurrence string
+		labels     []string
+		priority   string
+	}{
+		{"Pay rent every 1st of month #finance !high", "Pay rent", "2025-02-01", "FREQ=MONTHLY;BYMONTHDAY=1", []string{"finance"}, "high"},
+		{"Call mom tomorrow 5pm", "Call mom", "2025-01-09T17:00:00+07:00", "", nil, ""},
+		{"Submit report next friday", "Submit report", "2025-01-17", "", nil, ""},
+		{"Standup every mon and thu at 9:30am #work", "Standup", "2025-01-09T09:30:00+07:00", "FREQ=WEEKLY;BYDAY=MO,TH", []string{"work"}, ""},
+		{"Water plants every 2 days", "Water plants", "2025-01-08", "FREQ=DAILY;INTERVAL=2", nil, ""},
+		{"Buy sun cream on friday", "Buy sun cream", "2025-01-10", "", nil, ""},
+		{"Dentist jan 20th at 10am !urgent", "Dentist", "2025-01-20T10:00:00+07:00", "", nil, "urgent"},
+		{"Renew passport 2025-03-01", "Renew passport", "2025-03-01", "", nil, ""},
+		{"Read 10 pages at home", "Read 10 pages at home", "", "", nil, ""},
+		{"Take out trash 1pm", "Take out trash", "2025-01-09T13:00:00+07:00", "", nil, ""},
+	}
+
+	for _, tc := range cases {
+		parsed, err := usecases.ParseQuickAdd(tc.text, now)
+		assert.Nil(t, err, tc.text)
+		assert.Equal(t, tc.title, parsed.Title, tc.text)
+		assert.Equal(t, tc.due, parsed.DueDate.String(), tc.text)
+		assert.Equal(t, tc.recurrence, parsed.Recurrence, tc.text)
+		assert.Equal(t, tc.labels, parsed.Labels, tc.text)
+		assert.Equal(t, tc.priority, parsed.Priority, tc.text)
+	}
+
+	_, err := usecases.ParseQuickAdd("#finance !high tomorrow", now)
+	assert.Equal(t, usecases.ErrQuickAddNoTitle, err, "Text without a title should be rejected")
+}
+
+// ✅ Test Quick Add Creates Missing Labels
+func Test_QuickAddTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockLabels := new(MockLabelRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Labels = mockLabels
+
+	mockLabels.On("GetLabelByName", "finance").Return(&models.Label{ID: 3, Name: "Finance"}, nil)
+	mockLabels.On("GetLabelByName", "home").Return((*models.Label)(nil), gorm.ErrRecordNotFound)
+	mockLabels.On("CreateLabel", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Label).ID = 4
+	}).Return(nil)
+	mockRepo.On("CreateTask", mock.Anything).Return(nil)
+	mockRepo.On("AddLabels", uint(0), []uint{3, 4}).Return(nil)
+	mockRepo.On("GetTaskByID", uint(0)).Return(&models.Task{Title: "Pay rent"}, nil)
+
+	parsed := &usecases.QuickAdd{Title: "Pay rent", Labels: []string{"finance", "home"}, Priority: models.PriorityHigh}
+	task, err := service.QuickAddTask(parsed, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Pay rent", task.Title)
+	mockLabels.AssertNumberOfCalls(t, "CreateLabel", 1)
+	mockRepo.AssertCalled(t, "CreateTask", mock.MatchedBy(func(created *models.Task) bool {
+		return created.Priority == models.PriorityHigh
+	}))
+}
+
+// ✅ Test Quick Add Doesn't Create Labels When the Lookup Fails
+func Test_QuickAddTask_LabelLookupFails(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockLabels := new(MockLabelRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Labels = mockLabels
+
+	mockLabels.On("GetLabelByName", "home").Return((*models.Label)(nil), assert.AnError)
+
+	_, err := service.QuickAddTask(&usecases.QuickAdd{Title: "Water plants", Labels: []string{"home"}}, nil)
+	assert.Equal(t, assert.AnError, err, "Lookup errors should be returned")
+	mockLabels.AssertNotCalled(t, "CreateLabel", mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateTask", mock.Anything)
+}
@@ -0,0 +1,222 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) CreateReminder(reminder *models.Reminder) error {
+	args := m.Called(reminder)
+	reminder.ID = 1
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) GetReminderByID(id uint) (*models.Reminder, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) GetReminders(taskID uint) ([]models.Reminder, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) GetPendingOffsetReminders(taskID uint) ([]models.Reminder, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) UpdateReminder(reminder *models.Reminder) error {
+	args := m.Called(reminder)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) ClaimReminder(id uint, now time.Time, lease time.Duration) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockReminderRepository) DeleteReminder(taskID, id uint) error {
+	args := m.Called(taskID, id)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) CreateNotification(notification *models.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error) {
+	args := m.Called(userID, unreadOnly)
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockReminderRepository) MarkNotificationRead(userID, id uint) error {
+	args := m.Called(userID, id)
+	return args.Error(0)
+}
+
+type MockReminderQueue struct {
+	mock.Mock
+}
+
+func (m *MockReminderQueue) Schedule(id uint, at time.Time) error {
+	args := m.Called(id, at)
+	return args.Error(0)
+}
+
+func (m *MockReminderQueue) Remove(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReminderQueue) ClaimDue(now time.Time, limit int, lease time.Duration) ([]uint, error) {
+	args := m.Called(now, limit, lease)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockReminderQueue) Ack(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+type MockReminderChannel struct {
+	mock.Mock
+}
+
+func (m *MockReminderChannel) Deliver(reminder *models.Reminder, task *models.Task) error {
+	args := m.Called(reminder, task)
+	return args.Error(0)
+}
+
+// ✅ Test Creating a Reminder Offset From the Due Date
+func Test_CreateReminder_Offset(t *testing.T) {
+	mockTasks := new(MockTaskRepository)
+	mockRepo := new(MockReminderRepository)
+	mockQueue := new(MockReminderQueue)
+	service := usecases.NewReminderService(mockRepo, mockQueue, mockTasks)
+	service.RegisterChannel(models.ChannelInApp, new(MockReminderChannel))
+
+	due := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	mockTasks.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1, DueDate: dueDate(due)}, nil)
+	mockRepo.On("CreateReminder", mock.Anything).Return(nil)
+	mockQueue.On("Schedule", uint(1), mock.Anything).Return(nil)
+
+	offset := 60
+	reminder := &models.Reminder{OffsetMinutes: &offset, Channel: models.ChannelInApp}
+	err := service.CreateReminder(1, reminder)
+
+	assert.Nil(t, err, "Offset reminders should be created")
+	dueDate, _ := time.Parse("2006-01-02", due)
+	assert.Equal(t, dueDate.Add(-time.Hour), reminder.FireAt, "Reminder should fire an hour before the due date")
+	mockQueue.AssertCalled(t, "Schedule", uint(1), reminder.FireAt)
+
+	err = service.CreateReminder(1, &models.Reminder{OffsetMinutes: &offset, Channel: models.ChannelEmail})
+	assert.Equal(t, usecases.ErrUnknownChannel, err, "Unregistered channels should be rejected")
+
+	past := time.Now().Add(-time.Hour)
+	err = service.CreateReminder(1, &models.Reminder{RemindAt: &past, Channel: models.ChannelInApp})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidReminder), "Past reminders should be rejected")
+}
+
+// ✅ Test Offsets From a Date Count in the User's Timezone
+func Test_CreateReminder_OffsetInUserTimezone(t *testing.T) {
+	mockTasks := new(MockTaskRepository)
+	mockRepo := new(MockReminderRepository)
+	mockQueue := new(MockReminderQueue)
+	mockUsers := new(MockUserRepository)
+	service := usecases.NewReminderService(mockRepo, mockQueue, mockTasks)
+	service.Users = mockUsers
+	service.RegisterChannel(models.ChannelInApp, new(MockReminderChannel))
+
+	due := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	mockTasks.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1, DueDate: dueDate(due)}, nil)
+	mockUsers.On("GetUserByID", uint(5)).Return(&models.User{Timezone: "Asia/Jakarta"}, nil)
+	mockRepo.On("CreateReminder", mock.Anything).Return(nil)
+	mockQueue.On("Schedule", uint(1), mock.Anything).Return(nil)
+
+	offset := 60
+	reminder := &models.Reminder{UserID: 5, OffsetMinutes: &offset, Channel: models.ChannelInApp}
+	assert.Nil(t, service.CreateReminder(1, reminder))
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	midnight, _ := time.ParseInLocation("2006-01-02", due, jakarta)
+	assert.True(t, midnight.Add(-time.Hour).Equal(reminder.FireAt), "Reminder should fire an hour before midnight in Jakarta")
+}
+
+// ✅ Test Firing Due Reminders
+func Test_ProcessDueReminders(t *testing.T) {
+	mockTasks := new(MockTaskRepository)
+	mockRepo := new(MockReminderRepository)
+	mockQueue := new(MockReminderQueue)
+	channel := new(MockReminderChannel)
+	service := usecases.NewReminderService(mockRepo, mockQueue, mockTasks)
+	service.RegisterChannel(models.ChannelWebhook, channel)
+
+	now := time.Now()
+	task := &models.Task{ID: 1, Title: "Pay rent", StatusCategory: models.StatusCategoryOpen}
+	sent := &models.Reminder{ID: 1, TaskID: 1, Channel: models.ChannelWebhook, Status: models.ReminderPending}
+	failing := &models.Reminder{ID: 2, TaskID: 1, Channel: models.ChannelWebhook, Status: models.ReminderPending}
+	done := &models.Reminder{ID: 3, TaskID: 1, Channel: models.ChannelWebhook, Status: models.ReminderSent}
+
+	mockQueue.On("ClaimDue", now, mock.Anything, mock.Anything).Return([]uint{1, 2, 3}, nil)
+	mockRepo.On("ClaimReminder", uint(1)).Return(true, nil)
+	mockRepo.On("ClaimReminder", uint(2)).Return(true, nil)
+	mockRepo.On("ClaimReminder", uint(3)).Return(false, nil)
+	mockRepo.On("GetReminderByID", uint(1)).Return(sent, nil)
+	mockRepo.On("GetReminderByID", uint(2)).Return(failing, nil)
+	mockRepo.On("GetReminderByID", uint(3)).Return(done, nil)
+	mockTasks.On("GetTaskByID", uint(1)).Return(task, nil)
+	channel.On("Deliver", sent, task).Return(nil)
+	channel.On("Deliver", failing, task).Return(errors.New("connection refused"))
+	mockRepo.On("UpdateReminder", mock.Anything).Return(nil)
+	mockQueue.On("Schedule", uint(2), now.Add(time.Minute)).Return(nil)
+	mockQueue.On("Ack", mock.Anything).Return(nil)
+
+	count, err := service.ProcessDue(now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count, "Only the successful delivery should count")
+	assert.Equal(t, models.ReminderSent, sent.Status)
+	assert.Equal(t, models.ReminderPending, failing.Status, "Failed deliveries should be retried")
+	assert.Equal(t, 1, failing.Attempts)
+	channel.AssertNumberOfCalls(t, "Deliver", 2)
+	mockQueue.AssertNumberOfCalls(t, "Ack", 3)
+}
+
+// ✅ Test Reminders Held by Another Worker Aren't Sent Twice
+func Test_ProcessDueReminders_ClaimedElsewhere(t *testing.T) {
+	mockTasks := new(MockTaskRepository)
+	mockRepo := new(MockReminderRepository)
+	mockQueue := new(MockReminderQueue)
+	channel := new(MockReminderChannel)
+	service := usecases.NewReminderService(mockRepo, mockQueue, mockTasks)
+	service.RegisterChannel(models.ChannelWebhook, channel)
+
+	// The queue lease ran out while another worker was still delivering it.
+	now := time.Now()
+	pending := &models.Reminder{ID: 4, TaskID: 1, Channel: models.ChannelWebhook, Status: models.ReminderPending}
+	mockQueue.On("ClaimDue", now, mock.Anything, mock.Anything).Return([]uint{4}, nil)
+	mockRepo.On("ClaimReminder", uint(4)).Return(false, nil)
+	mockRepo.On("GetReminderByID", uint(4)).Return(pending, nil)
+
+	count, err := service.ProcessDue(now)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	channel.AssertNotCalled(t, "Deliver", mock.Anything, mock.Anything)
+	mockQueue.AssertNotCalled(t, "Ack", mock.Anything)
+}
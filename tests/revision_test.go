@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type MockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockRevisionRepository) CreateRevision(revision *models.TaskRevision) error {
+	args := m.Called(revision)
+	return args.Error(0)
+}
+
+func (m *MockRevisionRepository) GetRevisions(taskID uint) ([]models.TaskRevision, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.TaskRevision), args.Error(1)
+}
+
+func (m *MockRevisionRepository) GetRevision(taskID uint, number int) (*models.TaskRevision, error) {
+	args := m.Called(taskID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TaskRevision), args.Error(1)
+}
+
+// created returns the revisions passed to CreateRevision, in order.
+func (m *MockRevisionRepository) created() []*models.TaskRevision {
+	var revisions []*models.TaskRevision
+	for _, call := range m.Calls {
+		if call.Method == "CreateRevision" {
+			revisions = append(revisions, call.Arguments.Get(0).(*models.TaskRevision))
+		}
+	}
+	return revisions
+}
+
+// ✅ Test Diffs Between Snapshots Include Labels
+func Test_DiffSnapshots(t *testing.T) {
+	from := models.TaskSnapshot{Title: "Draft", Status: "pending", Priority: "none", LabelIDs: []uint{1, 2}}
+	to := models.TaskSnapshot{Title: "Final", Status: "pending", Priority: "none", LabelIDs: []uint{2}}
+
+	assert.Equal(t, []models.FieldChange{
+		{Field: "title", From: "Draft", To: "Final"},
+		{Field: "label_ids", From: []uint{1, 2}, To: []uint{2}},
+	}, usecases.DiffSnapshots(from, to))
+	assert.Empty(t, usecases.DiffSnapshots(from, from))
+}
+
+// ✅ Test Changes Produce a Revision and Assignments Don't
+func Test_UpdateTask_RecordsRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	revisions := new(MockRevisionRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Revisions = revisions
+
+	current := &models.Task{ID: 3, Title: "Write report", Status: "pending", StatusCategory: models.StatusCategoryOpen, Priority: "none",
+		Labels: []models.Label{{ID: 7}, {ID: 2}}}
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	mockRepo.On("UpdateTask", uint(3), mock.Anything).Return(nil)
+	mockRepo.On("RemoveAssignee", uint(3), uint(4)).Return(nil)
+	revisions.On("CreateRevision", mock.Anything).Return(nil)
+
+	err := service.UpdateTaskAs(3, &models.Task{Title: "Write the report"}, usecases.ChangeContext{UserID: 9, RequestID: "req-1"})
+	assert.Nil(t, err)
+	assert.Nil(t, service.UnassignTask(3, 4))
+
+	created := revisions.created()
+	assert.Len(t, created, 1, "Unassigning should not produce a revision")
+	assert.Equal(t, models.ActivityUpdated, created[0].Action)
+	assert.Equal(t, uint(9), *created[0].UserID)
+	assert.Equal(t, "req-1", created[0].RequestID)
+	assert.Equal(t, []uint{2, 7}, created[0].Snapshot.LabelIDs, "Snapshots should list label IDs in order")
+}
+
+// ✅ Test Restoring a Revision
+func Test_RestoreRevision(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	revisions := new(MockRevisionRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Revisions = revisions
+	service.Activity = activity
+
+	projectID := uint(5)
+	current := &models.Task{ID: 3, Title: "Final", Status: "completed", StatusCategory: models.StatusCategoryDone, Priority: "high",
+		ProjectID: &projectID, Labels: []models.Label{{ID: 1}, {ID: 2}}}
+	old := &models.TaskRevision{TaskID: 3, Revision: 2, Action: models.ActivityUpdated, Snapshot: models.TaskSnapshot{
+		Title: "Draft", Status: "pending", Priority: "none", LabelIDs: []uint{2, 3},
+	}}
+
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	revisions.On("GetRevision", uint(3), 2).Return(old, nil)
+	mockRepo.On("MoveTask", uint(3), (*uint)(nil)).Return(nil)
+	mockRepo.On("RemoveLabel", uint(3), uint(1)).Return(nil)
+	mockRepo.On("AddLabels", uint(3), []uint{3}).Return(nil)
+	mockRepo.On("UpdateTask", uint(3), mock.MatchedBy(func(task *models.Task) bool {
+		return task.Title == "Draft" && task.Status == "pending" && task.Priority == "none"
+	})).Return(nil)
+	revisions.On("CreateRevision", mock.Anything).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	_, err := service.RestoreRevision(3, 2, usecases.ChangeContext{UserID: 9})
+	assert.Nil(t, err)
+	mockRepo.AssertExpectations(t)
+
+	created := revisions.created()
+	assert.Len(t, created, 1, "A restore should produce exactly one revision")
+	assert.Equal(t, models.ActivityRestored, created[0].Action)
+	recorded := activity.recorded()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, models.ActivityRestored, recorded[0].Action)
+}
+
+// ✅ Test a Failed Restore Records Nothing
+func Test_RestoreRevision_StepFails(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	revisions := new(MockRevisionRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Revisions = revisions
+	service.Activity = activity
+
+	current := &models.Task{ID: 3, Title: "Final", Status: "pending", Labels: []models.Label{{ID: 1}}}
+	old := &models.TaskRevision{TaskID: 3, Revision: 2, Snapshot: models.TaskSnapshot{Title: "Draft", Status: "pending"}}
+
+	mockRepo.On("GetTaskByID", uint(3)).Return(current, nil)
+	revisions.On("GetRevision", uint(3), 2).Return(old, nil)
+	mockRepo.On("RemoveLabel", uint(3), uint(1)).Return(assert.AnError)
+
+	_, err := service.RestoreRevision(3, 2, usecases.ChangeContext{})
+	assert.Equal(t, assert.AnError, err)
+	mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	assert.Empty(t, revisions.created())
+	assert.Empty(t, activity.recorded())
+}
+
+// ✅ Test Restoring a Missing Revision
+func Test_RestoreRevision_NotFound(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	revisions := new(MockRevisionRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Revisions = revisions
+
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, Title: "Task", Status: "pending"}, nil)
+	revisions.On("GetRevision", uint(3), 9).Return(nil, assert.AnError)
+
+	_, err := service.RestoreRevision(3, 9, usecases.ChangeContext{})
+	assert.Equal(t, usecases.ErrRevisionNotFound, err)
+	mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsers() ([]models.User, error) {
+	args := m.Called()
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByIDs(ids []uint) ([]models.User, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUsersByUsernames(usernames []string) ([]models.User, error) {
+	args := m.Called(usernames)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTimezone(id uint, timezone string) error {
+	args := m.Called(id, timezone)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUserByCalendarToken(tokenHash string) (*models.User, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetCalendarToken(id uint, tokenHash *string) error {
+	args := m.Called(id, tokenHash)
+	return args.Error(0)
+}
+
+type MockAssignmentNotifier struct {
+	mock.Mock
+}
+
+func (m *MockAssignmentNotifier) NotifyAssigned(task *models.Task, userID, assignedBy uint) error {
+	args := m.Called(task, userID, assignedBy)
+	return args.Error(0)
+}
+
+func users(ids ...uint) []models.User {
+	result := make([]models.User, len(ids))
+	for i, id := range ids {
+		result[i].ID = id
+	}
+	return result
+}
+
+// ✅ Test Assigning Users Notifies Only New Assignees
+func Test_AssignTask_NotifiesNewAssignees(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	notifier := new(MockAssignmentNotifier)
+	service := usecases.NewTaskService(mockRepo)
+	service.Users = mockUsers
+	service.Assignments = notifier
+
+	task := &models.Task{ID: 1, Title: "Review PR"}
+	mockUsers.On("GetUsersByIDs", []uint{1, 2, 3}).Return(users(1, 2, 3), nil)
+	// User 3 was already assigned, so only 1 and 2 are new.
+	mockRepo.On("AddAssignees", uint(1), []uint{1, 2, 3}, uint(1)).Return([]uint{1, 2}, nil)
+	mockRepo.On("GetTaskByID", uint(1)).Return(task, nil)
+	notifier.On("NotifyAssigned", task, uint(2), uint(1)).Return(nil)
+
+	err := service.AssignTask(1, []uint{1, 2, 3, 2}, usecases.ChangeContext{UserID: 1})
+	assert.Nil(t, err, "Assigning users should succeed")
+	notifier.AssertCalled(t, "NotifyAssigned", task, uint(2), uint(1))
+	notifier.AssertNumberOfCalls(t, "NotifyAssigned", 1)
+}
+
+// ✅ Test Assigning an Unknown User
+func Test_AssignTask_UnknownUser(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockUsers := new(MockUserRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Users = mockUsers
+
+	mockUsers.On("GetUsersByIDs", []uint{2, 99}).Return(users(2), nil)
+
+	err := service.AssignTask(1, []uint{2, 99}, usecases.ChangeContext{UserID: 1})
+	assert.Equal(t, usecases.ErrUserNotFound, err, "Unknown users should be rejected")
+	mockRepo.AssertNotCalled(t, "AddAssignees", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ✅ Test Assignment Notification Message
+func Test_NotifyAssigned(t *testing.T) {
+	mockRepo := new(MockReminderRepository)
+	service := usecases.NewReminderService(mockRepo, new(MockReminderQueue), new(MockTaskRepository))
+
+	mockRepo.On("CreateNotification", mock.MatchedBy(func(n *models.Notification) bool {
+		return n.UserID == 2 && n.TaskID == 1 && n.Message == `You were assigned to "Review PR"`
+	})).Return(nil)
+
+	err := service.NotifyAssigned(&models.Task{ID: 1, Title: "Review PR"}, 2, 1)
+	assert.Nil(t, err, "Notifying an assignee should succeed")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// ✅ Test Dependency Cycle Detection
+func Test_AddDependency_Cycle(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	// 2 is blocked by 1, 3 is blocked by 2: making 1 wait for 3 closes a loop.
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3}, nil)
+	mockRepo.On("GetDependencyEdges", []uint{3}).Return([]models.TaskDependency{{TaskID: 3, BlockerID: 2}}, nil)
+	mockRepo.On("GetDependencyEdges", []uint{2}).Return([]models.TaskDependency{{TaskID: 2, BlockerID: 1}, {TaskID: 3, BlockerID: 2}}, nil)
+
+	err := service.AddDependency(1, 3)
+	assert.Equal(t, usecases.ErrDependencyCycle, err, "Circular dependencies should be rejected")
+
+	err = service.AddDependency(1, 1)
+	assert.Equal(t, usecases.ErrDependencyCycle, err, "A task cannot block itself")
+	mockRepo.AssertNotCalled(t, "AddDependency", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Completing a Blocked Task
+func Test_CompleteBlockedTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	task := &models.Task{
+		ID: 4, Title: "Deploy", Status: "pending", StatusCategory: models.StatusCategoryOpen,
+		BlockedBy: []models.Task{{ID: 5, Title: "Write tests", StatusCategory: models.StatusCategoryOpen}},
+	}
+	mockRepo.On("GetTaskByID", uint(4)).Return(task, nil)
+
+	_, err := service.TransitionTask(4, "completed", usecases.ChangeContext{UserID: 1})
+	assert.True(t, errors.Is(err, usecases.ErrTaskBlocked), "Tasks with open blockers should not complete")
+	mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+
+	mockRepo.On("UpdateTask", uint(4), mock.Anything).Return(nil)
+	_, err = service.TransitionTask(4, "completed", usecases.ChangeContext{UserID: 1, Force: true})
+	assert.Nil(t, err, "Force should override open blockers")
+}
@@ -54,7 +54,7 @@ func TestCreateTask(t *testing.T) {
 		Title:       "New Test Task",
 		Description: "This is a test task",
 		Status:      "pending",
-		DueDate:     "2025-04-01",
+		DueDate:     dueDate("2025-04-01"),
 	}
 
 	body, _ := json.Marshal(task)
@@ -109,7 +109,7 @@ func TestUpdateTask(t *testing.T) {
 		Title:       "Updated Test Task",
 		Description: "Updated description",
 		Status:      "completed",
-		DueDate:     "2025-04-10",
+		DueDate:     dueDate("2025-04-10"),
 	}
 
 	body, _ := json.Marshal(updateTask)
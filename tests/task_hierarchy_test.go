@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+func uintPtr(value uint) *uint {
+	return &value
+}
+
+// ✅ Test Cycle Prevention
+func Test_SetParent_Cycle(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	// 1 -> 2 -> 3: nesting 1 under 3 would create a loop.
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockRepo.On("GetTaskByID", uint(2)).Return(&models.Task{ID: 2, ParentID: uintPtr(1)}, nil)
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, ParentID: uintPtr(2)}, nil)
+
+	err := service.SetParent(1, uintPtr(3))
+	assert.Equal(t, usecases.ErrTaskCycle, err, "Nesting a task under its own subtask should fail")
+
+	err = service.SetParent(1, uintPtr(1))
+	assert.Equal(t, usecases.ErrTaskCycle, err, "Nesting a task under itself should fail")
+	mockRepo.AssertNotCalled(t, "SetParent", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Depth Limit
+func Test_CreateSubtask_TooDeep(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	// 1 -> 2 -> 3 -> 4 already uses every level.
+	mockRepo.On("GetTaskByID", uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockRepo.On("GetTaskByID", uint(2)).Return(&models.Task{ID: 2, ParentID: uintPtr(1)}, nil)
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, ParentID: uintPtr(2)}, nil)
+	mockRepo.On("GetTaskByID", uint(4)).Return(&models.Task{ID: 4, ParentID: uintPtr(3)}, nil)
+
+	err := service.CreateSubtask(4, &models.Task{Title: "Too deep"})
+	assert.Equal(t, usecases.ErrTaskTooDeep, err, "Subtasks beyond the depth limit should be rejected")
+
+	task := &models.Task{Title: "Fits"}
+	mockRepo.On("CreateTask", task).Return(nil)
+	err = service.CreateSubtask(3, task)
+	assert.Nil(t, err, "Subtasks within the depth limit should be created")
+}
+
+// ✅ Test Parent Auto-completion
+func Test_AutoCompleteParent(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	child := &models.Task{ID: 2, Title: "Child", Status: "pending", StatusCategory: models.StatusCategoryOpen, ParentID: uintPtr(1)}
+	parent := &models.Task{
+		ID: 1, Title: "Parent", Status: "pending", StatusCategory: models.StatusCategoryOpen, AutoComplete: true,
+		Subtasks: []models.Task{
+			{ID: 2, StatusCategory: models.StatusCategoryDone},
+			{ID: 3, StatusCategory: models.StatusCategoryCancelled},
+		},
+	}
+
+	mockRepo.On("GetTaskByID", uint(2)).Return(child, nil)
+	mockRepo.On("GetTaskByID", uint(1)).Return(parent, nil)
+	mockRepo.On("UpdateTask", uint(2), mock.Anything).Return(nil)
+	mockRepo.On("UpdateTask", uint(1), mock.MatchedBy(func(task *models.Task) bool {
+		return task.Status == "completed"
+	})).Return(nil)
+
+	_, err := service.TransitionTask(2, "completed", usecases.ChangeContext{UserID: 1})
+	assert.Nil(t, err, "Expected no error when completing subtask")
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) CreateSeries(series *models.TaskSeries) error {
+	args := m.Called(series)
+	series.ID = 1
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetSeriesByID(id uint) (*models.TaskSeries, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.TaskSeries), args.Error(1)
+}
+
+func (m *MockSeriesRepository) UpdateSeries(id uint, updatedSeries *models.TaskSeries) error {
+	args := m.Called(id, updatedSeries)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) InWorkspace(workspaceID uint) repositories.SeriesRepository {
+	return m
+}
+
+func (m *MockSeriesRepository) InTransaction(tasks repositories.TaskRepository) repositories.SeriesRepository {
+	return m
+}
+
+func date(value string) time.Time {
+	parsed, _ := time.Parse("2006-01-02", value)
+	return parsed
+}
+
+// ✅ Test RRULE Next Occurrence
+func Test_RRuleNext(t *testing.T) {
+	cases := []struct {
+		rule     string
+		start    string
+		after    string
+		occurred int
+		want     string
+	}{
+		{"FREQ=DAILY;INTERVAL=2", "2025-01-01", "2025-01-01", 1, "2025-01-03"},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", "2025-01-06", "2025-01-06", 1, "2025-01-09"},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", "2025-01-06", "2025-01-06", 1, "2025-01-20"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "2025-01-31", "2025-01-31", 1, "2025-02-28"},
+		{"FREQ=MONTHLY;BYDAY=2TU", "2025-01-14", "2025-01-14", 1, "2025-02-11"},
+		{"FREQ=MONTHLY", "2025-01-31", "2025-01-31", 1, "2025-03-31"},
+	}
+
+	for _, tc := range cases {
+		rule, err := utils.ParseRRule(tc.rule)
+		assert.Nil(t, err, tc.rule)
+
+		next, ok := rule.Next(date(tc.start), date(tc.after), tc.occurred)
+		assert.True(t, ok, tc.rule)
+		assert.Equal(t, tc.want, next.Format("2006-01-02"), tc.rule)
+	}
+
+	rule, _ := utils.ParseRRule("FREQ=DAILY;COUNT=3")
+	_, ok := rule.Next(date("2025-01-01"), date("2025-01-03"), 3)
+	assert.False(t, ok, "COUNT should end the series")
+
+	rule, _ = utils.ParseRRule("FREQ=WEEKLY;UNTIL=20250110")
+	_, ok = rule.Next(date("2025-01-01"), date("2025-01-08"), 2)
+	assert.False(t, ok, "UNTIL should end the series")
+
+	for _, invalid := range []string{"", "INTERVAL=2", "FREQ=HOURLY", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=2;UNTIL=20250101"} {
+		_, err := utils.ParseRRule(invalid)
+		assert.NotNil(t, err, "Rule '%s' should be rejected", invalid)
+	}
+}
+
+// ✅ Test Creating a Recurring Task
+func Test_CreateRecurringTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockSeries := new(MockSeriesRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Series = mockSeries
+
+	task := &models.Task{Title: "Water plants", DueDate: dueDate("2025-01-06"), Recurrence: "FREQ=WEEKLY;BYDAY=MO"}
+	mockSeries.On("CreateSeries", mock.Anything).Return(nil)
+	mockRepo.On("CreateTask", task).Return(nil)
+
+	err := service.CreateTask(task)
+	assert.Nil(t, err, "Recurring tasks should be created")
+	assert.Equal(t, uint(1), *task.SeriesID)
+	assert.Equal(t, 1, task.Occurrence)
+
+	err = service.CreateTask(&models.Task{Title: "Bad", Recurrence: "FREQ=SOMETIMES"})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidRecurrence), "Invalid rules should be rejected")
+}
+
+// ✅ Test Requests Can't Join a Task to a Series
+func Test_CreateTask_IgnoresSeriesFields(t *testing.T) {
+	var task models.Task
+	err := json.Unmarshal([]byte(`{"title": "Sneaky", "series_id": 1, "occurrence": 4}`), &task)
+	assert.Nil(t, err)
+	assert.Nil(t, task.SeriesID, "Only recurrence should start a series")
+	assert.Equal(t, 0, task.Occurrence)
+}
+
+// ✅ Test Completing a Recurring Task
+func Test_CompleteRecurringTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockSeries := new(MockSeriesRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Series = mockSeries
+
+	seriesID := uint(1)
+	task := &models.Task{
+		ID: 7, Title: "Water plants", Status: "pending", StatusCategory: models.StatusCategoryOpen,
+		DueDate: dueDate("2025-01-06"), SeriesID: &seriesID, Occurrence: 1,
+	}
+	mockRepo.On("GetTaskByID", uint(7)).Return(task, nil)
+	mockRepo.On("UpdateTask", uint(7), mock.Anything).Return(nil)
+	mockSeries.On("GetSeriesByID", uint(1)).Return(&models.TaskSeries{
+		ID: 1, Rule: "FREQ=WEEKLY;BYDAY=MO", Mode: models.RecurrenceOnComplete,
+		StartDate: "2025-01-06", FirstOccurrence: 1, Title: "Water plants",
+	}, nil)
+	mockRepo.On("ClaimNextOccurrence", uint(7)).Return(true, nil).Once()
+	mockRepo.On("CreateTask", mock.Anything).Return(nil)
+
+	_, err := service.TransitionTask(7, "completed", usecases.ChangeContext{UserID: 1})
+	assert.Nil(t, err, "Completing a recurring task should succeed")
+	mockRepo.AssertCalled(t, "CreateTask", mock.MatchedBy(func(next *models.Task) bool {
+		return next.DueDate.String() == "2025-01-13" && next.Occurrence == 2 && *next.SeriesID == seriesID
+	}))
+
+	// A second completion finds the next occurrence already claimed.
+	mockRepo.On("ClaimNextOccurrence", uint(7)).Return(false, nil)
+	_, err = service.TransitionTask(7, "completed", usecases.ChangeContext{UserID: 1})
+	assert.Nil(t, err)
+	mockRepo.AssertNumberOfCalls(t, "CreateTask", 1)
+}
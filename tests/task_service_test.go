@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
 	"github.com/yasseryazid/technical-test/usecases"
 )
 
@@ -21,6 +22,25 @@ func (m *MockTaskRepository) GetTasks(status, search string, page, limit int) ([
 	return args.Get(0).([]models.Task), args.Int(1), args.Error(2)
 }
 
+func (m *MockTaskRepository) FindTasks(filter models.TaskFilter, sort string, page, limit int) ([]models.Task, int, error) {
+	args := m.Called(filter, sort, page, limit)
+	return args.Get(0).([]models.Task), args.Int(1), args.Error(2)
+}
+
+// EachTask passes the tasks given to Return to fn as a single batch.
+func (m *MockTaskRepository) EachTask(filter models.TaskFilter, sort string, batchSize int, fn func(tasks []models.Task) error) error {
+	args := m.Called(filter, sort, batchSize)
+	if err := args.Error(1); err != nil {
+		return err
+	}
+	return fn(args.Get(0).([]models.Task))
+}
+
+func (m *MockTaskRepository) GetCalendarTasks(workspaceIDs []uint, since time.Time, limit int) ([]models.Task, error) {
+	args := m.Called(workspaceIDs, since, limit)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
 func (m *MockTaskRepository) CreateTask(task *models.Task) error {
 	args := m.Called(task)
 	return args.Error(0)
@@ -41,6 +61,131 @@ func (m *MockTaskRepository) DeleteTask(id uint) error {
 	return args.Error(0)
 }
 
+func (m *MockTaskRepository) GetTrash(page, limit int) ([]models.Task, int, error) {
+	args := m.Called(page, limit)
+	return args.Get(0).([]models.Task), args.Int(1), args.Error(2)
+}
+
+func (m *MockTaskRepository) GetTrashedTask(id uint) (*models.Task, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) RestoreTask(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) PurgeTask(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetExpiredTrash(before time.Time) ([]uint, error) {
+	args := m.Called(before)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockTaskRepository) SetArchived(id uint, archivedAt *time.Time) error {
+	args := m.Called(id, archivedAt)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) ArchiveCompletedBefore(before time.Time) ([]uint, error) {
+	args := m.Called(before)
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockTaskRepository) MoveTask(id uint, projectID *uint) error {
+	args := m.Called(id, projectID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) AddLabels(id uint, labelIDs []uint) error {
+	args := m.Called(id, labelIDs)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveLabel(id, labelID uint) error {
+	args := m.Called(id, labelID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetSubtasks(id uint) ([]models.Task, error) {
+	args := m.Called(id)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) SetParent(id uint, parentID *uint) error {
+	args := m.Called(id, parentID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetTasksByIDs(ids []uint) ([]models.Task, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) AddDependency(id, blockerID uint) error {
+	args := m.Called(id, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) RemoveDependency(id, blockerID uint) error {
+	args := m.Called(id, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) GetDependencyEdges(ids []uint) ([]models.TaskDependency, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.TaskDependency), args.Error(1)
+}
+
+func (m *MockTaskRepository) ClaimNextOccurrence(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTaskRepository) GetDueOccurrences(now time.Time) ([]models.Task, error) {
+	args := m.Called(now)
+	return args.Get(0).([]models.Task), args.Error(1)
+}
+
+func (m *MockTaskRepository) UpdateFutureOccurrences(seriesID uint, after int, updatedTask *models.Task) error {
+	args := m.Called(seriesID, after, updatedTask)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) AddAssignees(id uint, userIDs []uint, assignedBy uint) ([]uint, error) {
+	args := m.Called(id, userIDs, assignedBy)
+	added, _ := args.Get(0).([]uint)
+	return added, args.Error(1)
+}
+
+func (m *MockTaskRepository) RemoveAssignee(id, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+// Transaction runs fn on the mock itself; nothing is rolled back.
+func (m *MockTaskRepository) Transaction(fn func(repo repositories.TaskRepository) error) error {
+	return fn(m)
+}
+
+// InWorkspace returns the mock itself, so expectations apply to every
+// workspace.
+func (m *MockTaskRepository) InWorkspace(workspaceID uint) repositories.TaskRepository {
+	return m
+}
+
+func dueDate(value string) models.DueDate {
+	due, _ := models.ParseDueDate(value)
+	return due
+}
+
 // ✅ Test Create Task
 func Test_CreateTask(t *testing.T) {
 	mockRepo := new(MockTaskRepository)
@@ -50,7 +195,7 @@ func Test_CreateTask(t *testing.T) {
 		Title:       "Test Task",
 		Description: "Testing task creation",
 		Status:      "pending",
-		DueDate:     time.Now().Format("2006-01-02"),
+		DueDate:     models.DateOnly(time.Now()),
 	}
 
 	mockRepo.On("CreateTask", task).Return(nil)
@@ -70,7 +215,7 @@ func Test_GetTaskByID(t *testing.T) {
 		Title:       "Test Task",
 		Description: "Testing",
 		Status:      "pending",
-		DueDate:     "2025-03-07",
+		DueDate:     dueDate("2025-03-07"),
 	}
 
 	mockRepo.On("GetTaskByID", uint(1)).Return(task, nil)
@@ -87,13 +232,15 @@ func Test_UpdateTask(t *testing.T) {
 	service := usecases.NewTaskService(mockRepo)
 
 	taskID := uint(1)
+	currentTask := &models.Task{ID: taskID, Title: "Task", Status: "pending"}
 	updatedTask := &models.Task{
 		Title:       "Updated Task",
 		Description: "Updated description",
 		Status:      "completed",
-		DueDate:     "2025-04-01",
+		DueDate:     dueDate("2025-04-01"),
 	}
 
+	mockRepo.On("GetTaskByID", taskID).Return(currentTask, nil)
 	mockRepo.On("UpdateTask", taskID, updatedTask).Return(nil)
 
 	err := service.UpdateTask(taskID, updatedTask)
@@ -120,8 +267,8 @@ func TestGetTasks(t *testing.T) {
 	service := usecases.NewTaskService(mockRepo)
 
 	tasks := []models.Task{
-		{ID: 1, Title: "Task 1", Description: "Task 1 Desc", Status: "pending", DueDate: "2025-03-10"},
-		{ID: 2, Title: "Task 2", Description: "Task 2 Desc", Status: "completed", DueDate: "2025-03-12"},
+		{ID: 1, Title: "Task 1", Description: "Task 1 Desc", Status: "pending", DueDate: dueDate("2025-03-10")},
+		{ID: 2, Title: "Task 2", Description: "Task 2 Desc", Status: "completed", DueDate: dueDate("2025-03-12")},
 	}
 
 	mockRepo.On("GetTasks", "", "", 1, 5).Return(tasks, len(tasks), nil)
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// taskFiles is an AttachmentFiles that remembers what it was asked to prune.
+type taskFiles struct {
+	hashes map[uint][]string
+	pruned []string
+}
+
+func (f *taskFiles) TaskFileHashes(taskID uint) ([]string, error) {
+	return f.hashes[taskID], nil
+}
+
+func (f *taskFiles) PruneFiles(hashes []string) {
+	f.pruned = append(f.pruned, hashes...)
+}
+
+// ✅ Test Deleting Keeps Attachment Contents
+func Test_DeleteTask_KeepsFiles(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	files := &taskFiles{hashes: map[uint][]string{3: {"abc"}}}
+	service := usecases.NewTaskService(mockRepo)
+	service.Files = files
+
+	mockRepo.On("DeleteTask", uint(3)).Return(nil)
+
+	assert.Nil(t, service.DeleteTask(3))
+	assert.Empty(t, files.pruned, "Trashed tasks must keep their files so they can be restored")
+}
+
+// ✅ Test Purging Removes Files and Is Recorded
+func Test_PurgeTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	files := &taskFiles{hashes: map[uint][]string{3: {"abc"}}}
+	service := usecases.NewTaskService(mockRepo)
+	service.Files = files
+	service.Activity = activity
+
+	mockRepo.On("GetTrashedTask", uint(3)).Return(&models.Task{ID: 3, WorkspaceID: 2, Title: "Old task"}, nil)
+	mockRepo.On("PurgeTask", uint(3)).Return(nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	assert.Nil(t, service.As(usecases.ChangeContext{UserID: 5}).PurgeTask(3))
+	assert.Equal(t, []string{"abc"}, files.pruned)
+
+	recorded := activity.recorded()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, models.ActivityPurged, recorded[0].Action)
+	assert.Equal(t, uint(5), *recorded[0].UserID)
+}
+
+// ✅ Test Purging a Task That Isn't in the Trash
+func Test_PurgeTask_NotInTrash(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	mockRepo.On("GetTrashedTask", uint(3)).Return(nil, assert.AnError)
+
+	assert.NotNil(t, service.PurgeTask(3))
+	mockRepo.AssertNotCalled(t, "PurgeTask", mock.Anything)
+}
+
+// ✅ Test Restoring From the Trash
+func Test_RestoreTask(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	activity := new(MockActivityRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Activity = activity
+
+	mockRepo.On("RestoreTask", uint(3)).Return(nil)
+	mockRepo.On("GetTaskByID", uint(3)).Return(&models.Task{ID: 3, Title: "Old task", Status: "pending"}, nil)
+	activity.On("RecordActivity", mock.Anything).Return(nil)
+
+	task, err := service.RestoreTask(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "Old task", task.Title)
+	assert.Equal(t, models.ActivityUndeleted, activity.recorded()[0].Action)
+}
+
+// ✅ Test the Retention Job Purges Expired Trash
+func Test_PurgeExpiredTrash(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	mockRepo.On("GetExpiredTrash", now.Add(-30*24*time.Hour)).Return([]uint{3, 4}, nil)
+	mockRepo.On("GetTrashedTask", uint(3)).Return(&models.Task{ID: 3}, nil)
+	mockRepo.On("GetTrashedTask", uint(4)).Return(&models.Task{ID: 4}, nil)
+	mockRepo.On("PurgeTask", uint(3)).Return(assert.AnError)
+	mockRepo.On("PurgeTask", uint(4)).Return(nil)
+
+	purged, err := service.PurgeExpiredTrash(now, usecases.DefaultTrashRetention)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, purged, "A failed purge should be skipped, not stop the job")
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// Mock repository
+type MockViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockViewRepository) GetViews(userID uint) ([]models.SavedView, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.SavedView), args.Error(1)
+}
+
+func (m *MockViewRepository) CreateView(view *models.SavedView) error {
+	args := m.Called(view)
+	return args.Error(0)
+}
+
+func (m *MockViewRepository) GetViewByID(id, userID uint) (*models.SavedView, error) {
+	args := m.Called(id, userID)
+	return args.Get(0).(*models.SavedView), args.Error(1)
+}
+
+func (m *MockViewRepository) UpdateView(id, userID uint, updatedView *models.SavedView) error {
+	args := m.Called(id, userID, updatedView)
+	return args.Error(0)
+}
+
+func (m *MockViewRepository) DeleteView(id, userID uint) error {
+	args := m.Called(id, userID)
+	return args.Error(0)
+}
+
+// ✅ Test Run Saved View
+func Test_RunView(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	service := usecases.NewViewService(new(MockViewRepository), usecases.NewTaskService(mockTaskRepo))
+
+	view := &models.SavedView{
+		ID:      1,
+		Name:    "Pending work",
+		Filters: models.TaskFilter{Status: "pending", Search: "report"},
+		Sort:    "-due_date",
+	}
+	tasks := []models.Task{{ID: 3, Title: "Quarterly report", Status: "pending"}}
+
+	mockTaskRepo.On("FindTasks", view.Filters, "-due_date", 1, 10).Return(tasks, 1, nil)
+
+	result, total, err := service.RunView(view, 1, 10)
+	assert.Nil(t, err, "Expected no error")
+	assert.Equal(t, 1, total, "Total tasks should match")
+	assert.Equal(t, tasks, result, "Returned tasks should match the expected value")
+	mockTaskRepo.AssertExpectations(t)
+}
+
+// ✅ Test Built-in Views
+func Test_GetBuiltinView(t *testing.T) {
+	service := usecases.NewViewService(new(MockViewRepository), nil)
+
+	view, ok := service.GetBuiltinView("overdue")
+	assert.True(t, ok, "Overdue view should exist")
+	assert.True(t, view.Filters.Overdue, "Overdue view should filter overdue tasks")
+
+	_, ok = service.GetBuiltinView("unknown")
+	assert.False(t, ok, "Unknown view should not exist")
+}
+
+// ✅ Test Error Handling
+func Test_CreateView_InvalidSort(t *testing.T) {
+	mockRepo := new(MockViewRepository)
+	service := usecases.NewViewService(mockRepo, nil)
+
+	err := service.CreateView(&models.SavedView{Name: "Bad sort", Sort: "password"})
+	assert.Equal(t, usecases.ErrInvalidSort, err, "Unknown sort field should be rejected")
+	mockRepo.AssertNotCalled(t, "CreateView", mock.Anything)
+}
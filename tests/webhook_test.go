@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/notifiers"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+// ✅ Test Only Public Addresses Are Allowed for Webhooks
+func Test_IsPublicIP(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":        true,
+		"2606:4700::6810:85e5": true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"::ffff:127.0.0.1":     false,
+	} {
+		assert.Equal(t, public, utils.IsPublicIP(netip.MustParseAddr(address)), address)
+	}
+
+	assert.False(t, utils.IsPublicHost("localhost"))
+	assert.False(t, utils.IsPublicHost("[::1]"))
+	assert.True(t, utils.IsPublicHost("hooks.example.com"))
+}
+
+// ✅ Test Webhooks Never Reach Local Servers or Leak Why They Failed
+func Test_WebhookRefusesLocalTargets(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	channel := &notifiers.WebhookChannel{Client: notifiers.NewPublicClient(time.Second)}
+	reminder := &models.Reminder{ID: 1, Channel: models.ChannelWebhook, Target: server.URL}
+	err := channel.Deliver(reminder, &models.Task{ID: 1, Title: "Pay rent"})
+
+	assert.False(t, hit, "The local server should not receive the webhook")
+	assert.EqualError(t, err, "delivery failed")
+
+	client := notifiers.NewPublicClient(time.Second)
+	assert.Equal(t, http.ErrUseLastResponse, client.CheckRedirect(nil, nil), "Redirects should not be followed")
+}
@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+// Mock repository
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) InWorkspace(workspaceID uint) repositories.WorkflowRepository {
+	return m
+}
+
+func (m *MockWorkflowRepository) GetWorkflows() ([]models.Workflow, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) CreateWorkflow(workflow *models.Workflow) error {
+	args := m.Called(workflow)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) GetWorkflowByID(id uint) (*models.Workflow, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) UpdateWorkflow(id uint, updatedWorkflow *models.Workflow) error {
+	args := m.Called(id, updatedWorkflow)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) DeleteWorkflow(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) RecordTransition(transition *models.TaskTransition) error {
+	args := m.Called(transition)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) GetTransitions(taskID uint) ([]models.TaskTransition, error) {
+	args := m.Called(taskID)
+	return args.Get(0).([]models.TaskTransition), args.Error(1)
+}
+
+func developmentWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID:   7,
+		Name: "Development",
+		States: []models.WorkflowState{
+			{Key: "todo", Category: models.StatusCategoryOpen},
+			{Key: "in_progress", Category: models.StatusCategoryOpen},
+			{Key: "done", Category: models.StatusCategoryDone},
+		},
+		Transitions: []models.WorkflowTransition{
+			{From: "todo", To: "in_progress"},
+			{From: "in_progress", To: "done"},
+		},
+	}
+}
+
+func newWorkflowTaskService() (*usecases.TaskService, *MockTaskRepository, *MockWorkflowRepository) {
+	mockRepo := new(MockTaskRepository)
+	mockProjects := new(MockProjectRepository)
+	mockWorkflows := new(MockWorkflowRepository)
+
+	workflowID := uint(7)
+	mockProjects.On("GetProjectByID", uint(1)).Return(&models.Project{ID: 1, WorkflowID: &workflowID}, nil)
+	mockWorkflows.On("GetWorkflowByID", workflowID).Return(developmentWorkflow(), nil)
+
+	service := usecases.NewTaskService(mockRepo)
+	service.Projects = mockProjects
+	service.Workflows = mockWorkflows
+	return service, mockRepo, mockWorkflows
+}
+
+// ✅ Test Transition Task
+func Test_TransitionTask(t *testing.T) {
+	service, mockRepo, mockWorkflows := newWorkflowTaskService()
+
+	projectID := uint(1)
+	task := &models.Task{ID: 3, Title: "Ship it", Status: "in_progress", ProjectID: &projectID}
+	mockRepo.On("GetTaskByID", uint(3)).Return(task, nil)
+	mockRepo.On("UpdateTask", uint(3), mock.Anything).Return(nil)
+	mockWorkflows.On("RecordTransition", mock.MatchedBy(func(transition *models.TaskTransition) bool {
+		return transition.FromStatus == "in_progress" && transition.ToStatus == "done" && transition.UserID == 9
+	})).Return(nil)
+
+	result, err := service.TransitionTask(3, "done", usecases.ChangeContext{UserID: 9})
+	assert.Nil(t, err, "Expected no error when transitioning task")
+	assert.Equal(t, models.StatusCategoryDone, result.StatusCategory, "Done state should mark the task done")
+	assert.NotNil(t, result.CompletedAt, "Completed tasks should carry a completion time")
+	mockWorkflows.AssertExpectations(t)
+}
+
+// ✅ Test Error Handling
+func Test_TransitionTask_NotAllowed(t *testing.T) {
+	service, mockRepo, _ := newWorkflowTaskService()
+
+	projectID := uint(1)
+	task := &models.Task{ID: 3, Title: "Ship it", Status: "todo", ProjectID: &projectID}
+	mockRepo.On("GetTaskByID", uint(3)).Return(task, nil)
+
+	_, err := service.TransitionTask(3, "done", usecases.ChangeContext{UserID: 9})
+	assert.True(t, errors.Is(err, usecases.ErrTransitionNotAllowed), "Skipping states should not be allowed")
+
+	_, err = service.TransitionTask(3, "shipped", usecases.ChangeContext{UserID: 9})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidStatus), "Unknown states should be rejected")
+	mockRepo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Default Workflow
+func Test_CreateTask_DefaultStatus(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	service := usecases.NewTaskService(mockRepo)
+
+	task := &models.Task{Title: "No status given"}
+	mockRepo.On("CreateTask", task).Return(nil)
+
+	err := service.CreateTask(task)
+	assert.Nil(t, err, "Expected no error when creating task")
+	assert.Equal(t, "pending", task.Status, "New tasks should start in the first workflow state")
+
+	err = service.CreateTask(&models.Task{Title: "Bad status", Status: "archived"})
+	assert.True(t, errors.Is(err, usecases.ErrInvalidStatus), "Unknown statuses should be rejected")
+}
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/usecases"
+)
+
+type MockWorkspaceRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkspaceRepository) CreateWorkspace(workspace *models.Workspace) error {
+	args := m.Called(workspace)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) GetWorkspaceByID(id uint) (*models.Workspace, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workspace), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) UpdateWorkspace(id uint, updatedWorkspace *models.Workspace) error {
+	args := m.Called(id, updatedWorkspace)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) DeleteWorkspace(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) CountTasks(id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMemberships(userID uint) ([]models.WorkspaceMember, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMember(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	args := m.Called(workspaceID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMembers(workspaceID uint) ([]models.WorkspaceMember, error) {
+	args := m.Called(workspaceID)
+	return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) GetMembersByUserIDs(workspaceID uint, userIDs []uint) ([]models.WorkspaceMember, error) {
+	args := m.Called(workspaceID, userIDs)
+	return args.Get(0).([]models.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) UpdateMemberRole(workspaceID, userID uint, role string) error {
+	args := m.Called(workspaceID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) RemoveMember(workspaceID, userID uint) error {
+	args := m.Called(workspaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) CreateInvitation(invitation *models.WorkspaceInvitation) error {
+	args := m.Called(invitation)
+	invitation.ID = 7
+	return args.Error(0)
+}
+
+func (m *MockWorkspaceRepository) GetInvitationByID(id uint) (*models.WorkspaceInvitation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WorkspaceInvitation), args.Error(1)
+}
+
+func (m *MockWorkspaceRepository) AcceptInvitation(invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) (bool, error) {
+	args := m.Called(invitation, member)
+	return args.Bool(0), args.Error(1)
+}
+
+func member(workspaceID, userID uint, role string) *models.WorkspaceMember {
+	return &models.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID, Role: role}
+}
+
+// ✅ Test Members Cannot Manage a Workspace
+func Test_WorkspaceRoles(t *testing.T) {
+	mockRepo := new(MockWorkspaceRepository)
+	service := usecases.NewWorkspaceService(mockRepo)
+
+	mockRepo.On("GetMember", uint(1), uint(3)).Return(member(1, 3, models.RoleMember), nil)
+	mockRepo.On("GetMember", uint(1), uint(9)).Return(nil, errors.New("record not found"))
+
+	err := service.UpdateWorkspace(1, 3, &models.Workspace{Name: "Renamed"})
+	assert.ErrorIs(t, err, usecases.ErrWorkspaceForbidden, "Members should not rename the workspace")
+	assert.Contains(t, err.Error(), models.PermWorkspaceManage, "The missing permission should be named")
+
+	_, _, err = service.CreateInvitation(1, 3, models.RoleMember)
+	assert.ErrorIs(t, err, usecases.ErrWorkspaceForbidden, "Members should not invite others")
+
+	_, err = service.GetMembers(1, 9)
+	assert.Equal(t, usecases.ErrWorkspaceNotFound, err, "Outsiders should not see the workspace")
+
+	_, _, err = service.CreateInvitation(1, 3, models.RoleOwner)
+	assert.ErrorIs(t, err, usecases.ErrInvalidRole, "Nobody can be invited as owner")
+	mockRepo.AssertNotCalled(t, "UpdateWorkspace", mock.Anything, mock.Anything)
+}
+
+// ✅ Test Owner Membership Is Protected
+func Test_RemoveWorkspaceOwner(t *testing.T) {
+	mockRepo := new(MockWorkspaceRepository)
+	service := usecases.NewWorkspaceService(mockRepo)
+
+	mockRepo.On("GetMember", uint(1), uint(2)).Return(member(1, 2, models.RoleAdmin), nil)
+	mockRepo.On("GetMember", uint(1), uint(1)).Return(member(1, 1, models.RoleOwner), nil)
+	mockRepo.On("GetMember", uint(1), uint(3)).Return(member(1, 3, models.RoleViewer), nil)
+	mockRepo.On("RemoveMember", uint(1), uint(3)).Return(nil)
+
+	err := service.RemoveMember(1, 2, 1)
+	assert.Equal(t, usecases.ErrOwnerMembership, err, "The owner cannot be removed")
+
+	err = service.UpdateMemberRole(1, 2, 1, models.RoleViewer)
+	assert.Equal(t, usecases.ErrOwnerMembership, err, "The owner cannot be demoted")
+
+	err = service.RemoveMember(1, 3, 3)
+	assert.Nil(t, err, "Members may leave on their own")
+}
+
+// ✅ Test Accepting an Invitation
+func Test_AcceptInvitation(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	mockRepo := new(MockWorkspaceRepository)
+	service := usecases.NewWorkspaceService(mockRepo)
+
+	mockRepo.On("GetMember", uint(1), uint(2)).Return(member(1, 2, models.RoleAdmin), nil)
+	mockRepo.On("CreateInvitation", mock.Anything).Return(nil)
+
+	invitation, token, err := service.CreateInvitation(1, 2, models.RoleViewer)
+	assert.Nil(t, err, "Admins should be able to invite")
+	assert.NotEmpty(t, token)
+
+	mockRepo.On("GetInvitationByID", uint(7)).Return(invitation, nil)
+	mockRepo.On("GetMember", uint(1), uint(5)).Return(nil, errors.New("record not found"))
+	mockRepo.On("AcceptInvitation", invitation, member(1, 5, models.RoleViewer)).Return(true, nil)
+
+	joined, err := service.AcceptInvitation(token, 5)
+	assert.Nil(t, err, "A fresh invitation should be accepted")
+	assert.Equal(t, models.RoleViewer, joined.Role)
+
+	_, err = service.AcceptInvitation(token+"x", 5)
+	assert.Equal(t, usecases.ErrInvalidInvitation, err, "Tampered tokens should be rejected")
+
+	used := time.Now()
+	invitation.AcceptedAt = &used
+	_, err = service.AcceptInvitation(token, 5)
+	assert.Equal(t, usecases.ErrInvalidInvitation, err, "Invitations can only be used once")
+}
+
+// ✅ Test Assignees Must Belong to the Workspace
+func Test_AssignTask_OutsideWorkspace(t *testing.T) {
+	mockRepo := new(MockTaskRepository)
+	mockWorkspaces := new(MockWorkspaceRepository)
+	service := usecases.NewTaskService(mockRepo)
+	service.Workspaces = mockWorkspaces
+	scoped := service.InWorkspace(4)
+
+	mockWorkspaces.On("GetMembersByUserIDs", uint(4), []uint{2, 8}).Return([]models.WorkspaceMember{*member(4, 2, models.RoleMember)}, nil)
+
+	err := scoped.AssignTask(1, []uint{2, 8}, usecases.ChangeContext{UserID: 2})
+	assert.Equal(t, usecases.ErrNotWorkspaceMember, err, "Only workspace members can be assigned")
+	mockRepo.AssertNotCalled(t, "AddAssignees", mock.Anything, mock.Anything, mock.Anything)
+}
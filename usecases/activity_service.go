@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// ActivityService reads the activity log TaskService writes.
+type ActivityService struct {
+	Repo  repositories.ActivityRepository
+	Tasks repositories.TaskRepository
+}
+
+func NewActivityService(repo repositories.ActivityRepository, tasks repositories.TaskRepository) *ActivityService {
+	return &ActivityService{Repo: repo, Tasks: tasks}
+}
+
+// InWorkspace returns a copy of the service that only shows the activity of
+// one workspace's tasks.
+func (s *ActivityService) InWorkspace(workspaceID uint) *ActivityService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	return &scoped
+}
+
+// GetTaskActivity returns a task's activity, newest first.
+func (s *ActivityService) GetTaskActivity(taskID uint) ([]models.TaskActivity, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetTaskActivity(taskID)
+}
+
+// FindActivity searches the whole audit log, including deleted tasks.
+func (s *ActivityService) FindActivity(filter models.ActivityFilter, page, limit int) ([]models.TaskActivity, int, error) {
+	for _, value := range []string{filter.From, filter.To} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, 0, ErrInvalidDateFilter
+		}
+	}
+	return s.Repo.FindActivity(filter, page, limit)
+}
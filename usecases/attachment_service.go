@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/storage"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+var (
+	ErrAttachmentNotFound  = errors.New("Attachment not found")
+	ErrAttachmentEmpty     = errors.New("File is empty")
+	ErrAttachmentTooLarge  = errors.New("File is too large")
+	ErrAttachmentType      = errors.New("File type is not allowed")
+	ErrAttachmentForbidden = errors.New("Only the uploader can delete this attachment")
+	ErrInvalidDownloadURL  = errors.New("Download link is invalid or has expired")
+)
+
+const (
+	// DefaultMaxAttachmentSize caps uploads at 10 MiB unless configured.
+	DefaultMaxAttachmentSize = 10 << 20
+	// DefaultDownloadURLTTL is how long a signed download URL stays valid.
+	DefaultDownloadURLTTL = 15 * time.Minute
+)
+
+// DefaultAttachmentTypes are the media types accepted unless configured.
+// Types that browsers may run as script, such as HTML and SVG, are left out.
+var DefaultAttachmentTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp",
+	"application/pdf", "application/zip",
+	"text/plain", "text/csv", "text/markdown",
+}
+
+// URLSigner is implemented by storages that can serve downloads themselves.
+// Other storages are read through the API's signed download route.
+type URLSigner interface {
+	SignedURL(key, filename string, expiresAt time.Time) (string, error)
+}
+
+type AttachmentService struct {
+	Repo    repositories.AttachmentRepository
+	Tasks   repositories.TaskRepository
+	Storage storage.Backend
+	// MaxSize is the largest accepted upload, in bytes.
+	MaxSize int64
+	// AllowedTypes lists accepted media types; "image/*" accepts every image.
+	AllowedTypes []string
+	URLTTL       time.Duration
+}
+
+func NewAttachmentService(repo repositories.AttachmentRepository, tasks repositories.TaskRepository, backend storage.Backend) *AttachmentService {
+	return &AttachmentService{
+		Repo:         repo,
+		Tasks:        tasks,
+		Storage:      backend,
+		MaxSize:      DefaultMaxAttachmentSize,
+		AllowedTypes: DefaultAttachmentTypes,
+		URLTTL:       DefaultDownloadURLTTL,
+	}
+}
+
+// InWorkspace returns a copy of the service that only sees attachments on one
+// workspace's tasks.
+func (s *AttachmentService) InWorkspace(workspaceID uint) *AttachmentService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *AttachmentService) GetAttachments(taskID uint) ([]models.Attachment, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return s.Repo.GetAttachments(taskID)
+}
+
+func (s *AttachmentService) GetAttachment(taskID, id uint) (*models.Attachment, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	attachment, err := s.Repo.GetAttachmentByID(id)
+	if err != nil || attachment.TaskID != taskID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// Upload stores body as an attachment of a task. The media type is sniffed
+// from the content rather than taken from the client, and contents already
+// stored for another attachment are not stored again.
+func (s *AttachmentService) Upload(taskID, userID uint, filename string, body io.Reader) (*models.Attachment, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+
+	// The upload is spooled to disk so it can be hashed before it is stored.
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(body, s.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if size > s.MaxSize {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrAttachmentTooLarge, s.MaxSize)
+	}
+
+	filename = cleanFilename(filename)
+	contentType, err := sniffContentType(tmp, filename)
+	if err != nil {
+		return nil, err
+	}
+	if !s.allowedType(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentType, contentType)
+	}
+
+	attachment := &models.Attachment{
+		TaskID:      taskID,
+		UploadedBy:  userID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Hash:        hex.EncodeToString(hasher.Sum(nil)),
+	}
+
+	// The file is stored and referenced under the hash's lock, so pruning
+	// can't delete it before the new attachment is committed.
+	err = s.Repo.LockHash(attachment.Hash, func(repo repositories.AttachmentRepository) error {
+		existing, err := repo.CountByHash(attachment.Hash)
+		if err != nil {
+			return err
+		}
+		if existing == 0 {
+			if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := s.Storage.Put(fileKey(attachment.Hash), tmp, size, contentType); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.CreateAttachment(attachment); err != nil {
+			if existing == 0 {
+				s.deleteFile(attachment.Hash)
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment deletes an attachment, and its contents once no other
+// attachment uses them. Uploaders may delete their own attachments;
+// moderators may delete anyone's.
+func (s *AttachmentService) DeleteAttachment(taskID, id, userID uint, moderator bool) error {
+	attachment, err := s.GetAttachment(taskID, id)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy != userID && !moderator {
+		return ErrAttachmentForbidden
+	}
+	if err := s.Repo.DeleteAttachment(id); err != nil {
+		return err
+	}
+	s.PruneFiles([]string{attachment.Hash})
+	return nil
+}
+
+// DownloadURL returns a URL the attachment can be downloaded from without a
+// session, and when it stops working.
+func (s *AttachmentService) DownloadURL(attachment *models.Attachment) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.URLTTL)
+	if signer, ok := s.Storage.(URLSigner); ok {
+		signed, err := signer.SignedURL(fileKey(attachment.Hash), attachment.Filename, expiresAt)
+		return signed, expiresAt, err
+	}
+
+	token, err := utils.GenerateDownloadToken(attachment.ID, expiresAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return "/api/attachments/download?token=" + url.QueryEscape(token), expiresAt, nil
+}
+
+// OpenDownload checks a download token and opens the attachment it was
+// issued for. The caller closes the returned reader.
+func (s *AttachmentService) OpenDownload(token string) (*models.Attachment, io.ReadCloser, error) {
+	id, err := utils.ParseDownloadToken(token)
+	if err != nil {
+		return nil, nil, ErrInvalidDownloadURL
+	}
+	attachment, err := s.Repo.GetAttachmentByID(id)
+	if err != nil {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	contents, err := s.Storage.Open(fileKey(attachment.Hash))
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, contents, nil
+}
+
+// TaskFileHashes returns the content hashes of a task's attachments, so they
+// can be pruned once the task is deleted.
+func (s *AttachmentService) TaskFileHashes(taskID uint) ([]string, error) {
+	return s.Repo.GetHashes(taskID)
+}
+
+// PruneFiles deletes the stored contents of hashes no attachment refers to
+// any more. Each hash is counted and deleted under its lock, so an upload of
+// the same contents can't reuse a file that is about to go. Failures are
+// logged; at worst a file is left behind.
+func (s *AttachmentService) PruneFiles(hashes []string) {
+	for _, hash := range hashes {
+		err := s.Repo.LockHash(hash, func(repo repositories.AttachmentRepository) error {
+			count, err := repo.CountByHash(hash)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				s.deleteFile(hash)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("[X] Failed to check attachment file %s: %v\n", hash, err)
+		}
+	}
+}
+
+func (s *AttachmentService) deleteFile(hash string) {
+	if err := s.Storage.Delete(fileKey(hash)); err != nil {
+		log.Printf("[X] Failed to delete attachment file %s: %v\n", hash, err)
+	}
+}
+
+func (s *AttachmentService) allowedType(contentType string) bool {
+	for _, allowed := range s.AllowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == contentType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// fileKey is where the contents with the given SHA-256 hash are stored.
+func fileKey(hash string) string {
+	return "attachments/" + hash[:2] + "/" + hash
+}
+
+// sniffContentType detects the media type of file from its first bytes. Plain
+// text is narrowed down by the file extension, e.g. to text/csv.
+func sniffContentType(file io.ReadSeeker, filename string) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if contentType == "text/plain" {
+		byExtension, _, _ := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(filename)))
+		if strings.HasPrefix(byExtension, "text/") && byExtension != "text/html" {
+			contentType = byExtension
+		}
+	}
+	return contentType, nil
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters, so it is safe to echo in headers.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[len(name)-255:], "")
+	}
+	return name
+}
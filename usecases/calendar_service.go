@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// Calendar feed types. Calendar apps that don't show to-dos still show
+// events.
+const (
+	FeedEvents = "event"
+	FeedTodos  = "todo"
+)
+
+const (
+	// FeedCacheTTL bounds how long a rendered feed is reused. Task changes
+	// invalidate it sooner; the TTL catches the rest, like renamed labels.
+	FeedCacheTTL = time.Hour
+	// FeedHistory is how far back finished tasks stay in a feed.
+	FeedHistory = 90 * 24 * time.Hour
+	// MaxFeedTasks caps the size of a feed.
+	MaxFeedTasks = 2000
+)
+
+var (
+	ErrFeedNotFound    = errors.New("Calendar feed not found")
+	ErrInvalidFeedType = errors.New("Invalid type. Use 'event' or 'todo'")
+)
+
+// FeedRenderer turns the tasks of a feed into a calendar document.
+type FeedRenderer func(user *models.User, tasks []models.Task) ([]byte, error)
+
+// FeedInvalidator is told about task changes so cached feeds showing the
+// task are rebuilt.
+type FeedInvalidator interface {
+	InvalidateWorkspace(workspaceID uint)
+}
+
+// CalendarService manages the secret calendar feed each user can subscribe
+// to. A feed has the tasks with a due date in every workspace where the
+// user may read tasks.
+type CalendarService struct {
+	Users      repositories.UserRepository
+	Workspaces repositories.WorkspaceRepository
+	Tasks      repositories.TaskRepository
+	// Cache keeps rendered feeds. When nil, every request renders the feed.
+	Cache repositories.FeedCache
+}
+
+func NewCalendarService(users repositories.UserRepository, workspaces repositories.WorkspaceRepository, tasks repositories.TaskRepository) *CalendarService {
+	return &CalendarService{Users: users, Workspaces: workspaces, Tasks: tasks}
+}
+
+// RegenerateToken gives the user a new feed token, which stops the old feed
+// URL from working. The token is only returned here.
+func (s *CalendarService) RegenerateToken(userID uint) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(secret)
+
+	hash := hashFeedToken(token)
+	if err := s.Users.SetCalendarToken(userID, &hash); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeToken turns the user's feed off.
+func (s *CalendarService) RevokeToken(userID uint) error {
+	return s.Users.SetCalendarToken(userID, nil)
+}
+
+// Feed returns the feed of the user holding token, rendering it with render
+// unless an up to date copy is cached.
+func (s *CalendarService) Feed(token, feedType string, render FeedRenderer) ([]byte, error) {
+	if feedType != FeedEvents && feedType != FeedTodos {
+		return nil, ErrInvalidFeedType
+	}
+	if token == "" {
+		return nil, ErrFeedNotFound
+	}
+	user, err := s.Users.GetUserByCalendarToken(hashFeedToken(token))
+	if err != nil {
+		return nil, ErrFeedNotFound
+	}
+
+	memberships, err := s.Workspaces.GetMemberships(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := []uint{}
+	for _, membership := range memberships {
+		if models.RoleHasPermission(membership.Role, models.PermTaskRead) {
+			workspaceIDs = append(workspaceIDs, membership.WorkspaceID)
+		}
+	}
+
+	key := ""
+	if s.Cache != nil {
+		if key, err = s.feedKey(user.ID, feedType, workspaceIDs); err != nil {
+			log.Printf("[X] Failed to read calendar feed versions: %v\n", err)
+		} else if feed, ok, err := s.Cache.Get(key); err != nil {
+			log.Printf("[X] Failed to read cached calendar feed of user %d: %v\n", user.ID, err)
+		} else if ok {
+			return feed, nil
+		}
+	}
+
+	tasks := []models.Task{}
+	if len(workspaceIDs) > 0 {
+		if tasks, err = s.Tasks.GetCalendarTasks(workspaceIDs, time.Now().Add(-FeedHistory), MaxFeedTasks); err != nil {
+			return nil, err
+		}
+	}
+	feed, err := render(user, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	if key != "" {
+		if err := s.Cache.Set(key, feed, FeedCacheTTL); err != nil {
+			log.Printf("[X] Failed to cache calendar feed of user %d: %v\n", user.ID, err)
+		}
+	}
+	return feed, nil
+}
+
+// InvalidateWorkspace makes the feeds showing the workspace's tasks render
+// again on their next request.
+func (s *CalendarService) InvalidateWorkspace(workspaceID uint) {
+	if s.Cache == nil {
+		return
+	}
+	if err := s.Cache.Bump(workspaceID); err != nil {
+		log.Printf("[X] Failed to invalidate calendar feeds of workspace %d: %v\n", workspaceID, err)
+	}
+}
+
+// feedKey names a rendered feed after the workspace versions it shows, so a
+// change in any of them, or in the user's memberships, misses the cache.
+func (s *CalendarService) feedKey(userID uint, feedType string, workspaceIDs []uint) (string, error) {
+	versions, err := s.Cache.Versions(workspaceIDs)
+	if err != nil {
+		return "", err
+	}
+
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d:%s", userID, feedType)
+	for i, id := range workspaceIDs {
+		fmt.Fprintf(&key, ":%d.%d", id, versions[i])
+	}
+	return key.String(), nil
+}
+
+func hashFeedToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
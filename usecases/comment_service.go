@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+var (
+	ErrCommentNotFound   = errors.New("Comment not found")
+	ErrCommentParent     = errors.New("Parent comment not found on this task")
+	ErrCommentForbidden  = errors.New("Only the author can change this comment")
+	ErrCommentNotChanged = errors.New("Comment body is unchanged")
+)
+
+// MentionNotifier tells users they were @mentioned in a comment.
+type MentionNotifier interface {
+	NotifyMentioned(task *models.Task, comment *models.Comment, userID uint) error
+}
+
+type CommentService struct {
+	Repo  repositories.CommentRepository
+	Tasks repositories.TaskRepository
+	// Users resolves @mentions. When nil, mentions are rendered but nobody
+	// is notified.
+	Users    repositories.UserRepository
+	Mentions MentionNotifier
+	// Workspaces limits notifications to members of WorkspaceID. When nil,
+	// or when the service is not scoped to a workspace, it is skipped.
+	Workspaces  repositories.WorkspaceRepository
+	WorkspaceID uint
+}
+
+func NewCommentService(repo repositories.CommentRepository, tasks repositories.TaskRepository) *CommentService {
+	return &CommentService{Repo: repo, Tasks: tasks}
+}
+
+// InWorkspace returns a copy of the service that only sees comments on one
+// workspace's tasks.
+func (s *CommentService) InWorkspace(workspaceID uint) *CommentService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
+func (s *CommentService) GetComments(taskID uint) ([]models.Comment, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetComments(taskID)
+}
+
+// CreateComment adds a comment to a task and notifies the users it
+// mentions. A reply to a reply joins the thread of the top-level comment.
+func (s *CommentService) CreateComment(taskID uint, comment *models.Comment) error {
+	task, err := s.Tasks.GetTaskByID(taskID)
+	if err != nil {
+		return err
+	}
+
+	if comment.ParentID != nil {
+		parent, err := s.Repo.GetCommentByID(taskID, *comment.ParentID)
+		if err != nil {
+			return ErrCommentParent
+		}
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	comment.TaskID = taskID
+	if err := s.Repo.CreateComment(comment); err != nil {
+		return err
+	}
+	s.notifyMentions(task, comment, nil)
+	return nil
+}
+
+// UpdateComment changes the body of the author's own comment. The previous
+// body is kept in the comment's history, and only users who were not
+// mentioned before are notified.
+func (s *CommentService) UpdateComment(taskID, id uint, body string, userID uint) (*models.Comment, error) {
+	task, err := s.Tasks.GetTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.Repo.GetCommentByID(taskID, id)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+	if comment.UserID != userID {
+		return nil, ErrCommentForbidden
+	}
+	if comment.Body == body {
+		return nil, ErrCommentNotChanged
+	}
+
+	previous := utils.ParseMentions(comment.Body)
+	if err := s.Repo.UpdateComment(comment, body, userID); err != nil {
+		return nil, err
+	}
+	s.notifyMentions(task, comment, previous)
+	return comment, nil
+}
+
+// DeleteComment deletes a comment and its replies. Authors may delete their
+// own comments; moderators may delete anyone's.
+func (s *CommentService) DeleteComment(taskID, id, userID uint, moderator bool) error {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return err
+	}
+	comment, err := s.Repo.GetCommentByID(taskID, id)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+	if comment.UserID != userID && !moderator {
+		return ErrCommentForbidden
+	}
+	return s.Repo.DeleteComment(taskID, id)
+}
+
+// GetHistory returns a comment with its earlier bodies, newest first.
+func (s *CommentService) GetHistory(taskID, id uint) (*models.Comment, []models.CommentRevision, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, nil, err
+	}
+	comment, err := s.Repo.GetCommentByID(taskID, id)
+	if err != nil {
+		return nil, nil, ErrCommentNotFound
+	}
+	revisions, err := s.Repo.GetRevisions(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return comment, revisions, nil
+}
+
+// notifyMentions notifies the users mentioned in a comment, except its
+// author, users in skip and, in a workspace, non-members. Failures are
+// logged; they don't undo the comment.
+func (s *CommentService) notifyMentions(task *models.Task, comment *models.Comment, skip []string) {
+	if s.Users == nil || s.Mentions == nil {
+		return
+	}
+
+	skipped := make(map[string]bool, len(skip))
+	for _, username := range skip {
+		skipped[username] = true
+	}
+	var usernames []string
+	for _, username := range utils.ParseMentions(comment.Body) {
+		if !skipped[username] {
+			usernames = append(usernames, username)
+		}
+	}
+	if len(usernames) == 0 {
+		return
+	}
+
+	users, err := s.Users.GetUsersByUsernames(usernames)
+	if err != nil {
+		log.Printf("[X] Failed to resolve mentions in comment %d: %v\n", comment.ID, err)
+		return
+	}
+	members, err := s.memberIDs(users)
+	if err != nil {
+		log.Printf("[X] Failed to check mentioned members in comment %d: %v\n", comment.ID, err)
+		return
+	}
+
+	for _, user := range users {
+		if user.ID == comment.UserID || (members != nil && !members[user.ID]) {
+			continue
+		}
+		if err := s.Mentions.NotifyMentioned(task, comment, user.ID); err != nil {
+			log.Printf("[X] Failed to notify user %d about comment %d: %v\n", user.ID, comment.ID, err)
+		}
+	}
+}
+
+// memberIDs returns which of users belong to the service's workspace, or nil
+// when membership is not checked.
+func (s *CommentService) memberIDs(users []models.User) (map[uint]bool, error) {
+	if s.Workspaces == nil || s.WorkspaceID == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	members, err := s.Workspaces.GetMembersByUserIDs(s.WorkspaceID, ids)
+	if err != nil {
+		return nil, err
+	}
+	memberIDs := make(map[uint]bool, len(members))
+	for _, member := range members {
+		memberIDs[member.UserID] = true
+	}
+	return memberIDs, nil
+}
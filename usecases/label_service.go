@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+var ErrLabelExists = errors.New("A label with this name already exists")
+
+type LabelService struct {
+	Repo repositories.LabelRepository
+}
+
+func NewLabelService(repo repositories.LabelRepository) *LabelService {
+	return &LabelService{Repo: repo}
+}
+
+// InWorkspace returns a copy of the service that only manages the labels of
+// one workspace.
+func (s *LabelService) InWorkspace(workspaceID uint) *LabelService {
+	return &LabelService{Repo: s.Repo.InWorkspace(workspaceID)}
+}
+
+func (s *LabelService) GetLabels() ([]models.Label, error) {
+	return s.Repo.GetLabels()
+}
+
+func (s *LabelService) CreateLabel(label *models.Label) error {
+	if existing, err := s.Repo.GetLabelByName(label.Name); err == nil && existing != nil {
+		return ErrLabelExists
+	}
+	return s.Repo.CreateLabel(label)
+}
+
+func (s *LabelService) GetLabelByID(id uint) (*models.Label, error) {
+	return s.Repo.GetLabelByID(id)
+}
+
+func (s *LabelService) UpdateLabel(id uint, updatedLabel *models.Label) error {
+	if existing, err := s.Repo.GetLabelByName(updatedLabel.Name); err == nil && existing != nil && existing.ID != id {
+		return ErrLabelExists
+	}
+	return s.Repo.UpdateLabel(id, updatedLabel)
+}
+
+func (s *LabelService) DeleteLabel(id uint) error {
+	return s.Repo.DeleteLabel(id)
+}
+
+// UsageCounts returns how many tasks carry each label.
+func (s *LabelService) UsageCounts() (map[uint]int, error) {
+	return s.Repo.CountUsage()
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// nextTaskCandidates caps how many open tasks are scored for NextTasks. The
+// database picks them by the same measures, so the best ones are among them.
+const nextTaskCandidates = 500
+
+var priorityWeights = map[string]float64{
+	models.PriorityNone:   0,
+	models.PriorityLow:    10,
+	models.PriorityMedium: 20,
+	models.PriorityHigh:   30,
+	models.PriorityUrgent: 50,
+}
+
+type ScoredTask struct {
+	Task  models.Task
+	Score float64
+}
+
+// ScoreTask ranks an open task for "what should I do next". Priority
+// weighs the most, then how close (or how far past) the due date is, and
+// finally the task's age so old tasks slowly bubble up.
+func ScoreTask(task *models.Task, now time.Time) float64 {
+	score := priorityWeights[task.Priority]
+
+	if !task.DueDate.IsZero() {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		daysLeft := task.DueDate.Deadline(now.Location()).Sub(today).Hours() / 24
+		if daysLeft < 0 {
+			score += 30 + math.Min(-daysLeft, 14)
+		} else {
+			score += math.Max(0, 25-2.5*daysLeft)
+		}
+	}
+
+	if !task.CreatedAt.IsZero() {
+		ageDays := now.Sub(task.CreatedAt).Hours() / 24
+		score += math.Min(math.Max(ageDays, 0), 30) * 0.3
+	}
+
+	return math.Round(score*100) / 100
+}
+
+// NextTasks returns the open tasks to work on next, best first.
+func (s *TaskService) NextTasks(filter models.TaskFilter, limit int) ([]ScoredTask, error) {
+	filter.StatusCategory = models.StatusCategoryOpen
+	if err := ValidateTaskFilter(filter); err != nil {
+		return nil, err
+	}
+	tasks, _, err := s.Repo.FindTasks(filter, repositories.SortUrgency, 1, nextTaskCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scored := make([]ScoredTask, len(tasks))
+	for i, task := range tasks {
+		scored[i] = ScoredTask{Task: task, Score: ScoreTask(&task, now)}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored, nil
+}
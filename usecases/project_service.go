@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+type ProjectService struct {
+	Repo  repositories.ProjectRepository
+	Tasks *TaskService
+	// Workflows is used to check the workflow assigned to a project. When
+	// nil, workflow IDs are stored without checks.
+	Workflows repositories.WorkflowRepository
+}
+
+func NewProjectService(repo repositories.ProjectRepository, tasks *TaskService) *ProjectService {
+	return &ProjectService{Repo: repo, Tasks: tasks}
+}
+
+// InWorkspace returns a copy of the service that only manages the projects
+// of one workspace, and lists and creates project tasks there.
+func (s *ProjectService) InWorkspace(workspaceID uint) *ProjectService {
+	scoped := *s
+	scoped.Repo = s.Repo.InWorkspace(workspaceID)
+	if s.Workflows != nil {
+		scoped.Workflows = s.Workflows.InWorkspace(workspaceID)
+	}
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	return &scoped
+}
+
+// As returns a copy of the service that attributes the project tasks it
+// creates to ctx.
+func (s *ProjectService) As(ctx ChangeContext) *ProjectService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.As(ctx)
+	return &scoped
+}
+
+func (s *ProjectService) GetProjects(includeArchived bool) ([]models.Project, error) {
+	return s.Repo.GetProjects(includeArchived)
+}
+
+func (s *ProjectService) CreateProject(project *models.Project) error {
+	if err := s.checkWorkflow(project.WorkflowID); err != nil {
+		return err
+	}
+	return s.Repo.CreateProject(project)
+}
+
+func (s *ProjectService) GetProjectByID(id uint) (*models.Project, error) {
+	return s.Repo.GetProjectByID(id)
+}
+
+func (s *ProjectService) UpdateProject(id uint, updatedProject *models.Project) error {
+	if err := s.checkWorkflow(updatedProject.WorkflowID); err != nil {
+		return err
+	}
+	return s.Repo.UpdateProject(id, updatedProject)
+}
+
+func (s *ProjectService) DeleteProject(id uint) error {
+	return s.Repo.DeleteProject(id)
+}
+
+// TaskCounts returns, per project, the number of tasks in each status.
+func (s *ProjectService) TaskCounts(projects []models.Project) (map[uint]map[string]int, error) {
+	if len(projects) == 0 {
+		return map[uint]map[string]int{}, nil
+	}
+
+	ids := make([]uint, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+	return s.Repo.CountTasksByStatus(ids)
+}
+
+func (s *ProjectService) GetProjectTasks(id uint, filter models.TaskFilter, sort string, page, limit int) ([]models.Task, int, error) {
+	if _, err := s.Repo.GetProjectByID(id); err != nil {
+		return nil, 0, ErrProjectNotFound
+	}
+	filter.ProjectID = id
+	return s.Tasks.FindTasks(filter, sort, page, limit)
+}
+
+// CreateProjectTask creates a task inside the given project, which must
+// belong to the service's workspace.
+func (s *ProjectService) CreateProjectTask(id uint, task *models.Task) error {
+	if _, err := s.Repo.GetProjectByID(id); err != nil {
+		return ErrProjectNotFound
+	}
+	task.ProjectID = &id
+	return s.Tasks.CreateTask(task)
+}
+
+func (s *ProjectService) checkWorkflow(workflowID *uint) error {
+	if workflowID == nil || s.Workflows == nil {
+		return nil
+	}
+	if _, err := s.Workflows.GetWorkflowByID(*workflowID); err != nil {
+		return ErrWorkflowNotFound
+	}
+	return nil
+}
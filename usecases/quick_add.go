@@ -0,0 +1,513 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+	"gorm.io/gorm"
+)
+
+var ErrQuickAddNoTitle = errors.New("Quick add text needs a title")
+
+// QuickAdd is a task parsed from a line like
+// "Pay rent every 1st of month #finance !high".
+type QuickAdd struct {
+	Title      string
+	DueDate    models.DueDate
+	Recurrence string
+	Labels     []string
+	Priority   string
+}
+
+var (
+	weekdayNames = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+	monthNames = map[string]time.Month{
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	}
+	rruleDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+	ordinalPattern  = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)?$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	isoDatePattern  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	quickAddSpacing = regexp.MustCompile(`\s+`)
+)
+
+// quickAddParser walks the words of a quick-add line. Words that are not
+// part of a recognised phrase make up the title.
+type quickAddParser struct {
+	words []string
+	now   time.Time
+	date  *time.Time
+	clock *[2]int
+	// tonight defaults the time to the evening unless one is given.
+	tonight bool
+	title   []string
+	task    QuickAdd
+}
+
+// ParseQuickAdd extracts a task from free text. Relative dates are resolved
+// against now, in now's location.
+func ParseQuickAdd(text string, now time.Time) (*QuickAdd, error) {
+	p := &quickAddParser{words: strings.Fields(text), now: now}
+
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+		p.title = append(p.title, p.words[i])
+		i++
+	}
+
+	p.task.Title = strings.TrimSpace(quickAddSpacing.ReplaceAllString(strings.Join(p.title, " "), " "))
+	if p.task.Title == "" {
+		return nil, ErrQuickAddNoTitle
+	}
+	if err := p.resolveDueDate(); err != nil {
+		return nil, err
+	}
+	return &p.task, nil
+}
+
+// match tries each phrase at word i and returns how many words it used.
+func (p *quickAddParser) match(i int) int {
+	word := p.words[i]
+	if strings.HasPrefix(word, "#") && len(word) > 1 {
+		p.task.Labels = appendUnique(p.task.Labels, strings.TrimRight(word[1:], ",.;"))
+		return 1
+	}
+	if priority := strings.ToLower(strings.TrimRight(strings.TrimPrefix(word, "!"), ",.;")); word[0] == '!' && models.PriorityRank(priority) >= 0 {
+		p.task.Priority = priority
+		return 1
+	}
+
+	if n := p.matchRecurrence(i); n > 0 {
+		return n
+	}
+
+	// "on friday", "by tomorrow", "due jan 6", "at 5pm"
+	offset := 0
+	switch p.word(i) {
+	case "on", "by", "due":
+		offset = 1
+	case "at":
+		if n := p.matchTime(i + 1); n > 0 {
+			return n + 1
+		}
+		return 0
+	}
+	if n := p.matchDate(i+offset, offset == 1); n > 0 {
+		return n + offset
+	}
+	if n := p.matchTime(i + offset); n > 0 {
+		return n + offset
+	}
+	return 0
+}
+
+// word returns the normalised word at i, or "" past the end.
+func (p *quickAddParser) word(i int) string {
+	if i < 0 || i >= len(p.words) {
+		return ""
+	}
+	return strings.ToLower(strings.Trim(p.words[i], ",.;"))
+}
+
+func (p *quickAddParser) matchRecurrence(i int) int {
+	if p.task.Recurrence != "" {
+		return 0
+	}
+
+	switch p.word(i) {
+	case "daily":
+		p.task.Recurrence = "FREQ=DAILY"
+		return 1
+	case "weekly":
+		p.task.Recurrence = "FREQ=WEEKLY"
+		return 1
+	case "monthly":
+		p.task.Recurrence = "FREQ=MONTHLY"
+		return 1
+	case "yearly", "annually":
+		p.task.Recurrence = "FREQ=YEARLY"
+		return 1
+	case "weekdays":
+		p.task.Recurrence = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+		return 1
+	case "every":
+	default:
+		return 0
+	}
+
+	n := 1
+	interval := 1
+	if value, err := strconv.Atoi(p.word(i + n)); err == nil && value > 0 {
+		interval = value
+		n++
+	}
+	withInterval := func(freq string) string {
+		if interval > 1 {
+			return fmt.Sprintf("FREQ=%s;INTERVAL=%d", freq, interval)
+		}
+		return "FREQ=" + freq
+	}
+
+	switch p.word(i + n) {
+	case "day", "days":
+		p.task.Recurrence = withInterval("DAILY")
+		return n + 1
+	case "week", "weeks":
+		p.task.Recurrence = withInterval("WEEKLY")
+		return n + 1
+	case "month", "months":
+		p.task.Recurrence = withInterval("MONTHLY")
+		return n + 1
+	case "year", "years":
+		p.task.Recurrence = withInterval("YEARLY")
+		return n + 1
+	case "weekday", "weekdays":
+		p.task.Recurrence = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+		return n + 1
+	case "last":
+		if p.word(i+n+1) == "day" {
+			p.task.Recurrence = "FREQ=MONTHLY;BYMONTHDAY=-1"
+			return n + 2 + p.matchOfMonth(i+n+2)
+		}
+		return 0
+	}
+
+	if interval == 1 {
+		// "every 1st of month": the number was read as an interval.
+		n = 1
+	}
+	if match := ordinalPattern.FindStringSubmatch(p.word(i + n)); match != nil && match[2] != "" {
+		day, _ := strconv.Atoi(match[1])
+		if day < 1 || day > 31 {
+			return 0
+		}
+		p.task.Recurrence = fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", day)
+		return n + 1 + p.matchOfMonth(i+n+1)
+	}
+
+	// "every monday", "every mon and thu", "every tue, fri"
+	var days []string
+	for {
+		weekday, ok := weekdayNames[strings.TrimSuffix(p.word(i+n), "s")]
+		if !ok {
+			break
+		}
+		days = append(days, rruleDays[weekday])
+		n++
+		if p.word(i+n) == "and" {
+			if _, ok := weekdayNames[strings.TrimSuffix(p.word(i+n+1), "s")]; ok {
+				n++
+			}
+		}
+	}
+	if len(days) == 0 {
+		return 0
+	}
+	p.task.Recurrence = "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ",")
+	return n
+}
+
+// matchOfMonth consumes a trailing "of month" or "of the month".
+func (p *quickAddParser) matchOfMonth(i int) int {
+	if p.word(i) != "of" {
+		return 0
+	}
+	if p.word(i+1) == "month" {
+		return 2
+	}
+	if p.word(i+1) == "the" && p.word(i+2) == "month" {
+		return 3
+	}
+	return 0
+}
+
+// matchDate reads a date phrase. Abbreviated weekdays ("fri") only count
+// after "on", "by" or "due" so short title words aren't taken for dates.
+func (p *quickAddParser) matchDate(i int, explicit bool) int {
+	if p.date != nil {
+		return 0
+	}
+
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	word := p.word(i)
+
+	switch word {
+	case "today":
+		p.setDate(today)
+		return 1
+	case "tonight":
+		p.setDate(today)
+		p.tonight = true
+		return 1
+	case "tomorrow", "tmr", "tmrw":
+		p.setDate(today.AddDate(0, 0, 1))
+		return 1
+	case "next":
+		next := p.word(i + 1)
+		if weekday, ok := weekdayNames[next]; ok {
+			// The given weekday in the following Monday-based week.
+			monday := today.AddDate(0, 0, -((int(today.Weekday())+6)%7)+7)
+			p.setDate(monday.AddDate(0, 0, (int(weekday)+6)%7))
+			return 2
+		}
+		switch next {
+		case "week":
+			p.setDate(today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7))
+			return 2
+		case "month":
+			p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()))
+			return 2
+		}
+		return 0
+	case "in":
+		amount, err := strconv.Atoi(p.word(i + 1))
+		if err != nil || amount < 1 {
+			return 0
+		}
+		switch strings.TrimSuffix(p.word(i+2), "s") {
+		case "day":
+			p.setDate(today.AddDate(0, 0, amount))
+		case "week":
+			p.setDate(today.AddDate(0, 0, 7*amount))
+		case "month":
+			p.setDate(today.AddDate(0, amount, 0))
+		default:
+			return 0
+		}
+		return 3
+	}
+
+	if weekday, ok := weekdayNames[word]; ok && (explicit || strings.HasSuffix(word, "day")) {
+		p.setDate(today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7))
+		return 1
+	}
+
+	if isoDatePattern.MatchString(word) {
+		date, err := time.ParseInLocation("2006-01-02", word, today.Location())
+		if err != nil {
+			return 0
+		}
+		p.setDate(date)
+		return 1
+	}
+
+	// "jan 6", "january 6th", "6 jan", "6th of january"
+	if month, ok := monthNames[word]; ok {
+		if match := ordinalPattern.FindStringSubmatch(p.word(i + 1)); match != nil {
+			day, _ := strconv.Atoi(match[1])
+			return p.setMonthDay(today, month, day, 2)
+		}
+		return 0
+	}
+	if match := ordinalPattern.FindStringSubmatch(word); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		n := 1
+		if p.word(i+n) == "of" {
+			n++
+		}
+		if month, ok := monthNames[p.word(i+n)]; ok {
+			return p.setMonthDay(today, month, day, n+1)
+		}
+	}
+	return 0
+}
+
+// setMonthDay picks the next date with the given month and day, rolling
+// over to next year once it has passed.
+func (p *quickAddParser) setMonthDay(today time.Time, month time.Month, day, consumed int) int {
+	date := time.Date(today.Year(), month, day, 0, 0, 0, 0, today.Location())
+	if date.Month() != month {
+		return 0
+	}
+	if date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	p.setDate(date)
+	return consumed
+}
+
+func (p *quickAddParser) setDate(date time.Time) {
+	p.date = &date
+}
+
+func (p *quickAddParser) matchTime(i int) int {
+	if p.clock != nil {
+		return 0
+	}
+
+	word := p.word(i)
+	switch word {
+	case "noon", "midday":
+		p.clock = &[2]int{12, 0}
+		return 1
+	case "midnight":
+		p.clock = &[2]int{23, 59}
+		return 1
+	}
+
+	n := 1
+	if next := p.word(i + 1); next == "am" || next == "pm" {
+		word += next
+		n++
+	}
+
+	match := clockPattern.FindStringSubmatch(word)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		// A bare number is not a time.
+		return 0
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0
+		}
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0
+	}
+
+	p.clock = &[2]int{hour, minute}
+	return n
+}
+
+// resolveDueDate combines the parsed date, time and recurrence. A time on
+// its own means the next time that clock comes round; a recurring task
+// without a date is due on the rule's first date.
+func (p *quickAddParser) resolveDueDate() error {
+	date := p.date
+	if date == nil && p.task.Recurrence != "" {
+		rule, err := utils.ParseRRule(p.task.Recurrence)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+		}
+		if first, ok := rule.Next(p.now, p.now.AddDate(0, 0, -1), 0); ok {
+			local := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, p.now.Location())
+			date = &local
+		}
+	}
+
+	if p.clock == nil && p.tonight {
+		p.clock = &[2]int{20, 0}
+	}
+	if p.clock == nil {
+		if date != nil {
+			p.task.DueDate = models.DateOnly(*date)
+		}
+		return nil
+	}
+
+	if date == nil {
+		at := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), p.clock[0], p.clock[1], 0, 0, p.now.Location())
+		if !at.After(p.now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		p.task.DueDate = models.DueDate{Time: &at, HasTime: true}
+		return nil
+	}
+
+	at := time.Date(date.Year(), date.Month(), date.Day(), p.clock[0], p.clock[1], 0, 0, p.now.Location())
+	p.task.DueDate = models.DueDate{Time: &at, HasTime: true}
+	return nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if strings.EqualFold(existing, value) {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// QuickAddTask creates the task described by a parsed quick-add line.
+// Labels are matched by name, and missing ones are created.
+func (s *TaskService) QuickAddTask(parsed *QuickAdd, projectID *uint) (*models.Task, error) {
+	task := &models.Task{
+		Title:      parsed.Title,
+		DueDate:    parsed.DueDate,
+		Recurrence: parsed.Recurrence,
+		Priority:   parsed.Priority,
+		ProjectID:  projectID,
+	}
+
+	// Labels created for the task are rolled back if the task isn't created.
+	err := s.inTransaction(func(tx *TaskService) error {
+		labelIDs, err := tx.labelIDsByName(parsed.Labels)
+		if err != nil {
+			return err
+		}
+		if err := tx.CreateTask(task); err != nil {
+			return err
+		}
+		if len(labelIDs) > 0 {
+			return tx.Repo.AddLabels(task.ID, labelIDs)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.GetTaskByID(task.ID)
+}
+
+func (s *TaskService) labelIDsByName(names []string) ([]uint, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if s.Labels == nil {
+		return nil, ErrLabelNotFound
+	}
+
+	ids := make([]uint, 0, len(names))
+	for _, name := range names {
+		label, err := s.Labels.GetLabelByName(name)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			label = &models.Label{Name: name}
+			err = s.Labels.CreateLabel(label)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, label.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,319 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+var (
+	ErrInvalidReminder = errors.New("Invalid reminder")
+	ErrUnknownChannel  = errors.New("Unknown reminder channel")
+)
+
+const (
+	// reminderBatchSize caps how many reminders one worker tick claims.
+	reminderBatchSize = 100
+	// reminderLease is how long a claimed reminder may take before another
+	// instance is allowed to pick it up again.
+	reminderLease = 2 * time.Minute
+	// deliveryLease is how long one delivery may take before another worker
+	// may retry it. A whole batch can outlast reminderLease, so each reminder
+	// is also claimed in the database right before it is delivered.
+	deliveryLease = time.Minute
+	// maxReminderAttempts is how often delivery is tried before giving up.
+	maxReminderAttempts = 5
+)
+
+// ReminderChannel delivers a fired reminder, e.g. by webhook or email.
+type ReminderChannel interface {
+	Deliver(reminder *models.Reminder, task *models.Task) error
+}
+
+// ReminderScheduler is what TaskService needs to keep reminders in step with
+// due dates.
+type ReminderScheduler interface {
+	RescheduleTask(task *models.Task) error
+}
+
+type ReminderService struct {
+	Repo     repositories.ReminderRepository
+	Queue    repositories.ReminderQueue
+	Tasks    repositories.TaskRepository
+	Channels map[string]ReminderChannel
+	// Users gives the timezone in which offsets from a date without a time
+	// are counted: that of the user the reminder is for. When nil, or for
+	// users without a valid timezone, UTC is used.
+	Users repositories.UserRepository
+}
+
+func NewReminderService(repo repositories.ReminderRepository, queue repositories.ReminderQueue, tasks repositories.TaskRepository) *ReminderService {
+	return &ReminderService{
+		Repo:     repo,
+		Queue:    queue,
+		Tasks:    tasks,
+		Channels: map[string]ReminderChannel{},
+	}
+}
+
+// InWorkspace returns a copy of the service that only manages reminders on
+// one workspace's tasks.
+func (s *ReminderService) InWorkspace(workspaceID uint) *ReminderService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	return &scoped
+}
+
+// RegisterChannel makes a delivery channel available to reminders.
+func (s *ReminderService) RegisterChannel(name string, channel ReminderChannel) {
+	s.Channels[name] = channel
+}
+
+// CreateReminder schedules a reminder for a task. Exactly one of RemindAt
+// and OffsetMinutes must be set; an offset needs the task to have a due date.
+func (s *ReminderService) CreateReminder(taskID uint, reminder *models.Reminder) error {
+	task, err := s.Tasks.GetTaskByID(taskID)
+	if err != nil {
+		return err
+	}
+	if _, ok := s.Channels[reminder.Channel]; !ok {
+		return ErrUnknownChannel
+	}
+
+	reminder.TaskID = taskID
+	reminder.Status = models.ReminderPending
+	fireAt, err := reminderFireAt(reminder, task, s.location(reminder.UserID))
+	if err != nil {
+		return err
+	}
+	if !fireAt.After(time.Now()) {
+		return fmt.Errorf("%w: reminder time has already passed", ErrInvalidReminder)
+	}
+	reminder.FireAt = fireAt
+
+	if err := s.Repo.CreateReminder(reminder); err != nil {
+		return err
+	}
+	return s.Queue.Schedule(reminder.ID, reminder.FireAt)
+}
+
+func (s *ReminderService) GetReminders(taskID uint) ([]models.Reminder, error) {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetReminders(taskID)
+}
+
+func (s *ReminderService) DeleteReminder(taskID, id uint) error {
+	if _, err := s.Tasks.GetTaskByID(taskID); err != nil {
+		return err
+	}
+	if err := s.Repo.DeleteReminder(taskID, id); err != nil {
+		return err
+	}
+	return s.Queue.Remove(id)
+}
+
+// RescheduleTask moves the pending offset reminders of a task after its due
+// date changed. Reminders whose new time has passed fire on the next tick.
+func (s *ReminderService) RescheduleTask(task *models.Task) error {
+	reminders, err := s.Repo.GetPendingOffsetReminders(task.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range reminders {
+		reminder := &reminders[i]
+		fireAt, err := reminderFireAt(reminder, task, s.location(reminder.UserID))
+		if err != nil {
+			reminder.Status = models.ReminderSkipped
+			reminder.LastError = "task no longer has a due date"
+			if err := s.Repo.UpdateReminder(reminder); err != nil {
+				return err
+			}
+			if err := s.Queue.Remove(reminder.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		reminder.FireAt = fireAt
+		if err := s.Repo.UpdateReminder(reminder); err != nil {
+			return err
+		}
+		if err := s.Queue.Schedule(reminder.ID, fireAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ProcessDue fires the reminders that are due by now and returns how many
+// were delivered. Each reminder is claimed from the queue by one instance
+// only.
+func (s *ReminderService) ProcessDue(now time.Time) (int, error) {
+	ids, err := s.Queue.ClaimDue(now, reminderBatchSize, reminderLease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, id := range ids {
+		delivered, err := s.fire(id, now)
+		if err != nil {
+			log.Printf("[X] Failed to process reminder %d: %v\n", id, err)
+			continue
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// RunWorker calls ProcessDue every interval. It blocks, so run it in its own
+// goroutine.
+func (s *ReminderService) RunWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		sent, err := s.ProcessDue(time.Now())
+		if err != nil {
+			log.Printf("[X] Reminder worker failed: %v\n", err)
+			continue
+		}
+		if sent > 0 {
+			log.Printf("[V] Reminder worker sent %d reminder(s)\n", sent)
+		}
+	}
+}
+
+// fire delivers one claimed reminder. Failed deliveries are retried with a
+// growing delay until maxReminderAttempts is reached.
+func (s *ReminderService) fire(id uint, now time.Time) (bool, error) {
+	claimed, err := s.Repo.ClaimReminder(id, time.Now(), deliveryLease)
+	if err != nil {
+		return false, err
+	}
+
+	reminder, err := s.Repo.GetReminderByID(id)
+	if err != nil {
+		// The reminder was deleted after it was queued.
+		return false, s.Queue.Ack(id)
+	}
+	if reminder.Status != models.ReminderPending {
+		return false, s.Queue.Ack(id)
+	}
+	if !claimed {
+		// Another worker is delivering it and will ack it when done.
+		return false, nil
+	}
+	// Saving the reminder releases the claim.
+	reminder.ClaimedUntil = nil
+
+	task, err := s.Tasks.GetTaskByID(reminder.TaskID)
+	if err != nil || task.StatusCategory != models.StatusCategoryOpen {
+		reminder.Status = models.ReminderSkipped
+		return false, s.finish(reminder)
+	}
+
+	channel, ok := s.Channels[reminder.Channel]
+	if !ok {
+		reminder.Status = models.ReminderFailed
+		reminder.LastError = ErrUnknownChannel.Error()
+		return false, s.finish(reminder)
+	}
+
+	reminder.Attempts++
+	if err := channel.Deliver(reminder, task); err != nil {
+		reminder.LastError = err.Error()
+		if reminder.Attempts >= maxReminderAttempts {
+			reminder.Status = models.ReminderFailed
+			return false, s.finish(reminder)
+		}
+
+		retryAt := now.Add(time.Duration(reminder.Attempts*reminder.Attempts) * time.Minute)
+		if err := s.Repo.UpdateReminder(reminder); err != nil {
+			return false, err
+		}
+		if err := s.Queue.Schedule(reminder.ID, retryAt); err != nil {
+			return false, err
+		}
+		return false, s.Queue.Ack(reminder.ID)
+	}
+
+	reminder.Status = models.ReminderSent
+	reminder.LastError = ""
+	reminder.SentAt = &now
+	return true, s.finish(reminder)
+}
+
+func (s *ReminderService) finish(reminder *models.Reminder) error {
+	if err := s.Repo.UpdateReminder(reminder); err != nil {
+		return err
+	}
+	return s.Queue.Ack(reminder.ID)
+}
+
+func (s *ReminderService) GetNotifications(userID uint, unreadOnly bool) ([]models.Notification, error) {
+	return s.Repo.GetNotifications(userID, unreadOnly)
+}
+
+func (s *ReminderService) MarkNotificationRead(userID, id uint) error {
+	return s.Repo.MarkNotificationRead(userID, id)
+}
+
+// NotifyAssigned leaves an in-app notification for a user assigned to a
+// task.
+func (s *ReminderService) NotifyAssigned(task *models.Task, userID, assignedBy uint) error {
+	return s.Repo.CreateNotification(&models.Notification{
+		UserID:  userID,
+		TaskID:  task.ID,
+		Message: fmt.Sprintf("You were assigned to \"%s\"", task.Title),
+	})
+}
+
+// NotifyMentioned leaves an in-app notification for a user mentioned in a
+// comment.
+func (s *ReminderService) NotifyMentioned(task *models.Task, comment *models.Comment, userID uint) error {
+	return s.Repo.CreateNotification(&models.Notification{
+		UserID:  userID,
+		TaskID:  task.ID,
+		Message: fmt.Sprintf("%s mentioned you on \"%s\"", comment.User.Username, task.Title),
+	})
+}
+
+// location returns the timezone of the user a reminder is for.
+func (s *ReminderService) location(userID uint) *time.Location {
+	if s.Users == nil || userID == 0 {
+		return time.UTC
+	}
+	user, err := s.Users.GetUserByID(userID)
+	if err != nil || user.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func reminderFireAt(reminder *models.Reminder, task *models.Task, loc *time.Location) (time.Time, error) {
+	if (reminder.RemindAt == nil) == (reminder.OffsetMinutes == nil) {
+		return time.Time{}, fmt.Errorf("%w: set either remind_at or offset_minutes", ErrInvalidReminder)
+	}
+	if reminder.RemindAt != nil {
+		return *reminder.RemindAt, nil
+	}
+
+	if task.DueDate.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: task has no due date", ErrInvalidReminder)
+	}
+	return task.DueDate.Deadline(loc).Add(-time.Duration(*reminder.OffsetMinutes) * time.Minute), nil
+}
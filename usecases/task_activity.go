@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"log"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// taskField is a task field the activity log keeps track of.
+type taskField struct {
+	name  string
+	value func(task *models.Task) interface{}
+}
+
+// trackedTaskFields are diffed in this order.
+var trackedTaskFields = []taskField{
+	{"title", func(task *models.Task) interface{} { return task.Title }},
+	{"description", func(task *models.Task) interface{} { return task.Description }},
+	{"status", func(task *models.Task) interface{} { return task.Status }},
+	{"priority", func(task *models.Task) interface{} { return task.Priority }},
+	{"due_date", func(task *models.Task) interface{} {
+		if task.DueDate.IsZero() {
+			return nil
+		}
+		return task.DueDate.String()
+	}},
+	{"project_id", func(task *models.Task) interface{} { return optionalID(task.ProjectID) }},
+	{"parent_id", func(task *models.Task) interface{} { return optionalID(task.ParentID) }},
+	{"auto_complete", func(task *models.Task) interface{} { return task.AutoComplete }},
+}
+
+// DiffTasks lists the tracked fields that differ between two versions of a
+// task. With before nil it lists the fields a new task has set; with after
+// nil, those a deleted task had.
+func DiffTasks(before, after *models.Task) []models.FieldChange {
+	changes := []models.FieldChange{}
+	for _, field := range trackedTaskFields {
+		var from, to interface{}
+		if before != nil {
+			from = field.value(before)
+		}
+		if after != nil {
+			to = field.value(after)
+		}
+		if from == to || (isBlank(from) && isBlank(to)) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Field: field.name, From: from, To: to})
+	}
+	return changes
+}
+
+// As returns a copy of the service that attributes changes made without an
+// explicit ChangeContext to ctx.
+func (s *TaskService) As(ctx ChangeContext) *TaskService {
+	scoped := *s
+	scoped.Actor = ctx
+	return &scoped
+}
+
+// invalidateFeeds makes the calendar feeds showing the workspace render
+// again once the current change commits.
+func (s *TaskService) invalidateFeeds(workspaceID uint) {
+	if s.Feeds != nil {
+		s.afterCommit(func() { s.Feeds.InvalidateWorkspace(workspaceID) })
+	}
+}
+
+// recordActivity adds an entry to the task's activity log and, for changes
+// to its content, a new revision. It also invalidates the calendar feeds
+// showing the task. Failures are logged; they don't undo the change.
+func (s *TaskService) recordActivity(task *models.Task, action string, changes []models.FieldChange, ctx ChangeContext) {
+	if ctx.UserID == 0 && ctx.RequestID == "" {
+		ctx = s.Actor
+	}
+	s.invalidateFeeds(task.WorkspaceID)
+	if revisedActions[action] {
+		s.recordRevision(task.ID, action, ctx)
+	}
+	if s.Activity == nil {
+		return
+	}
+
+	activity := &models.TaskActivity{
+		TaskID:      task.ID,
+		WorkspaceID: task.WorkspaceID,
+		Action:      action,
+		RequestID:   ctx.RequestID,
+		Changes:     changes,
+	}
+	if ctx.UserID != 0 {
+		activity.UserID = &ctx.UserID
+	}
+	s.afterCommit(func() {
+		if err := s.Activity.RecordActivity(activity); err != nil {
+			log.Printf("[X] Failed to record %s activity of task %d: %v\n", action, task.ID, err)
+		}
+	})
+}
+
+// recordActivityOf loads the task before recording, for changes that don't
+// load it themselves.
+func (s *TaskService) recordActivityOf(id uint, action string, changes []models.FieldChange) {
+	if s.Activity == nil && s.Revisions == nil {
+		return
+	}
+	task, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		log.Printf("[X] Failed to load task %d for its activity log: %v\n", id, err)
+		return
+	}
+	s.recordActivity(task, action, changes, ChangeContext{})
+}
+
+func optionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// isBlank treats empty strings and false like a missing value, so creating
+// or deleting a task doesn't list every unset field.
+func isBlank(value interface{}) bool {
+	return value == nil || value == "" || value == false
+}
+
+// recordUpdate records a saved update. It is a transition when the status is
+// the only field that changed.
+func (s *TaskService) recordUpdate(current, updatedTask *models.Task, statusChanged bool, ctx ChangeContext) {
+	// Only the fields taskRepository.UpdateTask writes can have changed.
+	after := *current
+	after.Title = updatedTask.Title
+	after.Description = updatedTask.Description
+	after.Status = updatedTask.Status
+	after.Priority = updatedTask.Priority
+	after.AutoComplete = updatedTask.AutoComplete
+	after.DueDate = updatedTask.DueDate
+
+	changes := DiffTasks(current, &after)
+	if len(changes) == 0 {
+		return
+	}
+	action := models.ActivityUpdated
+	if statusChanged && len(changes) == 1 {
+		action = models.ActivityTransitioned
+	}
+	s.recordActivity(current, action, changes, ctx)
+}
+
+// loadForActivity returns the task as it is before a change, or nil when
+// neither activity nor revisions are recorded and the change doesn't need it.
+func (s *TaskService) loadForActivity(id uint) (*models.Task, error) {
+	if s.Activity == nil && s.Revisions == nil {
+		return nil, nil
+	}
+	return s.Repo.GetTaskByID(id)
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// DefaultAutoArchiveAge is how long after completion RunAutoArchiver
+// archives a task.
+const DefaultAutoArchiveAge = 30 * 24 * time.Hour
+
+var (
+	ErrTaskArchived    = errors.New("Task is already archived")
+	ErrTaskNotArchived = errors.New("Task is not archived")
+)
+
+// ArchiveTask hides a task from listings without changing its status.
+func (s *TaskService) ArchiveTask(id uint) (*models.Task, error) {
+	return s.setArchived(id, true)
+}
+
+func (s *TaskService) UnarchiveTask(id uint) (*models.Task, error) {
+	return s.setArchived(id, false)
+}
+
+func (s *TaskService) setArchived(id uint, archived bool) (*models.Task, error) {
+	task, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if archived && task.ArchivedAt != nil {
+		return nil, ErrTaskArchived
+	}
+	if !archived && task.ArchivedAt == nil {
+		return nil, ErrTaskNotArchived
+	}
+
+	var archivedAt *time.Time
+	action := models.ActivityUnarchived
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+		action = models.ActivityArchived
+	}
+	if err := s.Repo.SetArchived(id, archivedAt); err != nil {
+		return nil, err
+	}
+
+	s.recordActivity(task, action, archiveChange(archived), ChangeContext{})
+	task.ArchivedAt = archivedAt
+	return task, nil
+}
+
+// ArchiveCompleted archives the tasks that were completed more than age
+// ago and returns how many it archived.
+func (s *TaskService) ArchiveCompleted(now time.Time, age time.Duration) (int, error) {
+	ids, err := s.Repo.ArchiveCompletedBefore(now.Add(-age))
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		s.recordActivityOf(id, models.ActivityArchived, archiveChange(true))
+	}
+	return len(ids), nil
+}
+
+// RunAutoArchiver archives tasks completed more than age ago every interval
+// until the process exits.
+func (s *TaskService) RunAutoArchiver(age, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		archived, err := s.ArchiveCompleted(time.Now(), age)
+		if err != nil {
+			log.Printf("[X] Auto-archiver failed: %v\n", err)
+			continue
+		}
+		if archived > 0 {
+			log.Printf("[V] Auto-archiver archived %d task(s)\n", archived)
+		}
+	}
+}
+
+func archiveChange(archived bool) []models.FieldChange {
+	return []models.FieldChange{{Field: "archived", From: !archived, To: archived}}
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+var ErrUserNotFound = errors.New("User not found")
+
+// AssignmentNotifier tells users they were assigned to a task.
+type AssignmentNotifier interface {
+	NotifyAssigned(task *models.Task, userID, assignedBy uint) error
+}
+
+// AssignTask assigns users to a task. Users who were already assigned are
+// left alone; everyone newly assigned, except the person assigning, is
+// notified.
+func (s *TaskService) AssignTask(id uint, userIDs []uint, ctx ChangeContext) error {
+	userIDs = uniqueIDs(userIDs)
+	if s.Users != nil {
+		users, err := s.Users.GetUsersByIDs(userIDs)
+		if err != nil {
+			return err
+		}
+		if len(users) != len(userIDs) {
+			return ErrUserNotFound
+		}
+	}
+	if s.Workspaces != nil && s.WorkspaceID != 0 {
+		members, err := s.Workspaces.GetMembersByUserIDs(s.WorkspaceID, userIDs)
+		if err != nil {
+			return err
+		}
+		if len(members) != len(userIDs) {
+			return ErrNotWorkspaceMember
+		}
+	}
+
+	added, err := s.Repo.AddAssignees(id, userIDs, ctx.UserID)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 || (s.Assignments == nil && s.Activity == nil) {
+		return nil
+	}
+
+	task, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		log.Printf("[X] Failed to load task %d for assignment notifications: %v\n", id, err)
+		return nil
+	}
+	s.recordActivity(task, models.ActivityAssigned, []models.FieldChange{{Field: "assignee_ids", To: added}}, ctx)
+	if s.Assignments == nil {
+		return nil
+	}
+	for _, userID := range added {
+		if userID == ctx.UserID {
+			continue
+		}
+		if err := s.Assignments.NotifyAssigned(task, userID, ctx.UserID); err != nil {
+			log.Printf("[X] Failed to notify user %d about task %d: %v\n", userID, id, err)
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) UnassignTask(id, userID uint) error {
+	if err := s.Repo.RemoveAssignee(id, userID); err != nil {
+		return err
+	}
+	s.recordActivityOf(id, models.ActivityUnassigned, []models.FieldChange{{Field: "assignee_ids", From: []uint{userID}}})
+	return nil
+}
@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+// MaxBulkOperations is how many tasks one bulk request may change.
+const MaxBulkOperations = 500
+
+// Bulk modes. All-or-nothing rolls every change back when one operation
+// fails; best-effort keeps the operations that succeeded.
+const (
+	BulkAllOrNothing = "all_or_nothing"
+	BulkBestEffort   = "best_effort"
+)
+
+// Bulk operations.
+const (
+	BulkUpdate     = "update"
+	BulkTransition = "transition"
+	BulkDelete     = "delete"
+	BulkMove       = "move"
+	BulkAddLabel   = "add_label"
+)
+
+// Outcomes of a bulk operation.
+const (
+	BulkOK         = "ok"
+	BulkFailed     = "failed"
+	BulkRolledBack = "rolled_back"
+	BulkSkipped    = "skipped"
+)
+
+var (
+	ErrInvalidBulkMode      = errors.New("Invalid mode. Use 'all_or_nothing' or 'best_effort'")
+	ErrInvalidBulkOperation = errors.New("Invalid bulk operation")
+	ErrTooManyOperations    = fmt.Errorf("A bulk request may change at most %d tasks", MaxBulkOperations)
+	ErrNoOperations         = errors.New("Give either operations or a filter with an operation")
+	ErrInvalidTaskFields    = errors.New("Invalid task fields")
+
+	errBulkAborted = errors.New("bulk request aborted")
+)
+
+// BulkRequest lists the operations to run, or selects tasks with Filter and
+// applies Operation to each of them.
+type BulkRequest struct {
+	Mode       string             `json:"mode"`
+	Operations []BulkOperation    `json:"operations"`
+	Filter     *models.TaskFilter `json:"filter"`
+	Operation  *BulkOperation     `json:"operation"`
+}
+
+// BulkOperation is one change to one task. Which fields are read depends on
+// Op.
+type BulkOperation struct {
+	Op        string      `json:"op"`
+	TaskID    uint        `json:"task_id"`
+	Fields    *BulkFields `json:"fields"`
+	Status    string      `json:"status"`
+	ProjectID *uint       `json:"project_id"`
+	LabelIDs  []uint      `json:"label_ids"`
+}
+
+// BulkFields are the fields an update operation sets; nil fields are kept.
+// An empty due_date clears it.
+type BulkFields struct {
+	Title        *string         `json:"title"`
+	Description  *string         `json:"description"`
+	Priority     *string         `json:"priority"`
+	DueDate      *models.DueDate `json:"due_date"`
+	AutoComplete *bool           `json:"auto_complete"`
+}
+
+type BulkResult struct {
+	Index  int
+	TaskID uint
+	Op     string
+	Status string
+	Err    error
+}
+
+type BulkReport struct {
+	Mode      string
+	Committed bool
+	Succeeded int
+	Failed    int
+	Results   []BulkResult
+}
+
+// sideEffects holds the changes a bulk request makes outside the task
+// repository's transaction, so they only happen once it commits.
+type sideEffects struct {
+	pending []func()
+}
+
+// afterCommit runs fn now, or once the bulk transaction commits when the
+// service is running one.
+func (s *TaskService) afterCommit(fn func()) {
+	if s.effects != nil {
+		s.effects.pending = append(s.effects.pending, fn)
+		return
+	}
+	fn()
+}
+
+// inTransaction runs fn with a copy of the service whose task and label
+// writes share one transaction. Side effects wait for the commit, or for the enclosing
+// bulk transaction's when there is one.
+func (s *TaskService) inTransaction(fn func(tx *TaskService) error) error {
+	effects := &sideEffects{}
+	err := s.Repo.Transaction(func(repo repositories.TaskRepository) error {
+		scoped := *s
+		scoped.Repo = repo
+		if s.Labels != nil {
+			scoped.Labels = s.Labels.InTransaction(repo)
+		}
+		if s.Series != nil {
+			scoped.Series = s.Series.InTransaction(repo)
+		}
+		scoped.effects = effects
+		return fn(&scoped)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, effect := range effects.pending {
+		s.afterCommit(effect)
+	}
+	return nil
+}
+
+// Bulk runs the operations of req in one transaction and reports the outcome
+// of each. validate, if set, checks updated tasks like a single update
+// would. Errors are only returned for invalid requests and database
+// failures; failed operations are reported in the results.
+func (s *TaskService) Bulk(req BulkRequest, validate func(task *models.Task) error, ctx ChangeContext) (*BulkReport, error) {
+	if req.Mode == "" {
+		req.Mode = BulkAllOrNothing
+	}
+	if req.Mode != BulkAllOrNothing && req.Mode != BulkBestEffort {
+		return nil, ErrInvalidBulkMode
+	}
+	operations, err := s.bulkOperations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BulkReport{Mode: req.Mode, Results: make([]BulkResult, len(operations))}
+	for i, operation := range operations {
+		report.Results[i] = BulkResult{Index: i, TaskID: operation.TaskID, Op: operation.Op, Status: BulkSkipped}
+	}
+
+	committed := &sideEffects{}
+	err = s.Repo.Transaction(func(repo repositories.TaskRepository) error {
+		for i, operation := range operations {
+			effects := &sideEffects{}
+			err := repo.Transaction(func(itemRepo repositories.TaskRepository) error {
+				item := *s
+				item.Repo = itemRepo
+				item.effects = effects
+				return item.applyBulk(operation, validate, ctx)
+			})
+
+			result := &report.Results[i]
+			if err != nil {
+				result.Status = BulkFailed
+				result.Err = err
+				report.Failed++
+				if req.Mode == BulkAllOrNothing {
+					return errBulkAborted
+				}
+				continue
+			}
+			result.Status = BulkOK
+			report.Succeeded++
+			committed.pending = append(committed.pending, effects.pending...)
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBulkAborted) {
+		for i := range report.Results {
+			if report.Results[i].Status == BulkOK {
+				report.Results[i].Status = BulkRolledBack
+			}
+		}
+		report.Succeeded = 0
+		return report, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	report.Committed = true
+	for _, fn := range committed.pending {
+		fn()
+	}
+	return report, nil
+}
+
+// bulkOperations checks the request and expands a filter selector into one
+// operation per matching task.
+func (s *TaskService) bulkOperations(req BulkRequest) ([]BulkOperation, error) {
+	operations := req.Operations
+	if req.Filter != nil {
+		if len(operations) > 0 || req.Operation == nil {
+			return nil, ErrNoOperations
+		}
+		if err := ValidateTaskFilter(*req.Filter); err != nil {
+			return nil, err
+		}
+		tasks, _, err := s.Repo.FindTasks(*req.Filter, "id", 1, MaxBulkOperations+1)
+		if err != nil {
+			return nil, err
+		}
+		operations = make([]BulkOperation, len(tasks))
+		for i, task := range tasks {
+			operations[i] = *req.Operation
+			operations[i].TaskID = task.ID
+		}
+	}
+
+	if len(operations) == 0 && req.Filter == nil {
+		return nil, ErrNoOperations
+	}
+	if len(operations) > MaxBulkOperations {
+		return nil, ErrTooManyOperations
+	}
+	for i, operation := range operations {
+		if err := checkBulkOperation(operation); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %s", ErrInvalidBulkOperation, i, err)
+		}
+	}
+	return operations, nil
+}
+
+func checkBulkOperation(operation BulkOperation) error {
+	if operation.TaskID == 0 {
+		return errors.New("task_id is required")
+	}
+	switch operation.Op {
+	case BulkUpdate:
+		if operation.Fields == nil {
+			return errors.New("fields are required")
+		}
+	case BulkTransition:
+		if operation.Status == "" {
+			return errors.New("status is required")
+		}
+	case BulkAddLabel:
+		if len(operation.LabelIDs) == 0 {
+			return errors.New("label_ids are required")
+		}
+	case BulkDelete, BulkMove:
+	default:
+		return fmt.Errorf("unknown op '%s'", operation.Op)
+	}
+	return nil
+}
+
+// HasBulkOp reports whether any operation of req is op.
+func (req BulkRequest) HasBulkOp(op string) bool {
+	if req.Operation != nil && req.Operation.Op == op {
+		return true
+	}
+	for _, operation := range req.Operations {
+		if operation.Op == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TaskService) applyBulk(operation BulkOperation, validate func(task *models.Task) error, ctx ChangeContext) error {
+	switch operation.Op {
+	case BulkUpdate:
+		return s.bulkUpdate(operation.TaskID, operation.Fields, validate, ctx)
+	case BulkTransition:
+		_, err := s.TransitionTask(operation.TaskID, operation.Status, ctx)
+		return err
+	case BulkDelete:
+		return s.DeleteTask(operation.TaskID)
+	case BulkMove:
+		return s.MoveTask(operation.TaskID, operation.ProjectID)
+	case BulkAddLabel:
+		return s.AddLabels(operation.TaskID, operation.LabelIDs)
+	}
+	return ErrInvalidBulkOperation
+}
+
+func (s *TaskService) bulkUpdate(id uint, fields *BulkFields, validate func(task *models.Task) error, ctx ChangeContext) error {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+
+	updated := *current
+	if fields.Title != nil {
+		updated.Title = *fields.Title
+	}
+	if fields.Description != nil {
+		updated.Description = *fields.Description
+	}
+	if fields.Priority != nil {
+		updated.Priority = *fields.Priority
+	}
+	if fields.DueDate != nil {
+		updated.DueDate = *fields.DueDate
+	}
+	if fields.AutoComplete != nil {
+		updated.AutoComplete = *fields.AutoComplete
+	}
+	if validate != nil {
+		if err := validate(&updated); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTaskFields, err)
+		}
+	}
+	return s.saveTask(current, &updated, ctx, true)
+}
@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// maxGraphNodes caps how many tasks DependencyGraph walks.
+const maxGraphNodes = 200
+
+var (
+	ErrBlockerNotFound = errors.New("Blocking task not found")
+	ErrDependencyCycle = errors.New("This dependency would create a cycle")
+	ErrTaskBlocked     = errors.New("Task is blocked by open tasks")
+)
+
+// AddDependency records that blockerID has to be finished before id.
+func (s *TaskService) AddDependency(id, blockerID uint) error {
+	if id == blockerID {
+		return ErrDependencyCycle
+	}
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return err
+	}
+	if _, err := s.Repo.GetTaskByID(blockerID); err != nil {
+		return ErrBlockerNotFound
+	}
+
+	cycle, err := s.isBlockedBy(blockerID, id)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrDependencyCycle
+	}
+	return s.Repo.AddDependency(id, blockerID)
+}
+
+func (s *TaskService) RemoveDependency(id, blockerID uint) error {
+	return s.Repo.RemoveDependency(id, blockerID)
+}
+
+// isBlockedBy reports whether id is blocked by target, directly or through
+// other tasks.
+func (s *TaskService) isBlockedBy(id, target uint) (bool, error) {
+	visited := map[uint]bool{id: true}
+	frontier := []uint{id}
+
+	for len(frontier) > 0 {
+		edges, err := s.Repo.GetDependencyEdges(frontier)
+		if err != nil {
+			return false, err
+		}
+
+		inFrontier := make(map[uint]bool, len(frontier))
+		for _, taskID := range frontier {
+			inFrontier[taskID] = true
+		}
+
+		frontier = nil
+		for _, edge := range edges {
+			if !inFrontier[edge.TaskID] {
+				continue
+			}
+			if edge.BlockerID == target {
+				return true, nil
+			}
+			if !visited[edge.BlockerID] {
+				visited[edge.BlockerID] = true
+				frontier = append(frontier, edge.BlockerID)
+			}
+		}
+	}
+	return false, nil
+}
+
+// DependencyGraph returns the tasks connected to id through dependencies in
+// either direction, and the dependencies between them.
+func (s *TaskService) DependencyGraph(id uint) ([]models.Task, []models.TaskDependency, error) {
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return nil, nil, err
+	}
+
+	visited := map[uint]bool{id: true}
+	nodeIDs := []uint{id}
+	frontier := []uint{id}
+	seenEdges := map[models.TaskDependency]bool{}
+	var edges []models.TaskDependency
+
+	for len(frontier) > 0 && len(nodeIDs) < maxGraphNodes {
+		found, err := s.Repo.GetDependencyEdges(frontier)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		frontier = nil
+		for _, edge := range found {
+			key := models.TaskDependency{TaskID: edge.TaskID, BlockerID: edge.BlockerID}
+			if seenEdges[key] {
+				continue
+			}
+			for _, taskID := range []uint{edge.TaskID, edge.BlockerID} {
+				if !visited[taskID] && len(nodeIDs) < maxGraphNodes {
+					visited[taskID] = true
+					nodeIDs = append(nodeIDs, taskID)
+					frontier = append(frontier, taskID)
+				}
+			}
+			if visited[edge.TaskID] && visited[edge.BlockerID] {
+				seenEdges[key] = true
+				edges = append(edges, edge)
+			}
+		}
+	}
+
+	nodes, err := s.Repo.GetTasksByIDs(nodeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, edges, nil
+}
+
+// checkBlockers refuses to finish a task while any of its blockers is open.
+func checkBlockers(task *models.Task) error {
+	var open []string
+	for _, blocker := range task.BlockedBy {
+		if blocker.StatusCategory == models.StatusCategoryOpen {
+			open = append(open, fmt.Sprintf("#%d %s", blocker.ID, blocker.Title))
+		}
+	}
+	if len(open) > 0 {
+		return fmt.Errorf("%w: %s. Use force=true to complete it anyway", ErrTaskBlocked, strings.Join(open, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// MaxTaskDepth is how many levels a task tree may have, counting the
+// top-level task.
+const MaxTaskDepth = 4
+
+var (
+	ErrParentNotFound = errors.New("Parent task not found")
+	ErrTaskCycle      = errors.New("A task cannot be nested under itself or one of its subtasks")
+	ErrTaskTooDeep    = fmt.Errorf("Tasks cannot be nested more than %d levels deep", MaxTaskDepth)
+)
+
+func (s *TaskService) GetSubtasks(id uint) ([]models.Task, error) {
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetSubtasks(id)
+}
+
+// CreateSubtask creates a task under the given parent. The subtask joins the
+// parent's project unless it names one of its own.
+func (s *TaskService) CreateSubtask(parentID uint, task *models.Task) error {
+	task.ParentID = &parentID
+	return s.CreateTask(task)
+}
+
+// SetParent nests a task under another one, or makes it a top-level task
+// when parentID is nil.
+func (s *TaskService) SetParent(id uint, parentID *uint) error {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+	if _, err := s.checkParent(id, parentID); err != nil {
+		return err
+	}
+	if err := s.Repo.SetParent(id, parentID); err != nil {
+		return err
+	}
+
+	nested := *current
+	nested.ParentID = parentID
+	s.recordActivity(current, models.ActivityUpdated, DiffTasks(current, &nested), ChangeContext{})
+	return nil
+}
+
+// checkParent makes sure placing task id (0 for a new task) under parentID
+// neither creates a cycle nor exceeds MaxTaskDepth. It returns the parent.
+func (s *TaskService) checkParent(id uint, parentID *uint) (*models.Task, error) {
+	if parentID == nil {
+		return nil, nil
+	}
+	if *parentID == id {
+		return nil, ErrTaskCycle
+	}
+
+	parent, err := s.Repo.GetTaskByID(*parentID)
+	if err != nil {
+		return nil, ErrParentNotFound
+	}
+
+	depth := 1
+	for ancestor := parent; ancestor.ParentID != nil; depth++ {
+		if *ancestor.ParentID == id || depth > MaxTaskDepth {
+			return nil, ErrTaskCycle
+		}
+		if ancestor, err = s.Repo.GetTaskByID(*ancestor.ParentID); err != nil {
+			return nil, err
+		}
+	}
+
+	height := 1
+	if id != 0 {
+		if height, err = s.treeHeight(id, 1); err != nil {
+			return nil, err
+		}
+	}
+	if depth+height > MaxTaskDepth {
+		return nil, ErrTaskTooDeep
+	}
+	return parent, nil
+}
+
+// treeHeight returns the number of levels in the tree rooted at id.
+func (s *TaskService) treeHeight(id uint, level int) (int, error) {
+	if level > MaxTaskDepth {
+		return level, nil
+	}
+
+	subtasks, err := s.Repo.GetSubtasks(id)
+	if err != nil {
+		return 0, err
+	}
+
+	height := 1
+	for _, subtask := range subtasks {
+		childHeight, err := s.treeHeight(subtask.ID, level+1)
+		if err != nil {
+			return 0, err
+		}
+		if childHeight+1 > height {
+			height = childHeight + 1
+		}
+	}
+	return height, nil
+}
+
+// autoCompleteParent completes a parent that asked for it once none of its
+// subtasks are open and at least one of them is done.
+func (s *TaskService) autoCompleteParent(parentID uint, ctx ChangeContext) {
+	parent, err := s.Repo.GetTaskByID(parentID)
+	if err != nil || !parent.AutoComplete || parent.StatusCategory != models.StatusCategoryOpen {
+		return
+	}
+
+	anyDone := false
+	for _, subtask := range parent.Subtasks {
+		switch subtask.StatusCategory {
+		case models.StatusCategoryOpen:
+			return
+		case models.StatusCategoryDone:
+			anyDone = true
+		}
+	}
+	if !anyDone {
+		return
+	}
+
+	workflow, err := s.WorkflowForTask(parent)
+	if err != nil {
+		log.Printf("[X] Failed to auto-complete task %d: %v\n", parentID, err)
+		return
+	}
+	done, ok := findStateInCategory(workflow, models.StatusCategoryDone)
+	if !ok {
+		return
+	}
+
+	updatedParent := *parent
+	updatedParent.Status = done.Key
+	if err := s.saveTask(parent, &updatedParent, ctx, false); err != nil {
+		log.Printf("[X] Failed to auto-complete task %d: %v\n", parentID, err)
+		return
+	}
+	log.Printf("[V] Task %d auto-completed after its subtasks\n", parentID)
+}
@@ -0,0 +1,435 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+// Import file formats.
+const (
+	ImportCSV  = "csv"
+	ImportJSON = "json"
+)
+
+const (
+	// MaxImportSize is the largest import file accepted, in bytes.
+	MaxImportSize = 10 << 20
+	// MaxImportRows is how many tasks one import may create.
+	MaxImportRows = 10000
+	// ImportBackgroundRows is the number of rows above which an import runs
+	// as a background job instead of during the request.
+	ImportBackgroundRows = 100
+
+	// importProgressEvery is how many rows a background job processes
+	// between progress updates.
+	importProgressEvery = 50
+)
+
+// ImportFields are the task fields an import can set. In CSV files labels
+// are comma separated names; missing labels are created.
+var ImportFields = []string{"title", "description", "status", "priority", "due_date", "project_id", "labels", "recurrence"}
+
+var (
+	ErrInvalidImportFormat  = errors.New("Invalid format. Use 'csv' or 'json'")
+	ErrInvalidImportFile    = errors.New("Invalid import file")
+	ErrInvalidImportMapping = errors.New("Invalid column mapping")
+	ErrInvalidImportRow     = errors.New("Invalid row")
+	ErrEmptyImport          = errors.New("The import file has no rows")
+	ErrTooManyImportRows    = fmt.Errorf("An import may contain at most %d rows", MaxImportRows)
+	ErrImportJobNotFound    = errors.New("Import job not found")
+)
+
+// ImportRow is one parsed row of an import file. Err is set when the row
+// could not be read; it is reported instead of importing the row.
+type ImportRow struct {
+	Row    int
+	Task   models.Task
+	Labels []string
+	Err    error
+}
+
+// ImportOptions controls how rows are imported. Validate, if set, checks
+// each task like a single create would. ErrorMessage decides what a
+// rejected row tells the client; by default it is the error text.
+type ImportOptions struct {
+	DryRun       bool
+	Validate     func(task *models.Task) error
+	ErrorMessage func(error) string
+}
+
+// ImportReport is the outcome of an import. Imported counts the tasks that
+// were created, or in a dry run, the rows that would have been.
+type ImportReport struct {
+	DryRun   bool
+	Total    int
+	Imported int
+	Failed   int
+	Errors   []models.ImportRowError
+}
+
+// importRecord is the shape of one task in a JSON import.
+type importRecord struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Status      string         `json:"status"`
+	Priority    string         `json:"priority"`
+	DueDate     models.DueDate `json:"due_date"`
+	ProjectID   *uint          `json:"project_id"`
+	Labels      []string       `json:"labels"`
+	Recurrence  string         `json:"recurrence"`
+}
+
+// ParseImport reads the rows of a CSV or JSON import file. CSV columns
+// named after a field in ImportFields are read as that field; mapping names
+// the column for the others. It is ignored for JSON.
+func ParseImport(format string, r io.Reader, mapping map[string]string) ([]ImportRow, error) {
+	var rows []ImportRow
+	var err error
+	switch format {
+	case ImportCSV:
+		rows, err = parseImportCSV(r, mapping)
+	case ImportJSON:
+		rows, err = parseImportJSON(r)
+	default:
+		return nil, ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	return rows, nil
+}
+
+func parseImportCSV(r io.Reader, mapping map[string]string) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	columns, err := importColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		if len(rows) == MaxImportRows {
+			return nil, ErrTooManyImportRows
+		}
+
+		value := func(field string) string {
+			index, ok := columns[field]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return utils.UnescapeCSVCell(strings.TrimSpace(record[index]))
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvImportRow(line, value))
+	}
+	return rows, nil
+}
+
+// importColumns finds the column index of each mapped field.
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int)
+	for _, field := range ImportFields {
+		if i, ok := index[field]; ok {
+			columns[field] = i
+		}
+	}
+	for field, column := range mapping {
+		if !isImportField(field) {
+			return nil, fmt.Errorf("%w: unknown field '%s'. Use %s", ErrInvalidImportMapping, field, strings.Join(ImportFields, ", "))
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, fmt.Errorf("%w: column '%s' not found", ErrInvalidImportMapping, column)
+		}
+		columns[field] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("%w: no column for title", ErrInvalidImportMapping)
+	}
+	return columns, nil
+}
+
+func isImportField(field string) bool {
+	for _, known := range ImportFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+func csvImportRow(line int, value func(field string) string) ImportRow {
+	row := ImportRow{
+		Row: line,
+		Task: models.Task{
+			Title:       value("title"),
+			Description: value("description"),
+			Status:      value("status"),
+			Priority:    strings.ToLower(value("priority")),
+			Recurrence:  value("recurrence"),
+		},
+	}
+
+	dueDate, err := models.ParseDueDate(value("due_date"))
+	if err != nil {
+		row.Err = err
+		return row
+	}
+	row.Task.DueDate = dueDate
+
+	if projectID := value("project_id"); projectID != "" {
+		id, err := strconv.ParseUint(projectID, 10, 32)
+		if err != nil || id == 0 {
+			row.Err = fmt.Errorf("%w: invalid project_id '%s'", ErrInvalidImportRow, projectID)
+			return row
+		}
+		project := uint(id)
+		row.Task.ProjectID = &project
+	}
+
+	for _, label := range strings.Split(value("labels"), ",") {
+		row.Labels = appendUnique(row.Labels, strings.TrimSpace(label))
+	}
+	return row
+}
+
+func parseImportJSON(r io.Reader) ([]ImportRow, error) {
+	var records []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("%w: expected a JSON array of tasks", ErrInvalidImportFile)
+	}
+	if len(records) > MaxImportRows {
+		return nil, ErrTooManyImportRows
+	}
+
+	rows := make([]ImportRow, len(records))
+	for i, raw := range records {
+		rows[i].Row = i + 1
+
+		var record importRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			var dueDateErr *models.DueDateError
+			if errors.As(err, &dueDateErr) {
+				rows[i].Err = dueDateErr
+			} else {
+				rows[i].Err = fmt.Errorf("%w: %v", ErrInvalidImportRow, err)
+			}
+			continue
+		}
+
+		rows[i].Task = models.Task{
+			Title:       strings.TrimSpace(record.Title),
+			Description: record.Description,
+			Status:      record.Status,
+			Priority:    strings.ToLower(record.Priority),
+			DueDate:     record.DueDate,
+			ProjectID:   record.ProjectID,
+			Recurrence:  record.Recurrence,
+		}
+		for _, label := range record.Labels {
+			rows[i].Labels = appendUnique(rows[i].Labels, strings.TrimSpace(label))
+		}
+	}
+	return rows, nil
+}
+
+// Import creates a task for every valid row, or only checks the rows in a
+// dry run. Small imports run right away and return a report; larger ones
+// are started as a background job, which is returned instead.
+func (s *TaskService) Import(rows []ImportRow, format string, opts ImportOptions) (*ImportReport, *models.ImportJob, error) {
+	if opts.DryRun || len(rows) <= ImportBackgroundRows || s.Imports == nil {
+		return s.importRows(rows, opts, nil), nil, nil
+	}
+
+	job := &models.ImportJob{
+		WorkspaceID: s.WorkspaceID,
+		UserID:      s.Actor.UserID,
+		Format:      format,
+		Status:      models.ImportPending,
+		Total:       len(rows),
+		Errors:      []models.ImportRowError{},
+	}
+	if err := s.Imports.CreateImportJob(job); err != nil {
+		return nil, nil, err
+	}
+
+	running := *job
+	go s.runImportJob(&running, rows, opts)
+	return nil, job, nil
+}
+
+// GetImportJob returns an import job of the service's workspace.
+func (s *TaskService) GetImportJob(id uint) (*models.ImportJob, error) {
+	if s.Imports == nil {
+		return nil, ErrImportJobNotFound
+	}
+	job, err := s.Imports.GetImportJob(id, s.WorkspaceID)
+	if err != nil {
+		return nil, ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+// FailInterruptedImports marks the import jobs a previous run of the server
+// didn't finish as failed. It is meant to be called once at startup.
+func (s *TaskService) FailInterruptedImports() {
+	if s.Imports == nil {
+		return
+	}
+	failed, err := s.Imports.FailUnfinishedImportJobs("Interrupted by a server restart")
+	if err != nil {
+		log.Printf("[X] Failed to clean up interrupted imports: %v\n", err)
+		return
+	}
+	if failed > 0 {
+		log.Printf("[V] Marked %d interrupted import(s) as failed\n", failed)
+	}
+}
+
+// runImportJob runs in its own goroutine, where a panic would take the
+// whole server down, so one is recovered and fails the job instead.
+func (s *TaskService) runImportJob(job *models.ImportJob, rows []ImportRow, opts ImportOptions) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("[X] Import job %d panicked: %v\n%s", job.ID, recovered, debug.Stack())
+			now := time.Now()
+			job.Status = models.ImportFailed
+			job.Error = "The import stopped unexpectedly"
+			job.FinishedAt = &now
+			s.saveImportJob(job)
+		}
+	}()
+
+	job.Status = models.ImportRunning
+	s.saveImportJob(job)
+
+	report := s.importRows(rows, opts, func(processed int, report *ImportReport) {
+		if processed%importProgressEvery != 0 {
+			return
+		}
+		job.Processed = processed
+		job.Created = report.Imported
+		job.Failed = report.Failed
+		job.Errors = report.Errors
+		s.saveImportJob(job)
+	})
+
+	now := time.Now()
+	job.Status = models.ImportCompleted
+	job.Processed = report.Total
+	job.Created = report.Imported
+	job.Failed = report.Failed
+	job.Errors = report.Errors
+	job.FinishedAt = &now
+	s.saveImportJob(job)
+	log.Printf("[V] Import job %d finished: %d created, %d failed\n", job.ID, report.Imported, report.Failed)
+}
+
+func (s *TaskService) saveImportJob(job *models.ImportJob) {
+	if err := s.Imports.UpdateImportJob(job); err != nil {
+		log.Printf("[X] Failed to update import job %d: %v\n", job.ID, err)
+	}
+}
+
+// importRows imports the rows one by one, calling progress after each.
+func (s *TaskService) importRows(rows []ImportRow, opts ImportOptions, progress func(processed int, report *ImportReport)) *ImportReport {
+	report := &ImportReport{DryRun: opts.DryRun, Total: len(rows), Errors: []models.ImportRowError{}}
+	for i, row := range rows {
+		if err := s.importRow(row, opts); err != nil {
+			message := err.Error()
+			if opts.ErrorMessage != nil {
+				message = opts.ErrorMessage(err)
+			}
+			report.Failed++
+			report.Errors = append(report.Errors, models.ImportRowError{Row: row.Row, Message: message})
+		} else {
+			report.Imported++
+		}
+		if progress != nil {
+			progress(i+1, report)
+		}
+	}
+	return report
+}
+
+func (s *TaskService) importRow(row ImportRow, opts ImportOptions) error {
+	if row.Err != nil {
+		return row.Err
+	}
+	task := row.Task
+	if opts.Validate != nil {
+		if err := opts.Validate(&task); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidTaskFields, err)
+		}
+	}
+
+	if opts.DryRun {
+		if len(row.Labels) > 0 && s.Labels == nil {
+			return ErrLabelNotFound
+		}
+		if task.Recurrence != "" {
+			if s.Series == nil {
+				return fmt.Errorf("%w: recurring tasks are not enabled", ErrInvalidRecurrence)
+			}
+			if _, _, err := parseRecurrence(task.Recurrence, task.RecurrenceMode); err != nil {
+				return err
+			}
+		}
+		return s.prepareTask(&task)
+	}
+
+	// Labels created for a row are rolled back if its task isn't created.
+	return s.inTransaction(func(tx *TaskService) error {
+		labelIDs, err := tx.labelIDsByName(row.Labels)
+		if err != nil {
+			return err
+		}
+		if err := tx.CreateTask(&task); err != nil {
+			return err
+		}
+		if len(labelIDs) > 0 {
+			return tx.Repo.AddLabels(task.ID, labelIDs)
+		}
+		return nil
+	})
+}
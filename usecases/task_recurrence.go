@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+var (
+	ErrInvalidRecurrence = errors.New("Invalid recurrence")
+	ErrNotRecurring      = errors.New("Task is not recurring")
+)
+
+// startSeries turns a new task into the first occurrence of a recurring
+// series. Without a due date, the task is due on the rule's first date from
+// today.
+func (s *TaskService) startSeries(task *models.Task) error {
+	if s.Series == nil {
+		return fmt.Errorf("%w: recurring tasks are not enabled", ErrInvalidRecurrence)
+	}
+
+	rule, mode, err := parseRecurrence(task.Recurrence, task.RecurrenceMode)
+	if err != nil {
+		return err
+	}
+
+	if task.DueDate.IsZero() {
+		today := time.Now()
+		first, ok := rule.Next(today, today.AddDate(0, 0, -1), 0)
+		if !ok {
+			return fmt.Errorf("%w: rule has no upcoming dates", ErrInvalidRecurrence)
+		}
+		task.DueDate = models.DateOnly(first)
+	}
+
+	series := &models.TaskSeries{
+		Rule:            task.Recurrence,
+		Mode:            mode,
+		StartDate:       task.DueDate.Date().Format("2006-01-02"),
+		FirstOccurrence: 1,
+		Title:           task.Title,
+		Description:     task.Description,
+		Priority:        task.Priority,
+		WorkspaceID:     task.WorkspaceID,
+		ProjectID:       task.ProjectID,
+	}
+	if err := s.Series.CreateSeries(series); err != nil {
+		return err
+	}
+
+	task.SeriesID = &series.ID
+	task.Occurrence = 1
+	return nil
+}
+
+// UpdateTaskSeries updates a recurring task together with every later
+// occurrence of its series ("all future"). A new recurrence rule takes effect
+// from this occurrence on.
+func (s *TaskService) UpdateTaskSeries(id uint, updatedTask *models.Task, ctx ChangeContext) error {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+	if current.SeriesID == nil || s.Series == nil {
+		return ErrNotRecurring
+	}
+
+	series, err := s.Series.GetSeriesByID(*current.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	if updatedTask.Recurrence != "" || updatedTask.RecurrenceMode != "" {
+		rule := updatedTask.Recurrence
+		if rule == "" {
+			rule = series.Rule
+		}
+		if _, mode, err := parseRecurrence(rule, updatedTask.RecurrenceMode); err != nil {
+			return err
+		} else if updatedTask.RecurrenceMode != "" {
+			series.Mode = mode
+		}
+		if rule != series.Rule {
+			series.Rule = rule
+			series.StartDate = current.DueDate.Date().Format("2006-01-02")
+			series.FirstOccurrence = current.Occurrence
+		}
+	}
+
+	if err := s.UpdateTaskAs(id, updatedTask, ctx); err != nil {
+		return err
+	}
+
+	series.Title = updatedTask.Title
+	series.Description = updatedTask.Description
+	series.Priority = updatedTask.Priority
+	if err := s.Series.UpdateSeries(series.ID, series); err != nil {
+		return err
+	}
+	if err := s.Repo.UpdateFutureOccurrences(series.ID, current.Occurrence, updatedTask); err != nil {
+		return err
+	}
+	// UpdateTaskAs invalidated the feeds before the later occurrences
+	// changed, so a feed rendered in between would keep the old ones.
+	s.invalidateFeeds(current.WorkspaceID)
+	return nil
+}
+
+// GenerateDueOccurrences creates the next occurrence of every scheduled
+// series whose current occurrence is due by now. It returns how many tasks
+// were created.
+func (s *TaskService) GenerateDueOccurrences(now time.Time) (int, error) {
+	tasks, err := s.Repo.GetDueOccurrences(now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := range tasks {
+		next, err := s.nextOccurrence(&tasks[i], models.RecurrenceScheduled)
+		if err != nil {
+			log.Printf("[X] Failed to generate next occurrence of task %d: %v\n", tasks[i].ID, err)
+			continue
+		}
+		if next != nil {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// RunRecurrenceScheduler calls GenerateDueOccurrences every interval. It
+// blocks, so run it in its own goroutine.
+func (s *TaskService) RunRecurrenceScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		created, err := s.GenerateDueOccurrences(time.Now())
+		if err != nil {
+			log.Printf("[X] Recurrence scheduler failed: %v\n", err)
+			continue
+		}
+		if created > 0 {
+			log.Printf("[V] Recurrence scheduler created %d task(s)\n", created)
+		}
+	}
+}
+
+// completeOccurrence generates the next occurrence of an on-complete series
+// once the current one is done. Failures are logged rather than returned so
+// they never undo the completion itself.
+func (s *TaskService) completeOccurrence(task *models.Task) {
+	if task.SeriesID == nil {
+		return
+	}
+
+	next, err := s.nextOccurrence(task, models.RecurrenceOnComplete)
+	if err != nil {
+		log.Printf("[X] Failed to generate next occurrence of task %d: %v\n", task.ID, err)
+		return
+	}
+	if next != nil {
+		log.Printf("[V] Task %d generated occurrence %d as task %d\n", task.ID, next.Occurrence, next.ID)
+	}
+}
+
+// nextOccurrence creates the occurrence that follows task in its series if
+// the series uses the given mode. It returns nil when the series is over or
+// the next occurrence already exists.
+func (s *TaskService) nextOccurrence(task *models.Task, mode string) (*models.Task, error) {
+	if task.SeriesID == nil || s.Series == nil {
+		return nil, nil
+	}
+
+	series, err := s.Series.GetSeriesByID(*task.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series.Mode != mode {
+		return nil, nil
+	}
+
+	rule, err := utils.ParseRRule(series.Rule)
+	if err != nil {
+		return nil, err
+	}
+	start, ok := parseDueDate(series.StartDate)
+	if !ok {
+		return nil, fmt.Errorf("series %d has an invalid start date", series.ID)
+	}
+	after := task.DueDate.Date()
+	if task.DueDate.IsZero() {
+		after = time.Now()
+	}
+
+	date, ok := rule.Next(start, after, task.Occurrence-series.FirstOccurrence+1)
+	if !ok {
+		return nil, nil
+	}
+
+	// The claim is released again if the occurrence can't be created, so a
+	// later completion or scheduler run retries it.
+	var next *models.Task
+	err = s.inTransaction(func(tx *TaskService) error {
+		claimed, err := tx.Repo.ClaimNextOccurrence(task.ID)
+		if err != nil || !claimed {
+			return err
+		}
+
+		next = &models.Task{
+			Title:       series.Title,
+			Description: series.Description,
+			Priority:    series.Priority,
+			DueDate:     nextDueDate(task.DueDate, date),
+			WorkspaceID: task.WorkspaceID,
+			ProjectID:   task.ProjectID,
+			ParentID:    task.ParentID,
+			SeriesID:    task.SeriesID,
+			Occurrence:  task.Occurrence + 1,
+		}
+		if err := tx.CreateTask(next); err != nil {
+			return err
+		}
+
+		if len(task.Labels) > 0 {
+			labelIDs := make([]uint, len(task.Labels))
+			for i, label := range task.Labels {
+				labelIDs[i] = label.ID
+			}
+			return tx.Repo.AddLabels(next.ID, labelIDs)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+func parseRecurrence(value, mode string) (*utils.RRule, string, error) {
+	rule, err := utils.ParseRRule(value)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+
+	switch mode {
+	case "":
+		mode = models.RecurrenceOnComplete
+	case models.RecurrenceOnComplete, models.RecurrenceScheduled:
+	default:
+		return nil, "", fmt.Errorf("%w: unknown mode '%s'", ErrInvalidRecurrence, mode)
+	}
+	return rule, mode, nil
+}
+
+// nextDueDate moves a due date to another day, keeping its time of day.
+func nextDueDate(due models.DueDate, date time.Time) models.DueDate {
+	if !due.HasTime {
+		return models.DateOnly(date)
+	}
+	at := due.Time.UTC()
+	next := time.Date(date.Year(), date.Month(), date.Day(), at.Hour(), at.Minute(), at.Second(), 0, time.UTC)
+	return models.DueDate{Time: &next, HasTime: true}
+}
+
+// parseDueDate reads a series start date, which the database may return
+// with a time part.
+func parseDueDate(value string) (time.Time, bool) {
+	if len(value) < 10 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006-01-02", value[:10])
+	return date, err == nil
+}
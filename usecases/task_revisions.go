@@ -0,0 +1,246 @@
+package usecases
+
+import (
+	"errors"
+	"log"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+var ErrRevisionNotFound = errors.New("Revision not found")
+
+// revisedActions are the changes that produce a new revision. Assignments
+// aren't part of a task's content, and deleted tasks have no next version.
+var revisedActions = map[string]bool{
+	models.ActivityCreated:      true,
+	models.ActivityUpdated:      true,
+	models.ActivityTransitioned: true,
+	models.ActivityMoved:        true,
+	models.ActivityLabeled:      true,
+	models.ActivityUnlabeled:    true,
+	models.ActivityRestored:     true,
+}
+
+// DiffSnapshots lists the fields that differ between two revisions, with
+// label_ids last.
+func DiffSnapshots(from, to models.TaskSnapshot) []models.FieldChange {
+	changes := DiffTasks(from.Task(), to.Task())
+	if !sameIDs(from.LabelIDs, to.LabelIDs) {
+		changes = append(changes, models.FieldChange{Field: "label_ids", From: from.LabelIDs, To: to.LabelIDs})
+	}
+	return changes
+}
+
+// GetRevisions returns every revision of a task, newest first.
+func (s *TaskService) GetRevisions(id uint) ([]models.TaskRevision, error) {
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return nil, err
+	}
+	if s.Revisions == nil {
+		return []models.TaskRevision{}, nil
+	}
+	return s.Revisions.GetRevisions(id)
+}
+
+func (s *TaskService) GetRevision(id uint, number int) (*models.TaskRevision, error) {
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return nil, err
+	}
+	if s.Revisions == nil {
+		return nil, ErrRevisionNotFound
+	}
+	revision, err := s.Revisions.GetRevision(id, number)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+// DiffRevisions lists the changes that lead from revision from to revision
+// to. Either may be the older one.
+func (s *TaskService) DiffRevisions(id uint, from, to int) ([]models.FieldChange, error) {
+	older, err := s.GetRevision(id, from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := s.GetRevision(id, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSnapshots(older.Snapshot, newer.Snapshot), nil
+}
+
+// RestoreRevision rolls a task back to the state of one of its revisions and
+// records the result as a new revision. The workflow transition rules are
+// skipped, but the revision's project, parent and status must still be
+// valid. Labels deleted since are left out.
+func (s *TaskService) RestoreRevision(id uint, number int, ctx ChangeContext) (*models.Task, error) {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.GetRevision(id, number)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := revision.Snapshot
+
+	// Check everything before changing anything.
+	project, err := s.checkProject(snapshot.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !sameID(current.ParentID, snapshot.ParentID) {
+		if _, err := s.checkParent(id, snapshot.ParentID); err != nil {
+			return nil, err
+		}
+	}
+	workflow, err := s.workflowFor(project)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyStatus(workflow, snapshot.Task()); err != nil {
+		return nil, err
+	}
+	labelIDs, err := s.existingLabels(snapshot.LabelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The steps below are recorded once, as the restore, and either all
+	// apply or none do.
+	err = s.inTransaction(func(tx *TaskService) error {
+		quiet := *tx
+		quiet.Activity = nil
+		quiet.Revisions = nil
+
+		if !sameID(current.ProjectID, snapshot.ProjectID) {
+			if err := quiet.Repo.MoveTask(id, snapshot.ProjectID); err != nil {
+				return err
+			}
+		}
+		if !sameID(current.ParentID, snapshot.ParentID) {
+			if err := quiet.Repo.SetParent(id, snapshot.ParentID); err != nil {
+				return err
+			}
+		}
+		if err := quiet.restoreLabels(current, labelIDs); err != nil {
+			return err
+		}
+
+		moved, err := quiet.Repo.GetTaskByID(id)
+		if err != nil {
+			return err
+		}
+		updated := *moved
+		updated.Title = snapshot.Title
+		updated.Description = snapshot.Description
+		updated.Status = snapshot.Status
+		updated.Priority = snapshot.Priority
+		updated.DueDate = snapshot.DueDate
+		updated.AutoComplete = snapshot.AutoComplete
+		return quiet.saveTask(moved, &updated, ctx, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.recordActivity(restored, models.ActivityRestored, DiffSnapshots(current.Snapshot(), restored.Snapshot()), ctx)
+	return restored, nil
+}
+
+// recordRevision stores the task's current state as its next revision.
+func (s *TaskService) recordRevision(id uint, action string, ctx ChangeContext) {
+	if s.Revisions == nil {
+		return
+	}
+	task, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		log.Printf("[X] Failed to load task %d for its revision: %v\n", id, err)
+		return
+	}
+
+	revision := &models.TaskRevision{
+		TaskID:    id,
+		Action:    action,
+		RequestID: ctx.RequestID,
+		Snapshot:  task.Snapshot(),
+	}
+	if ctx.UserID != 0 {
+		revision.UserID = &ctx.UserID
+	}
+	s.afterCommit(func() {
+		if err := s.Revisions.CreateRevision(revision); err != nil {
+			log.Printf("[X] Failed to record revision of task %d: %v\n", id, err)
+		}
+	})
+}
+
+// existingLabels drops the IDs of labels that no longer exist.
+func (s *TaskService) existingLabels(ids []uint) ([]uint, error) {
+	if s.Labels == nil || len(ids) == 0 {
+		return ids, nil
+	}
+	labels, err := s.Labels.GetLabelsByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]uint, len(labels))
+	for i, label := range labels {
+		existing[i] = label.ID
+	}
+	return existing, nil
+}
+
+// restoreLabels makes labelIDs the task's labels.
+func (s *TaskService) restoreLabels(task *models.Task, labelIDs []uint) error {
+	wanted := make(map[uint]bool, len(labelIDs))
+	for _, labelID := range labelIDs {
+		wanted[labelID] = true
+	}
+
+	for _, label := range task.Labels {
+		if wanted[label.ID] {
+			delete(wanted, label.ID)
+			continue
+		}
+		if err := s.Repo.RemoveLabel(task.ID, label.ID); err != nil {
+			return err
+		}
+	}
+
+	missing := make([]uint, 0, len(wanted))
+	for _, labelID := range labelIDs {
+		if wanted[labelID] {
+			missing = append(missing, labelID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return s.Repo.AddLabels(task.ID, missing)
+}
+
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// sameIDs compares two sorted ID lists.
+func sameIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
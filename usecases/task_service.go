@@ -1,24 +1,206 @@
 package usecases
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
 	"github.com/yasseryazid/technical-test/models"
 	"github.com/yasseryazid/technical-test/repositories"
 )
 
+var (
+	ErrTaskNotFound      = errors.New("Task not found")
+	ErrInvalidSort       = errors.New("Invalid sort field")
+	ErrProjectNotFound   = errors.New("Project not found")
+	ErrProjectArchived   = errors.New("Project is archived")
+	ErrLabelNotFound     = errors.New("Label not found")
+	ErrInvalidDateFilter = errors.New("Invalid date filter. Use YYYY-MM-DD")
+)
+
+// exportBatchSize is how many tasks ExportTasks reads at a time.
+const exportBatchSize = 200
+
 type TaskService struct {
 	Repo repositories.TaskRepository
+	// Projects is used to check project assignments. When nil, project IDs
+	// are stored without checks.
+	Projects repositories.ProjectRepository
+	// Labels is used to check label IDs before attaching them. When nil,
+	// unknown IDs are left to the database to reject.
+	Labels repositories.LabelRepository
+	// Workflows resolves project workflows and records transitions. When
+	// nil, every task follows DefaultWorkflow.
+	Workflows repositories.WorkflowRepository
+	// Series stores the templates of recurring tasks. When nil, tasks with a
+	// recurrence rule are rejected.
+	Series repositories.SeriesRepository
+	// Reminders moves offset reminders when a due date changes. When nil,
+	// reminders keep the time they were scheduled for.
+	Reminders ReminderScheduler
+	// Users is used to check user IDs before assigning them. When nil,
+	// unknown IDs are left to the database to reject.
+	Users repositories.UserRepository
+	// Assignments notifies users assigned to a task. When nil, nobody is
+	// notified.
+	Assignments AssignmentNotifier
+	// Workspaces is used to check that assignees belong to WorkspaceID. When
+	// nil, or when the service is not scoped to a workspace, it is skipped.
+	Workspaces  repositories.WorkspaceRepository
+	WorkspaceID uint
+	// Files deletes the stored contents of a purged task's attachments.
+	// When nil, they are left in storage.
+	Files AttachmentFiles
+	// Activity records every change in the task's activity log. When nil,
+	// nothing is recorded.
+	Activity repositories.ActivityRepository
+	// Revisions keeps a snapshot of every version of a task. When nil, no
+	// revisions are kept and none can be restored.
+	Revisions repositories.RevisionRepository
+	// Imports tracks imports running in the background. When nil, every
+	// import runs during the request.
+	Imports repositories.ImportJobRepository
+	// Feeds is told about every change so cached calendar feeds are
+	// rebuilt. When nil, feeds only refresh when their cache expires.
+	Feeds FeedInvalidator
+	// Actor is who changes made without an explicit ChangeContext are
+	// attributed to; see As.
+	Actor ChangeContext
+
+	// effects collects the side effects of a bulk operation until its
+	// transaction commits; see afterCommit.
+	effects *sideEffects
+}
+
+// AttachmentFiles is what TaskService needs to clean up attachment contents.
+type AttachmentFiles interface {
+	TaskFileHashes(taskID uint) ([]string, error)
+	PruneFiles(hashes []string)
+}
+
+// ChangeContext describes who is making a change to a task. Force lets the
+// change through even if the task's blockers are still open. RequestID ties
+// the change to the API request that made it in the activity log.
+type ChangeContext struct {
+	UserID    uint
+	Force     bool
+	RequestID string
 }
 
 func NewTaskService(repo repositories.TaskRepository) *TaskService {
 	return &TaskService{Repo: repo}
 }
 
+// InWorkspace returns a copy of the service that only sees the tasks of one
+// workspace and creates new tasks there. Projects, labels and workflows are
+// limited to that workspace too.
+func (s *TaskService) InWorkspace(workspaceID uint) *TaskService {
+	scoped := *s
+	scoped.Repo = s.Repo.InWorkspace(workspaceID)
+	if s.Projects != nil {
+		scoped.Projects = s.Projects.InWorkspace(workspaceID)
+	}
+	if s.Labels != nil {
+		scoped.Labels = s.Labels.InWorkspace(workspaceID)
+	}
+	if s.Workflows != nil {
+		scoped.Workflows = s.Workflows.InWorkspace(workspaceID)
+	}
+	if s.Series != nil {
+		scoped.Series = s.Series.InWorkspace(workspaceID)
+	}
+	scoped.WorkspaceID = workspaceID
+	return &scoped
+}
+
 func (s *TaskService) GetTasks(status, search string, page, limit int) ([]models.Task, int, error) {
 	return s.Repo.GetTasks(status, search, page, limit)
 }
 
+func (s *TaskService) FindTasks(filter models.TaskFilter, sort string, page, limit int) ([]models.Task, int, error) {
+	if !repositories.IsValidTaskSort(sort) {
+		return nil, 0, ErrInvalidSort
+	}
+	if err := ValidateTaskFilter(filter); err != nil {
+		return nil, 0, err
+	}
+	return s.Repo.FindTasks(filter, sort, page, limit)
+}
+
+// ExportTasks passes every task matching filter to fn, in batches and in the
+// order FindTasks would list them.
+func (s *TaskService) ExportTasks(filter models.TaskFilter, sort string, fn func(tasks []models.Task) error) error {
+	if !repositories.IsValidTaskSort(sort) {
+		return ErrInvalidSort
+	}
+	if err := ValidateTaskFilter(filter); err != nil {
+		return err
+	}
+	return s.Repo.EachTask(filter, sort, exportBatchSize, fn)
+}
+
+// ValidateTaskFilter checks the parts of a filter the database can't.
+func ValidateTaskFilter(filter models.TaskFilter) error {
+	for _, value := range []string{filter.DueFrom, filter.DueTo} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return ErrInvalidDateFilter
+		}
+	}
+	return nil
+}
+
 func (s *TaskService) CreateTask(task *models.Task) error {
-	return s.Repo.CreateTask(task)
+	if err := s.prepareTask(task); err != nil {
+		return err
+	}
+	// A series is only kept if its first task is created.
+	err := s.inTransaction(func(tx *TaskService) error {
+		if task.Recurrence != "" {
+			if err := tx.startSeries(task); err != nil {
+				return err
+			}
+		}
+		return tx.Repo.CreateTask(task)
+	})
+	if err != nil {
+		return err
+	}
+	s.recordActivity(task, models.ActivityCreated, DiffTasks(nil, task), ChangeContext{})
+	return nil
+}
+
+// prepareTask checks a new task's parent, project and status and fills in
+// the defaults, without storing anything.
+func (s *TaskService) prepareTask(task *models.Task) error {
+	parent, err := s.checkParent(0, task.ParentID)
+	if err != nil {
+		return err
+	}
+	if parent != nil && task.ProjectID == nil {
+		task.ProjectID = parent.ProjectID
+	}
+
+	project, err := s.checkProject(task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	workflow, err := s.workflowFor(project)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == "" {
+		task.Status = initialState(workflow).Key
+	}
+	if task.Priority == "" {
+		task.Priority = models.PriorityNone
+	}
+	return applyStatus(workflow, task)
 }
 
 func (s *TaskService) GetTaskByID(id uint) (*models.Task, error) {
@@ -26,9 +208,266 @@ func (s *TaskService) GetTaskByID(id uint) (*models.Task, error) {
 }
 
 func (s *TaskService) UpdateTask(id uint, updatedTask *models.Task) error {
-	return s.Repo.UpdateTask(id, updatedTask)
+	return s.UpdateTaskAs(id, updatedTask, ChangeContext{})
 }
 
+// UpdateTaskAs updates a task on behalf of the user in ctx. A status change
+// is validated and recorded as a workflow transition.
+func (s *TaskService) UpdateTaskAs(id uint, updatedTask *models.Task, ctx ChangeContext) error {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+
+	if updatedTask.Status == "" {
+		updatedTask.Status = current.Status
+	}
+	if updatedTask.Priority == "" {
+		updatedTask.Priority = current.Priority
+	}
+	return s.saveTask(current, updatedTask, ctx, true)
+}
+
+// TransitionTask moves a task to another workflow status.
+func (s *TaskService) TransitionTask(id uint, status string, ctx ChangeContext) (*models.Task, error) {
+	current, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if status == current.Status {
+		return nil, fmt.Errorf("%w: task is already '%s'", ErrTransitionNotAllowed, status)
+	}
+
+	updatedTask := *current
+	updatedTask.Status = status
+	if err := s.saveTask(current, &updatedTask, ctx, true); err != nil {
+		return nil, err
+	}
+	return &updatedTask, nil
+}
+
+// GetTransitions returns the status history of a task, oldest first.
+func (s *TaskService) GetTransitions(id uint) ([]models.TaskTransition, error) {
+	if _, err := s.Repo.GetTaskByID(id); err != nil {
+		return nil, err
+	}
+	if s.Workflows == nil {
+		return []models.TaskTransition{}, nil
+	}
+	return s.Workflows.GetTransitions(id)
+}
+
+// WorkflowForTask returns the workflow that governs the task's status.
+func (s *TaskService) WorkflowForTask(task *models.Task) (*models.Workflow, error) {
+	project, err := s.projectOf(task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.workflowFor(project)
+}
+
+// saveTask persists a change to a task. checkRules is false for changes the
+// system makes on its own, which skip the workflow transition rules.
+func (s *TaskService) saveTask(current, updatedTask *models.Task, ctx ChangeContext, checkRules bool) error {
+	workflow, err := s.WorkflowForTask(current)
+	if err != nil {
+		return err
+	}
+
+	changed := updatedTask.Status != current.Status
+	if changed && checkRules {
+		if err := checkTransition(workflow, current.Status, updatedTask.Status); err != nil {
+			return err
+		}
+	}
+	if err := applyStatus(workflow, updatedTask); err != nil {
+		return err
+	}
+	if changed && checkRules && !ctx.Force && updatedTask.StatusCategory == models.StatusCategoryDone {
+		if err := checkBlockers(current); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Repo.UpdateTask(current.ID, updatedTask); err != nil {
+		return err
+	}
+	s.recordUpdate(current, updatedTask, changed, ctx)
+
+	if s.Reminders != nil && !updatedTask.DueDate.Equal(current.DueDate) {
+		rescheduled := *updatedTask
+		rescheduled.ID = current.ID
+		s.afterCommit(func() {
+			if err := s.Reminders.RescheduleTask(&rescheduled); err != nil {
+				log.Printf("[X] Failed to reschedule reminders of task %d: %v\n", current.ID, err)
+			}
+		})
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if s.Workflows != nil {
+		transition := &models.TaskTransition{
+			TaskID:     current.ID,
+			FromStatus: current.Status,
+			ToStatus:   updatedTask.Status,
+			UserID:     ctx.UserID,
+		}
+		if s.effects != nil {
+			s.afterCommit(func() {
+				if err := s.Workflows.RecordTransition(transition); err != nil {
+					log.Printf("[X] Failed to record transition of task %d: %v\n", current.ID, err)
+				}
+			})
+		} else if err := s.Workflows.RecordTransition(transition); err != nil {
+			return err
+		}
+	}
+
+	if updatedTask.StatusCategory == models.StatusCategoryDone {
+		s.completeOccurrence(current)
+		if current.ParentID != nil {
+			s.autoCompleteParent(*current.ParentID, ctx)
+		}
+	}
+	return nil
+}
+
+// DeleteTask moves a task and its subtasks to the trash; see PurgeTask.
 func (s *TaskService) DeleteTask(id uint) error {
-	return s.Repo.DeleteTask(id)
+	task, err := s.loadForActivity(id)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.DeleteTask(id); err != nil {
+		return err
+	}
+	if task != nil {
+		s.recordActivity(task, models.ActivityDeleted, DiffTasks(task, nil), ChangeContext{})
+	}
+	return nil
+}
+
+// MoveTask moves a task into another project, or back to the inbox when
+// projectID is nil.
+func (s *TaskService) MoveTask(id uint, projectID *uint) error {
+	if _, err := s.checkProject(projectID); err != nil {
+		return err
+	}
+	current, err := s.loadForActivity(id)
+	if err != nil {
+		return err
+	}
+	if err := s.Repo.MoveTask(id, projectID); err != nil {
+		return err
+	}
+
+	if current != nil {
+		moved := *current
+		moved.ProjectID = projectID
+		s.recordActivity(current, models.ActivityMoved, DiffTasks(current, &moved), ChangeContext{})
+	}
+	return nil
+}
+
+// AddLabels attaches labels to a task. Labels already on the task are kept.
+func (s *TaskService) AddLabels(id uint, labelIDs []uint) error {
+	if s.Labels != nil {
+		labels, err := s.Labels.GetLabelsByIDs(labelIDs)
+		if err != nil {
+			return err
+		}
+		if len(labels) != len(uniqueIDs(labelIDs)) {
+			return ErrLabelNotFound
+		}
+	}
+	if err := s.Repo.AddLabels(id, labelIDs); err != nil {
+		return err
+	}
+	s.recordActivityOf(id, models.ActivityLabeled, []models.FieldChange{{Field: "label_ids", To: uniqueIDs(labelIDs)}})
+	return nil
+}
+
+func (s *TaskService) RemoveLabel(id, labelID uint) error {
+	if err := s.Repo.RemoveLabel(id, labelID); err != nil {
+		return err
+	}
+	s.recordActivityOf(id, models.ActivityUnlabeled, []models.FieldChange{{Field: "label_ids", From: []uint{labelID}}})
+	return nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// checkProject returns the project a task is being placed in, refusing
+// unknown and archived projects.
+func (s *TaskService) checkProject(projectID *uint) (*models.Project, error) {
+	project, err := s.projectOf(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project != nil && project.Archived {
+		return nil, ErrProjectArchived
+	}
+	return project, nil
+}
+
+func (s *TaskService) projectOf(projectID *uint) (*models.Project, error) {
+	if projectID == nil || s.Projects == nil {
+		return nil, nil
+	}
+
+	project, err := s.Projects.GetProjectByID(*projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return project, nil
+}
+
+func (s *TaskService) workflowFor(project *models.Project) (*models.Workflow, error) {
+	if project == nil || project.WorkflowID == nil || s.Workflows == nil {
+		return &DefaultWorkflow, nil
+	}
+
+	workflow, err := s.Workflows.GetWorkflowByID(*project.WorkflowID)
+	if err != nil {
+		return nil, ErrWorkflowNotFound
+	}
+	return workflow, nil
+}
+
+// applyStatus checks the task status against the workflow and updates the
+// fields derived from it.
+func applyStatus(workflow *models.Workflow, task *models.Task) error {
+	state, ok := findState(workflow, task.Status)
+	if !ok {
+		return invalidStatusError(workflow, task.Status)
+	}
+	task.StatusCategory = state.Category
+	stampCompletion(task)
+	return nil
+}
+
+// stampCompletion keeps CompletedAt in step with the task status. The
+// repository preserves an existing timestamp when the task stays completed.
+func stampCompletion(task *models.Task) {
+	if task.StatusCategory != models.StatusCategoryDone {
+		task.CompletedAt = nil
+		return
+	}
+	if task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
 }
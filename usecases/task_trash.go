@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"log"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+)
+
+// DefaultTrashRetention is how long deleted tasks stay in the trash before
+// RunTrashPurger removes them for good.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// GetTrash returns a page of deleted tasks, most recently deleted first.
+func (s *TaskService) GetTrash(page, limit int) ([]models.Task, int, error) {
+	return s.Repo.GetTrash(page, limit)
+}
+
+// RestoreTask takes a task out of the trash, with the subtasks deleted along
+// with it.
+func (s *TaskService) RestoreTask(id uint) (*models.Task, error) {
+	if err := s.Repo.RestoreTask(id); err != nil {
+		return nil, err
+	}
+	task, err := s.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.recordActivity(task, models.ActivityUndeleted, DiffTasks(nil, task), ChangeContext{})
+	return task, nil
+}
+
+// PurgeTask permanently deletes a task from the trash, along with its
+// comments and attachments.
+func (s *TaskService) PurgeTask(id uint) error {
+	task, err := s.Repo.GetTrashedTask(id)
+	if err != nil {
+		return err
+	}
+
+	var hashes []string
+	if s.Files != nil {
+		if hashes, err = s.Files.TaskFileHashes(id); err != nil {
+			return err
+		}
+	}
+	if err := s.Repo.PurgeTask(id); err != nil {
+		return err
+	}
+	s.recordActivity(task, models.ActivityPurged, []models.FieldChange{}, ChangeContext{})
+	if s.Files != nil {
+		s.Files.PruneFiles(hashes)
+	}
+	return nil
+}
+
+// EmptyTrash purges every task in the trash and returns how many were
+// purged.
+func (s *TaskService) EmptyTrash() (int, error) {
+	return s.PurgeExpiredTrash(time.Now(), 0)
+}
+
+// PurgeExpiredTrash purges the tasks that have been in the trash longer than
+// retention. A task that fails to purge is logged and skipped.
+func (s *TaskService) PurgeExpiredTrash(now time.Time, retention time.Duration) (int, error) {
+	ids, err := s.Repo.GetExpiredTrash(now.Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if err := s.PurgeTask(id); err != nil {
+			log.Printf("[X] Failed to purge task %d from the trash: %v\n", id, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// RunTrashPurger purges expired trash every interval until the process
+// exits.
+func (s *TaskService) RunTrashPurger(retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		purged, err := s.PurgeExpiredTrash(time.Now(), retention)
+		if err != nil {
+			log.Printf("[X] Trash purger failed: %v\n", err)
+			continue
+		}
+		if purged > 0 {
+			log.Printf("[V] Trash purger removed %d task(s)\n", purged)
+		}
+	}
+}
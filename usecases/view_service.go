@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+var ErrBuiltinView = errors.New("Built-in views cannot be modified")
+
+// BuiltinView is a read-only view every user gets. Its filter is relative,
+// so "Overdue" always means overdue as of today.
+type BuiltinView struct {
+	Key     string
+	Name    string
+	Filters models.TaskFilter
+	Sort    string
+}
+
+var builtinViews = []BuiltinView{
+	{Key: "overdue", Name: "Overdue", Filters: models.TaskFilter{Overdue: true}, Sort: "due_date"},
+	{Key: "due-this-week", Name: "Due this week", Filters: models.TaskFilter{DueWithinDays: 7}, Sort: "due_date"},
+	{Key: "completed-recently", Name: "Completed recently", Filters: models.TaskFilter{CompletedWithinDays: 7}, Sort: "-completed_at"},
+}
+
+type ViewService struct {
+	Repo  repositories.ViewRepository
+	Tasks *TaskService
+}
+
+func NewViewService(repo repositories.ViewRepository, tasks *TaskService) *ViewService {
+	return &ViewService{Repo: repo, Tasks: tasks}
+}
+
+// InWorkspace returns a copy of the service whose views run against one
+// workspace's tasks.
+func (s *ViewService) InWorkspace(workspaceID uint) *ViewService {
+	scoped := *s
+	scoped.Tasks = s.Tasks.InWorkspace(workspaceID)
+	return &scoped
+}
+
+func (s *ViewService) BuiltinViews() []BuiltinView {
+	return builtinViews
+}
+
+func (s *ViewService) GetBuiltinView(key string) (BuiltinView, bool) {
+	for _, view := range builtinViews {
+		if view.Key == key {
+			return view, true
+		}
+	}
+	return BuiltinView{}, false
+}
+
+func (s *ViewService) GetViews(userID uint) ([]models.SavedView, error) {
+	return s.Repo.GetViews(userID)
+}
+
+func (s *ViewService) CreateView(view *models.SavedView) error {
+	if !repositories.IsValidTaskSort(view.Sort) {
+		return ErrInvalidSort
+	}
+	return s.Repo.CreateView(view)
+}
+
+func (s *ViewService) GetViewByID(id, userID uint) (*models.SavedView, error) {
+	return s.Repo.GetViewByID(id, userID)
+}
+
+func (s *ViewService) UpdateView(id, userID uint, updatedView *models.SavedView) error {
+	if !repositories.IsValidTaskSort(updatedView.Sort) {
+		return ErrInvalidSort
+	}
+	return s.Repo.UpdateView(id, userID, updatedView)
+}
+
+func (s *ViewService) DeleteView(id, userID uint) error {
+	return s.Repo.DeleteView(id, userID)
+}
+
+// RunView returns one page of the tasks matched by a saved view.
+func (s *ViewService) RunView(view *models.SavedView, page, limit int) ([]models.Task, int, error) {
+	return s.Tasks.FindTasks(view.Filters, view.Sort, page, limit)
+}
+
+// RunBuiltinView returns one page of the tasks matched by a built-in view.
+func (s *ViewService) RunBuiltinView(view BuiltinView, page, limit int) ([]models.Task, int, error) {
+	return s.Tasks.FindTasks(view.Filters, view.Sort, page, limit)
+}
@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+)
+
+var (
+	ErrInvalidStatus        = errors.New("Invalid status")
+	ErrTransitionNotAllowed = errors.New("Transition not allowed")
+	ErrWorkflowNotFound     = errors.New("Workflow not found")
+)
+
+// DefaultWorkflow applies to tasks outside a project and to projects without
+// a workflow of their own.
+var DefaultWorkflow = models.Workflow{
+	Name: "Default",
+	States: []models.WorkflowState{
+		{Key: "pending", Name: "Pending", Category: models.StatusCategoryOpen},
+		{Key: "completed", Name: "Completed", Category: models.StatusCategoryDone},
+	},
+	Transitions: []models.WorkflowTransition{
+		{From: "pending", To: "completed"},
+		{From: "completed", To: "pending"},
+	},
+}
+
+type WorkflowService struct {
+	Repo repositories.WorkflowRepository
+}
+
+func NewWorkflowService(repo repositories.WorkflowRepository) *WorkflowService {
+	return &WorkflowService{Repo: repo}
+}
+
+// InWorkspace returns a copy of the service that only manages the workflows
+// of one workspace.
+func (s *WorkflowService) InWorkspace(workspaceID uint) *WorkflowService {
+	return &WorkflowService{Repo: s.Repo.InWorkspace(workspaceID)}
+}
+
+func (s *WorkflowService) GetWorkflows() ([]models.Workflow, error) {
+	return s.Repo.GetWorkflows()
+}
+
+func (s *WorkflowService) CreateWorkflow(workflow *models.Workflow) error {
+	return s.Repo.CreateWorkflow(workflow)
+}
+
+func (s *WorkflowService) GetWorkflowByID(id uint) (*models.Workflow, error) {
+	return s.Repo.GetWorkflowByID(id)
+}
+
+func (s *WorkflowService) UpdateWorkflow(id uint, updatedWorkflow *models.Workflow) error {
+	return s.Repo.UpdateWorkflow(id, updatedWorkflow)
+}
+
+func (s *WorkflowService) DeleteWorkflow(id uint) error {
+	return s.Repo.DeleteWorkflow(id)
+}
+
+func initialState(workflow *models.Workflow) models.WorkflowState {
+	return workflow.States[0]
+}
+
+func findState(workflow *models.Workflow, key string) (models.WorkflowState, bool) {
+	for _, state := range workflow.States {
+		if state.Key == key {
+			return state, true
+		}
+	}
+	return models.WorkflowState{}, false
+}
+
+// findStateInCategory returns the first state of the given category, used
+// when a task has to be moved without the caller naming a state.
+func findStateInCategory(workflow *models.Workflow, category string) (models.WorkflowState, bool) {
+	for _, state := range workflow.States {
+		if state.Category == category {
+			return state, true
+		}
+	}
+	return models.WorkflowState{}, false
+}
+
+// checkTransition validates a status change. A workflow without transitions
+// allows every move, and a task whose current status is not part of the
+// workflow (e.g. after moving projects) may move to any state.
+func checkTransition(workflow *models.Workflow, from, to string) error {
+	if _, ok := findState(workflow, to); !ok {
+		return invalidStatusError(workflow, to)
+	}
+	if _, ok := findState(workflow, from); !ok || len(workflow.Transitions) == 0 {
+		return nil
+	}
+	for _, transition := range workflow.Transitions {
+		if transition.From == from && transition.To == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w from '%s' to '%s'", ErrTransitionNotAllowed, from, to)
+}
+
+func invalidStatusError(workflow *models.Workflow, status string) error {
+	keys := make([]string, len(workflow.States))
+	for i, state := range workflow.States {
+		keys[i] = "'" + state.Key + "'"
+	}
+	return fmt.Errorf("%w '%s'. Use %s", ErrInvalidStatus, status, strings.Join(keys, ", "))
+}
@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yasseryazid/technical-test/models"
+	"github.com/yasseryazid/technical-test/repositories"
+	"github.com/yasseryazid/technical-test/utils"
+)
+
+// invitationTTL is how long an invitation token can be used.
+const invitationTTL = 7 * 24 * time.Hour
+
+var (
+	ErrWorkspaceNotFound  = errors.New("Workspace not found")
+	ErrWorkspaceForbidden = errors.New("Your role in this workspace does not allow this")
+	ErrWorkspaceNotEmpty  = errors.New("Workspace still has tasks")
+	ErrInvalidRole        = errors.New("Invalid role")
+	ErrOwnerMembership    = errors.New("The workspace owner cannot be removed or given another role")
+	ErrInvalidInvitation  = errors.New("Invitation is invalid, expired or already used")
+	ErrAlreadyMember      = errors.New("You are already a member of this workspace")
+	ErrNotWorkspaceMember = errors.New("User is not a member of this workspace")
+)
+
+type WorkspaceService struct {
+	Repo repositories.WorkspaceRepository
+}
+
+func NewWorkspaceService(repo repositories.WorkspaceRepository) *WorkspaceService {
+	return &WorkspaceService{Repo: repo}
+}
+
+// GetWorkspaces returns the user's memberships with their workspaces.
+func (s *WorkspaceService) GetWorkspaces(userID uint) ([]models.WorkspaceMember, error) {
+	return s.Repo.GetMemberships(userID)
+}
+
+// CreateWorkspace creates a workspace owned by ownerID.
+func (s *WorkspaceService) CreateWorkspace(workspace *models.Workspace, ownerID uint) error {
+	workspace.OwnerID = ownerID
+	return s.Repo.CreateWorkspace(workspace)
+}
+
+// CreatePersonalWorkspace gives a new user a workspace of their own, which
+// is where their tasks go until they pick another one.
+func (s *WorkspaceService) CreatePersonalWorkspace(user *models.User) error {
+	return s.CreateWorkspace(&models.Workspace{Name: user.Username + "'s workspace"}, user.ID)
+}
+
+// Membership returns userID's membership of a workspace. Workspaces the user
+// is not a member of are reported as not found.
+func (s *WorkspaceService) Membership(workspaceID, userID uint) (*models.WorkspaceMember, error) {
+	member, err := s.Repo.GetMember(workspaceID, userID)
+	if err != nil {
+		return nil, ErrWorkspaceNotFound
+	}
+	return member, nil
+}
+
+func (s *WorkspaceService) GetWorkspace(id, userID uint) (*models.Workspace, *models.WorkspaceMember, error) {
+	member, err := s.Membership(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	workspace, err := s.Repo.GetWorkspaceByID(id)
+	if err != nil {
+		return nil, nil, ErrWorkspaceNotFound
+	}
+	return workspace, member, nil
+}
+
+func (s *WorkspaceService) UpdateWorkspace(id, userID uint, updatedWorkspace *models.Workspace) error {
+	if _, err := s.manager(id, userID); err != nil {
+		return err
+	}
+	return s.Repo.UpdateWorkspace(id, updatedWorkspace)
+}
+
+// DeleteWorkspace deletes an empty workspace. Only its owner may do this.
+func (s *WorkspaceService) DeleteWorkspace(id, userID uint) error {
+	member, err := s.Membership(id, userID)
+	if err != nil {
+		return err
+	}
+	if err := requireRolePermission(member, models.PermWorkspaceDelete); err != nil {
+		return err
+	}
+
+	count, err := s.Repo.CountTasks(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrWorkspaceNotEmpty
+	}
+	return s.Repo.DeleteWorkspace(id)
+}
+
+func (s *WorkspaceService) GetMembers(id, userID uint) ([]models.WorkspaceMember, error) {
+	if _, err := s.Membership(id, userID); err != nil {
+		return nil, err
+	}
+	return s.Repo.GetMembers(id)
+}
+
+// UpdateMemberRole changes another member's role. Owners and admins may do
+// this; ownership itself cannot be handed out or taken away.
+func (s *WorkspaceService) UpdateMemberRole(id, userID, memberID uint, role string) error {
+	if err := validateMemberRole(role); err != nil {
+		return err
+	}
+	if _, err := s.manager(id, userID); err != nil {
+		return err
+	}
+
+	member, err := s.Repo.GetMember(id, memberID)
+	if err != nil {
+		return ErrNotWorkspaceMember
+	}
+	if member.Role == models.RoleOwner {
+		return ErrOwnerMembership
+	}
+	return s.Repo.UpdateMemberRole(id, memberID, role)
+}
+
+// RemoveMember removes a member from a workspace. Members may always leave;
+// removing someone else takes an owner or admin.
+func (s *WorkspaceService) RemoveMember(id, userID, memberID uint) error {
+	if memberID == userID {
+		if _, err := s.Membership(id, userID); err != nil {
+			return err
+		}
+	} else if _, err := s.manager(id, userID); err != nil {
+		return err
+	}
+
+	member, err := s.Repo.GetMember(id, memberID)
+	if err != nil {
+		return ErrNotWorkspaceMember
+	}
+	if member.Role == models.RoleOwner {
+		return ErrOwnerMembership
+	}
+	return s.Repo.RemoveMember(id, memberID)
+}
+
+// CreateInvitation creates an invitation to join with the given role and
+// returns it with its signed token.
+func (s *WorkspaceService) CreateInvitation(id, userID uint, role string) (*models.WorkspaceInvitation, string, error) {
+	if err := validateMemberRole(role); err != nil {
+		return nil, "", err
+	}
+	if _, err := s.manager(id, userID); err != nil {
+		return nil, "", err
+	}
+
+	invitation := &models.WorkspaceInvitation{
+		WorkspaceID: id,
+		Role:        role,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(invitationTTL),
+	}
+	if err := s.Repo.CreateInvitation(invitation); err != nil {
+		return nil, "", err
+	}
+
+	token, err := utils.GenerateInvitationToken(invitation.ID, id, invitation.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	return invitation, token, nil
+}
+
+// AcceptInvitation adds userID to the workspace the token invites to.
+func (s *WorkspaceService) AcceptInvitation(token string, userID uint) (*models.WorkspaceMember, error) {
+	invitationID, err := utils.ParseInvitationToken(token)
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+	invitation, err := s.Repo.GetInvitationByID(invitationID)
+	if err != nil || invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitation
+	}
+	if _, err := s.Repo.GetMember(invitation.WorkspaceID, userID); err == nil {
+		return nil, ErrAlreadyMember
+	}
+
+	member := &models.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      userID,
+		Role:        invitation.Role,
+	}
+	accepted, err := s.Repo.AcceptInvitation(invitation, member)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, ErrInvalidInvitation
+	}
+	return member, nil
+}
+
+// manager returns userID's membership if it lets them manage the workspace.
+func (s *WorkspaceService) manager(id, userID uint) (*models.WorkspaceMember, error) {
+	member, err := s.Membership(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRolePermission(member, models.PermWorkspaceManage); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// requireRolePermission returns ErrWorkspaceForbidden, naming the missing
+// permission, unless the member's role grants it.
+func requireRolePermission(member *models.WorkspaceMember, permission string) error {
+	if !models.RoleHasPermission(member.Role, permission) {
+		return fmt.Errorf("%w: missing permission '%s'", ErrWorkspaceForbidden, permission)
+	}
+	return nil
+}
+
+// validateMemberRole checks a role that can be given to a member. There is
+// one owner per workspace, so "owner" is not one of them.
+func validateMemberRole(role string) error {
+	for _, valid := range models.WorkspaceRoles {
+		if role == valid && role != models.RoleOwner {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w '%s'. Use admin, member or viewer", ErrInvalidRole, role)
+}
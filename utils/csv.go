@@ -0,0 +1,32 @@
+package utils
+
+import "strings"
+
+// formulaPrefixes start cells that spreadsheets run as formulas when a CSV
+// file is opened.
+const formulaPrefixes = "=+-@\t\r"
+
+// EscapeCSVCell prefixes a cell that a spreadsheet would read as a formula
+// with a single quote, so it is shown as text instead. Cells that already
+// look escaped get a quote too, so UnescapeCSVCell gives back every value.
+func EscapeCSVCell(value string) string {
+	if value == "" {
+		return value
+	}
+	if strings.IndexByte(formulaPrefixes, value[0]) >= 0 || isEscapedCSVCell(value) {
+		return "'" + value
+	}
+	return value
+}
+
+// UnescapeCSVCell removes the quote EscapeCSVCell added.
+func UnescapeCSVCell(value string) string {
+	if isEscapedCSVCell(value) {
+		return value[1:]
+	}
+	return value
+}
+
+func isEscapedCSVCell(value string) bool {
+	return len(value) > 1 && value[0] == '\'' && strings.IndexByte(formulaPrefixes+"'", value[1]) >= 0
+}
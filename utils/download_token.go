@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const downloadPurpose = "attachment_download"
+
+// GenerateDownloadToken signs a link to one attachment that works without a
+// session until expiresAt.
+func GenerateDownloadToken(attachmentID uint, expiresAt time.Time) (string, error) {
+	return signPurposeToken(downloadPurpose, jwt.MapClaims{"attachment_id": attachmentID}, expiresAt)
+}
+
+// ParseDownloadToken checks a download token's signature and expiry and
+// returns the attachment ID it was issued for.
+func ParseDownloadToken(tokenString string) (uint, error) {
+	return parsePurposeToken(tokenString, downloadPurpose, "attachment_id")
+}
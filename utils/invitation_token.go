@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const invitationPurpose = "workspace_invitation"
+
+// GenerateInvitationToken signs an invitation so it can be shared as a link.
+// The invitation row decides whether it can still be used.
+func GenerateInvitationToken(invitationID, workspaceID uint, expiresAt time.Time) (string, error) {
+	return signPurposeToken(invitationPurpose, jwt.MapClaims{
+		"invitation_id": invitationID,
+		"workspace_id":  workspaceID,
+	}, expiresAt)
+}
+
+// ParseInvitationToken checks an invitation token's signature and expiry and
+// returns the invitation ID it was issued for.
+func ParseInvitationToken(tokenString string) (uint, error) {
+	return parsePurposeToken(tokenString, invitationPurpose, "invitation_id")
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/yasseryazid/technical-test/config"
 )
 
-func GenerateJWT(userID uint, username string) (string, error) {
+func GenerateJWT(userID uint, username string, permissions []string) (string, error) {
 	secretKey := os.Getenv("JWT_SECRET")
 	if secretKey == "" {
 		return "", errors.New("JWT_SECRET not set in environment")
@@ -19,9 +19,10 @@ func GenerateJWT(userID uint, username string) (string, error) {
 	expirationTime := time.Now().Add(24 * time.Hour).Unix() // Berlaku 24 jam
 
 	claims := jwt.MapClaims{
-		"user_id":  userID,
-		"username": username,
-		"exp":      expirationTime,
+		"user_id":     userID,
+		"username":    username,
+		"permissions": permissions,
+		"exp":         expirationTime,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -75,3 +76,48 @@ func LogoutJWT(tokenString string) error {
 	err := config.RedisClient.Del(ctx, tokenString).Err()
 	return err
 }
+
+// signPurposeToken signs a token for one purpose, such as an invitation
+// link, that carries a single ID under idClaim. Unlike session tokens it is
+// not stored in Redis, so ValidateJWT never accepts it.
+func signPurposeToken(purpose string, claims jwt.MapClaims, expiresAt time.Time) (string, error) {
+	secretKey := os.Getenv("JWT_SECRET")
+	if secretKey == "" {
+		return "", errors.New("JWT_SECRET not set in environment")
+	}
+
+	claims["purpose"] = purpose
+	claims["exp"] = expiresAt.Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secretKey))
+}
+
+// parsePurposeToken checks a purpose token's signature, expiry and purpose
+// and returns the ID under idClaim.
+func parsePurposeToken(tokenString, purpose, idClaim string) (uint, error) {
+	secretKey := os.Getenv("JWT_SECRET")
+	if secretKey == "" {
+		return 0, errors.New("JWT_SECRET not set in environment")
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(secretKey), nil
+	})
+	if err != nil || !token.Valid {
+		return 0, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != purpose {
+		return 0, errors.New("invalid token claims")
+	}
+	id, ok := claims[idClaim].(float64)
+	if !ok || id <= 0 {
+		return 0, errors.New("invalid token claims")
+	}
+	return uint(id), nil
+}
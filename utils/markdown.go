@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletPattern      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	numberedPattern    = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	linkPattern        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern        = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	italicPattern      = regexp.MustCompile(`\*([^*\s][^*]*?)\*`)
+	strikePattern      = regexp.MustCompile(`~~(.+?)~~`)
+	mentionPattern     = regexp.MustCompile(`(^|[^\w@])@([A-Za-z0-9_](?:[A-Za-z0-9_.-]*[A-Za-z0-9_])?)`)
+	placeholderPattern = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// RenderMarkdown renders the Markdown used in comments to HTML: paragraphs,
+// headings, lists, block quotes, fenced code, inline code, bold, italics,
+// strikethrough, links and @mentions. Raw HTML in the source is escaped
+// rather than passed through and links are limited to http, https and
+// mailto, so the result is safe to embed in a page.
+func RenderMarkdown(source string) string {
+	// NUL bytes would be mistaken for the placeholders renderInline uses.
+	source = strings.ReplaceAll(source, "\x00", "")
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	var out strings.Builder
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			i++
+			var code []string
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // closing fence
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			level := len(match[1])
+			fmt.Fprintf(&out, "<h%d>%s</h%d>\n", level, renderInline(match[2]), level)
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, renderInline(strings.TrimSpace(text)))
+				i++
+			}
+			out.WriteString("<blockquote><p>" + strings.Join(quoted, "<br>\n") + "</p></blockquote>\n")
+
+		case bulletPattern.MatchString(line):
+			i = renderList(&out, lines, i, bulletPattern, "ul")
+
+		case numberedPattern.MatchString(line):
+			i = renderList(&out, lines, i, numberedPattern, "ol")
+
+		default:
+			var paragraph []string
+			for i < len(lines) && isParagraphLine(lines[i]) {
+				paragraph = append(paragraph, renderInline(strings.TrimSpace(lines[i])))
+				i++
+			}
+			out.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// ParseMentions returns the usernames mentioned as @username in text, each
+// once, in the order they first appear.
+func ParseMentions(text string) []string {
+	var usernames []string
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := match[2]
+		if !seen[username] {
+			seen[username] = true
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
+
+func renderList(out *strings.Builder, lines []string, i int, pattern *regexp.Regexp, tag string) int {
+	out.WriteString("<" + tag + ">\n")
+	for i < len(lines) && pattern.MatchString(lines[i]) {
+		item := pattern.FindStringSubmatch(lines[i])[1]
+		out.WriteString("<li>" + renderInline(item) + "</li>\n")
+		i++
+	}
+	out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// isParagraphLine reports whether a line continues a paragraph rather than
+// starting another block.
+func isParagraphLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" &&
+		!strings.HasPrefix(trimmed, "```") &&
+		!strings.HasPrefix(trimmed, ">") &&
+		!headingPattern.MatchString(trimmed) &&
+		!bulletPattern.MatchString(line) &&
+		!numberedPattern.MatchString(line)
+}
+
+// renderInline renders the inline syntax of one line. Code spans and links
+// are rendered first and swapped for placeholders, so emphasis markers inside
+// them are left alone.
+func renderInline(text string) string {
+	var rendered []string
+	hold := func(fragment string) string {
+		rendered = append(rendered, fragment)
+		return fmt.Sprintf("\x00%d\x00", len(rendered)-1)
+	}
+
+	var withoutCode strings.Builder
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			withoutCode.WriteString(hold("<code>" + html.EscapeString(part) + "</code>"))
+		case i%2 == 1:
+			// An unclosed backtick is literal.
+			withoutCode.WriteString("`" + html.EscapeString(part))
+		default:
+			withoutCode.WriteString(html.EscapeString(part))
+		}
+	}
+
+	result := linkPattern.ReplaceAllStringFunc(withoutCode.String(), func(match string) string {
+		parts := linkPattern.FindStringSubmatch(match)
+		label, href := parts[1], parts[2]
+		if !isSafeURL(html.UnescapeString(href)) {
+			return label
+		}
+		return hold(`<a href="` + href + `" rel="nofollow noopener">` + label + `</a>`)
+	})
+	result = boldPattern.ReplaceAllString(result, "<strong>$1</strong>")
+	result = italicPattern.ReplaceAllString(result, "<em>$1</em>")
+	result = strikePattern.ReplaceAllString(result, "<del>$1</del>")
+	result = mentionPattern.ReplaceAllString(result, `$1<span class="mention">@$2</span>`)
+
+	// Links may hold code spans, so restore until nothing is left.
+	for placeholderPattern.MatchString(result) {
+		result = placeholderPattern.ReplaceAllStringFunc(result, func(match string) string {
+			index, _ := strconv.Atoi(placeholderPattern.FindStringSubmatch(match)[1])
+			return rendered[index]
+		})
+	}
+	return result
+}
+
+func isSafeURL(href string) bool {
+	lower := strings.ToLower(href)
+	return strings.HasPrefix(lower, "http://") ||
+		strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "mailto:")
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// nonPublicPrefixes are the special-purpose ranges (RFC 6890) that outgoing
+// requests made on a user's behalf must never reach, on top of loopback,
+// private and link-local addresses.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// IsPublicIP reports whether ip is a globally routable unicast address, so
+// the server may connect to it for a user, e.g. to deliver a webhook.
+func IsPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublicHost reports whether host may be a public server. Names are only
+// rejected when they are local by definition; where they resolve to is
+// checked when connecting.
+func IsPublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return IsPublicIP(ip)
+	}
+	return host != "" && host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
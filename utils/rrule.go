@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is the subset of an RFC 5545 recurrence rule supported for tasks:
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, BYDAY, BYMONTHDAY, COUNT
+// and UNTIL. Rules work on dates; times of day are ignored.
+type RRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []RRuleDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+// RRuleDay is a BYDAY entry. Ordinal is 0 for "every", otherwise the n-th
+// (or, when negative, n-th last) such weekday of the month.
+type RRuleDay struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// rruleHorizon limits how far ahead Next searches for an occurrence.
+const rruleHorizon = 10 * 366
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10". A
+// leading "RRULE:" is accepted.
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("recurrence rule is empty")
+	}
+
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("malformed rule part '%s'", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(val)
+			switch rule.Freq {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+			default:
+				return nil, fmt.Errorf("unsupported FREQ '%s'", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL '%s'", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT '%s'", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleDate(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL '%s'", val)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, entry := range strings.Split(strings.ToUpper(val), ",") {
+				day, err := parseRRuleDay(entry)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, entry := range strings.Split(val, ",") {
+				monthDay, err := strconv.Atoi(entry)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, fmt.Errorf("invalid BYMONTHDAY '%s'", entry)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				return nil, errors.New("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part '%s'", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot be combined")
+	}
+	return rule, nil
+}
+
+func parseRRuleDate(value string) (time.Time, error) {
+	if len(value) >= 8 {
+		if date, err := time.Parse("20060102", value[:8]); err == nil {
+			return date, nil
+		}
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func parseRRuleDay(entry string) (RRuleDay, error) {
+	if len(entry) < 2 {
+		return RRuleDay{}, fmt.Errorf("invalid BYDAY '%s'", entry)
+	}
+
+	weekday, ok := rruleWeekdays[entry[len(entry)-2:]]
+	if !ok {
+		return RRuleDay{}, fmt.Errorf("invalid BYDAY '%s'", entry)
+	}
+
+	day := RRuleDay{Weekday: weekday}
+	if prefix := entry[:len(entry)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RRuleDay{}, fmt.Errorf("invalid BYDAY '%s'", entry)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+// Next returns the first occurrence strictly after the given date for a
+// series starting on start that already has occurred times. The second
+// result is false once the series is over.
+func (r *RRule) Next(start, after time.Time, occurred int) (time.Time, bool) {
+	if r.Count > 0 && occurred >= r.Count {
+		return time.Time{}, false
+	}
+
+	start = truncateDay(start)
+	day := truncateDay(after).AddDate(0, 0, 1)
+	if day.Before(start) {
+		day = start
+	}
+
+	for i := 0; i < rruleHorizon; i++ {
+		if r.Until != nil && day.After(*r.Until) {
+			return time.Time{}, false
+		}
+		if r.matches(start, day) {
+			return day, true
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+func (r *RRule) matches(start, day time.Time) bool {
+	switch r.Freq {
+	case "DAILY":
+		if daysBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesByDay(day, false) && r.matchesByMonthDay(day)
+	case "WEEKLY":
+		if daysBetween(weekStart(start), weekStart(day))/7%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.matchesByDay(day, false)
+	case "MONTHLY":
+		if monthsBetween(start, day)%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		return r.matchesByDay(day, true) && r.matchesByMonthDay(day)
+	case "YEARLY":
+		if (day.Year()-start.Year())%r.Interval != 0 || day.Month() != start.Month() {
+			return false
+		}
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		return r.matchesByDay(day, true) && r.matchesByMonthDay(day)
+	}
+	return false
+}
+
+func (r *RRule) matchesByDay(day time.Time, useOrdinal bool) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday != day.Weekday() {
+			continue
+		}
+		if byDay.Ordinal == 0 || !useOrdinal {
+			return true
+		}
+		if byDay.Ordinal > 0 && (day.Day()-1)/7+1 == byDay.Ordinal {
+			return true
+		}
+		if byDay.Ordinal < 0 && (daysInMonth(day)-day.Day())/7+1 == -byDay.Ordinal {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RRule) matchesByMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay > 0 && day.Day() == monthDay {
+			return true
+		}
+		if monthDay < 0 && day.Day() == daysInMonth(day)+monthDay+1 {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// weekStart returns the Monday of the week containing t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}